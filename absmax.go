@@ -0,0 +1,103 @@
+package float16
+
+import "math"
+
+// Magnitude reductions for scaling-factor computation
+//
+// These functions compare magnitudes on the raw bit patterns: once the sign
+// bit is cleared, the ordering of finite values and infinity is the same as the
+// unsigned ordering of their encodings, so no conversion is needed.
+
+// InfPolicy controls how infinities are treated by magnitude reductions
+type InfPolicy int
+
+const (
+	// InfInclude treats infinities as ordinary values of infinite magnitude
+	InfInclude InfPolicy = iota
+	// InfSkip ignores infinities in the same way as NaNs
+	InfSkip
+	// InfError reports an error when an infinity is encountered
+	InfError
+)
+
+// AbsMax returns the largest magnitude in s in a single pass. NaNs are
+// skipped and infinities are included. It returns PositiveZero if s is empty or
+// contains only NaNs.
+func AbsMax(s []Float16) Float16 {
+	var maxAbs uint16
+	for _, v := range s {
+		a := uint16(v) &^ SignMask
+		if a > uint16(PositiveInfinity) {
+			continue // NaN
+		}
+		if a > maxAbs {
+			maxAbs = a
+		}
+	}
+	return Float16(maxAbs)
+}
+
+// MinMaxAbs returns the smallest and largest magnitudes in s in a single pass.
+// NaNs are skipped and infinities are included. It returns an error if s
+// contains no non-NaN values.
+func MinMaxAbs(s []Float16) (minAbs, maxAbs Float16, err error) {
+	minAbs, maxAbs, _, err = MinMaxAbsWithPolicy(s, InfInclude)
+	return minAbs, maxAbs, err
+}
+
+// MinMaxAbsWithPolicy is like MinMaxAbs but applies the given infinity policy
+// and also returns the number of NaNs that were skipped.
+func MinMaxAbsWithPolicy(s []Float16, policy InfPolicy) (minAbs, maxAbs Float16, nanCount int, err error) {
+	lo := uint16(0xFFFF)
+	hi := uint16(0)
+	for _, v := range s {
+		a := uint16(v) &^ SignMask
+		if a > uint16(PositiveInfinity) {
+			nanCount++
+			continue
+		}
+		if a == uint16(PositiveInfinity) {
+			switch policy {
+			case InfSkip:
+				continue
+			case InfError:
+				return 0, 0, nanCount, &Float16Error{
+					Op:   "MinMaxAbs",
+					Msg:  "infinite value in input",
+					Code: ErrInfinity,
+				}
+			}
+		}
+		if a < lo {
+			lo = a
+		}
+		if a > hi {
+			hi = a
+		}
+	}
+	if lo > hi {
+		return 0, 0, nanCount, &Float16Error{
+			Op:   "MinMaxAbs",
+			Msg:  "no values to reduce",
+			Code: ErrInvalidOperation,
+		}
+	}
+	return Float16(lo), Float16(hi), nanCount, nil
+}
+
+// AbsMax32 returns the largest magnitude in a float32 slice, skipping NaNs.
+// It is intended for computing a scale factor before conversion to Float16.
+// It returns 0 if s is empty or contains only NaNs.
+func AbsMax32(s []float32) float32 {
+	var maxAbs uint32
+	for _, v := range s {
+		a := math.Float32bits(v) &^ (1 << 31)
+		if a > 0x7F800000 {
+			continue // NaN
+		}
+		if a > maxAbs {
+			maxAbs = a
+		}
+	}
+	return math.Float32frombits(maxAbs)
+}
@@ -0,0 +1,148 @@
+package float16
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// allFloat16 returns every 16-bit pattern in order
+func allFloat16() []Float16 {
+	s := make([]Float16, 1<<16)
+	for i := range s {
+		s[i] = Float16(i)
+	}
+	return s
+}
+
+// refMinMaxAbs computes the magnitude range in float64, skipping NaNs and
+// optionally infinities
+func refMinMaxAbs(s []Float16, skipInf bool) (lo, hi float64, nans int) {
+	lo, hi = math.Inf(1), -1
+	for _, v := range s {
+		f := v.ToFloat64()
+		if math.IsNaN(f) {
+			nans++
+			continue
+		}
+		if skipInf && math.IsInf(f, 0) {
+			continue
+		}
+		a := math.Abs(f)
+		lo = math.Min(lo, a)
+		hi = math.Max(hi, a)
+	}
+	return lo, hi, nans
+}
+
+func TestMinMaxAbsExhaustive(t *testing.T) {
+	all := allFloat16()
+
+	// Sliding windows over every bit pattern exercise all sign/exponent mixes
+	for start := 0; start < len(all); start += 251 {
+		end := min(start+997, len(all))
+		s := all[start:end]
+		for _, policy := range []InfPolicy{InfInclude, InfSkip} {
+			lo, hi, nans := refMinMaxAbs(s, policy == InfSkip)
+			gotLo, gotHi, gotNaNs, err := MinMaxAbsWithPolicy(s, policy)
+			if hi < 0 {
+				if err == nil {
+					t.Fatalf("[%d:%d] policy %d: expected error for NaN-only input", start, end, policy)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("[%d:%d] policy %d: unexpected error %v", start, end, policy, err)
+			}
+			if gotLo.ToFloat64() != lo || gotHi.ToFloat64() != hi || gotNaNs != nans {
+				t.Fatalf("[%d:%d] policy %d: got (%v, %v, %d), want (%v, %v, %d)",
+					start, end, policy, gotLo, gotHi, gotNaNs, lo, hi, nans)
+			}
+			if policy == InfInclude && AbsMax(s).ToFloat64() != hi {
+				t.Fatalf("[%d:%d] AbsMax = %v, want %v", start, end, AbsMax(s), hi)
+			}
+		}
+	}
+}
+
+func TestMinMaxAbsSpecialCases(t *testing.T) {
+	s := []Float16{FromFloat32(-3), QuietNaN, FromFloat32(0.5), NegativeInfinity, NegativeZero}
+
+	lo, hi, err := MinMaxAbs(s)
+	if err != nil || lo != PositiveZero || hi != PositiveInfinity {
+		t.Errorf("MinMaxAbs = (%v, %v, %v), want (0, +Inf, nil)", lo, hi, err)
+	}
+
+	lo, hi, nans, err := MinMaxAbsWithPolicy(s, InfSkip)
+	if err != nil || lo != PositiveZero || hi != FromFloat32(3) || nans != 1 {
+		t.Errorf("InfSkip = (%v, %v, %d, %v), want (0, 3, 1, nil)", lo, hi, nans, err)
+	}
+
+	_, _, _, err = MinMaxAbsWithPolicy(s, InfError)
+	var fe *Float16Error
+	if !errors.As(err, &fe) || fe.Code != ErrInfinity {
+		t.Errorf("InfError: err = %v, want ErrInfinity", err)
+	}
+
+	if _, _, err := MinMaxAbs(nil); err == nil {
+		t.Error("expected error for empty slice")
+	}
+	if _, _, err := MinMaxAbs([]Float16{QuietNaN, NegativeQNaN}); err == nil {
+		t.Error("expected error for NaN-only slice")
+	}
+	if got := AbsMax(nil); got != PositiveZero {
+		t.Errorf("AbsMax(nil) = %v, want 0", got)
+	}
+	if got := AbsMax(s); got != PositiveInfinity {
+		t.Errorf("AbsMax = %v, want +Inf", got)
+	}
+}
+
+func TestAbsMax32(t *testing.T) {
+	nan := float32(math.NaN())
+	tests := []struct {
+		name string
+		in   []float32
+		want float32
+	}{
+		{"empty", nil, 0},
+		{"NaN only", []float32{nan}, 0},
+		{"mixed", []float32{1, -7.5, nan, 3}, 7.5},
+		{"negative zero", []float32{float32(math.Copysign(0, -1))}, 0},
+		{"infinity", []float32{2, float32(math.Inf(-1))}, float32(math.Inf(1))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AbsMax32(tt.in)
+			if got != tt.want || math.Signbit(float64(got)) {
+				t.Errorf("AbsMax32 = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func benchmarkAbsInput() []Float16 {
+	s := make([]Float16, 4096)
+	for i := range s {
+		s[i] = FromFloat32(float32(i%311) - 155.25)
+	}
+	return s
+}
+
+func BenchmarkMinMaxAbs(b *testing.B) {
+	s := benchmarkAbsInput()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = MinMaxAbs(s)
+	}
+}
+
+func BenchmarkMinMaxAbsComposed(b *testing.B) {
+	s := benchmarkAbsInput()
+	for i := 0; i < b.N; i++ {
+		abs := make([]Float16, len(s))
+		for j, v := range s {
+			abs[j] = Abs(v)
+		}
+		_ = ComputeSliceStats(abs)
+	}
+}
@@ -0,0 +1,82 @@
+package float16
+
+import "math"
+
+// Absorption
+//
+// A running sum stops changing once the terms added to it fall below half
+// the spacing of Float16 values around it. Absorbs and AbsorptionThreshold
+// answer that from the exponent of the sum alone, so accumulation over data
+// sorted by decreasing magnitude can stop as soon as the next term is
+// absorbed. Both assume the default round-to-nearest-even arithmetic.
+
+// spacings returns the exponents of the gaps between the finite nonzero
+// magnitude m and its neighbours: up toward larger magnitudes and down toward
+// zero. Only the smallest magnitude of a binade has a smaller gap below it.
+func spacings(m Float16) (up, down int) {
+	exp := int(m >> MantissaLen)
+	up = max(exp, 1) - ExponentBias - MantissaLen
+	down = up
+	if m&MantissaMask == 0 && exp > 1 {
+		down--
+	}
+	return up, down
+}
+
+// Absorbs reports whether Add(sum, x) returns sum unchanged, bit for bit,
+// without performing the addition. A nonzero finite sum absorbs x when |x| is
+// below half the gap to the neighbour of sum in the direction of x. At exactly
+// half the gap the sum is a tie and round-to-nearest-even keeps sum only when
+// its last significand bit is even: Absorbs(2048, 1) is true but
+// Absorbs(2050, 1) is false, as 2051 rounds up to 2052. An infinite sum
+// absorbs every finite x and the same infinity, +0 absorbs both zeros but -0
+// only itself, as -0 + +0 is +0, and NaN sums and terms are never absorbed.
+func Absorbs(sum, x Float16) bool {
+	switch {
+	case sum.IsNaN() || x.IsNaN():
+		return false
+	case sum.IsInf(0):
+		return !x.IsInf(0) || x == sum
+	case x.IsInf(0):
+		return false
+	case sum.IsZero():
+		return x == sum || sum == PositiveZero && x.IsZero()
+	case x.IsZero():
+		return true
+	}
+
+	m := sum &^ SignMask
+	up, down := spacings(m)
+	gap := up
+	if x.Signbit() != sum.Signbit() {
+		gap = down
+	}
+	// Float16 magnitudes are exact in float64, and so is the power of two
+	half := math.Ldexp(1, gap-1)
+	ax := x.Abs().ToFloat64()
+	return ax < half || (ax == half && m&1 == 0)
+}
+
+// AbsorptionThreshold returns the largest magnitude t such that Absorbs(sum,
+// x) holds for every x with |x| <= t, whatever its sign and with no reliance
+// on ties. Terms above t may still be absorbed, depending on their sign and
+// on tie-breaking. The threshold is 0 for zero and subnormal sums, whose
+// neighbours are only one step of 2^-24 away, MaxValue for an infinite sum
+// and NaN for a NaN sum.
+func AbsorptionThreshold(sum Float16) Float16 {
+	switch {
+	case sum.IsNaN():
+		return defaultNaN()
+	case sum.IsInf(0):
+		return MaxValue
+	case sum.IsZero():
+		return PositiveZero
+	}
+	_, down := spacings(sum &^ SignMask)
+	half := math.Ldexp(1, down-1)
+	if half <= SmallestSubnormal.ToFloat64() {
+		return PositiveZero
+	}
+	// The largest Float16 strictly below the power of two half
+	return FromFloat64(half) - 1
+}
@@ -0,0 +1,101 @@
+package float16
+
+import "testing"
+
+func TestAbsorbs(t *testing.T) {
+	tests := []struct {
+		sum, x Float16
+		want   bool
+	}{
+		{FromFloat32(2048), One16, true},  // tie, 2048 has an even significand
+		{FromFloat32(2050), One16, false}, // tie, 2051 rounds up to 2052
+		{FromFloat32(2048), negOne16, false},
+		{FromFloat32(2048), FromFloat32(-0.5), true},
+		{One16, FromFloat32(0x1p-11), true},
+		{One16, FromFloat32(-0x1p-11), false}, // the gap below 1 is 2^-11
+		{One16, FromFloat32(-0x1p-12), true},
+		{MaxValue, FromFloat32(16), false}, // rounds to +Inf
+		{MaxValue, FromFloat32(15.9921875), true},
+		{One16, NegativeZero, true},
+		{PositiveZero, PositiveZero, true},
+		{PositiveZero, NegativeZero, true},
+		{NegativeZero, PositiveZero, false},
+		{SmallestSubnormal, SmallestSubnormal, false},
+		{PositiveInfinity, MaxValue, true},
+		{PositiveInfinity, PositiveInfinity, true},
+		{PositiveInfinity, NegativeInfinity, false},
+		{MaxValue, PositiveInfinity, false},
+		{QuietNaN, One16, false},
+		{One16, QuietNaN, false},
+	}
+	for _, tt := range tests {
+		if got := Absorbs(tt.sum, tt.x); got != tt.want {
+			t.Errorf("Absorbs(%v, %v) = %v, want %v", tt.sum, tt.x, got, tt.want)
+		}
+	}
+}
+
+// TestAbsorbsGrid compares Absorbs with the addition itself for every x
+// against sums from every exponent, with mantissas at both ends of the binade
+// and of both parities, and both signs
+func TestAbsorbsGrid(t *testing.T) {
+	mantissas := []Float16{0, 1, 2, 0x155, 0x2AA, 0x3FE, 0x3FF}
+	for exp := Float16(0); exp <= 0x1F; exp++ {
+		for _, mant := range mantissas {
+			for _, sign := range []Float16{0, SignMask} {
+				sum := sign | exp<<MantissaLen | mant
+				for i := range 1 << 16 {
+					x := Float16(i)
+					want := !sum.IsNaN() && Add(sum, x) == sum
+					if got := Absorbs(sum, x); got != want {
+						t.Fatalf("Absorbs(%#04x, %#04x) = %v, Add gives %#04x", uint16(sum), uint16(x), got, uint16(Add(sum, x)))
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestAbsorptionThreshold(t *testing.T) {
+	tests := []struct {
+		sum, want Float16
+	}{
+		{One16, FromFloat32(0x1.ffcp-13)}, // just below half the 2^-11 gap under 1
+		{FromFloat32(1.5), FromFloat32(0x1.ffcp-12)},
+		{FromFloat32(-2048), FromFloat32(0.49975586)},
+		{MaxValue, FromFloat32(15.9921875)},
+		{SmallestNormal, PositiveZero},
+		{SmallestSubnormal, PositiveZero},
+		{NegativeZero, PositiveZero},
+		{PositiveInfinity, MaxValue},
+	}
+	for _, tt := range tests {
+		if got := AbsorptionThreshold(tt.sum); got != tt.want {
+			t.Errorf("AbsorptionThreshold(%v) = %v, want %v", tt.sum, got, tt.want)
+		}
+	}
+	if !AbsorptionThreshold(QuietNaN).IsNaN() {
+		t.Error("AbsorptionThreshold(NaN) is not NaN")
+	}
+
+	// Every finite sum absorbs both signs of its threshold, and the next
+	// magnitude up, a power of two, is rejected in at least one direction
+	// unless it is a tie that an even significand keeps
+	for i := range 1 << 16 {
+		sum := Float16(i)
+		if sum.IsNaN() || sum.IsInf(0) {
+			continue
+		}
+		th := AbsorptionThreshold(sum)
+		if th != 0 && (Add(sum, th) != sum || Add(sum, th.Neg()) != sum) {
+			t.Fatalf("AbsorptionThreshold(%#04x) = %v is not absorbed", i, th)
+		}
+		next := th + 1
+		if sum.IsZero() || sum&1 == 0 {
+			continue
+		}
+		if Add(sum, next) == sum && Add(sum, next.Neg()) == sum {
+			t.Fatalf("AbsorptionThreshold(%#04x) = %v, but %v is also absorbed", i, th, next)
+		}
+	}
+}
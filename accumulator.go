@@ -0,0 +1,112 @@
+package float16
+
+import (
+	"fmt"
+	"math"
+)
+
+// DotAccumulator computes a dot product incrementally from (a, b) pairs. The
+// product of two Float16 values is exact in float64, so the only rounding
+// happens in the running float64 sum, which can optionally carry a
+// compensation term. Accumulators built over disjoint shards of the input can
+// be combined with Merge. The zero value is a ready-to-use uncompensated
+// accumulator.
+type DotAccumulator struct {
+	sum         float64
+	comp        float64
+	compensated bool
+}
+
+// NewDotAccumulator returns an empty accumulator. When compensated is true
+// the running sum uses Neumaier's variant of Kahan summation, which makes the
+// result independent of the order pairs are added in for all practical
+// Float16 input.
+func NewDotAccumulator(compensated bool) *DotAccumulator {
+	return &DotAccumulator{compensated: compensated}
+}
+
+// Add accumulates a*b.
+func (d *DotAccumulator) Add(a, b Float16) {
+	d.add(a.ToFloat64() * b.ToFloat64())
+}
+
+// AddSlices accumulates the products of corresponding elements of a and b. It
+// returns an error and leaves the accumulator unchanged if the lengths differ.
+func (d *DotAccumulator) AddSlices(a, b []Float16) error {
+	if len(a) != len(b) {
+		return &Float16Error{
+			Op:   "DotAccumulator.AddSlices",
+			Msg:  fmt.Sprintf("slice length mismatch: %d and %d", len(a), len(b)),
+			Code: ErrInvalidOperation,
+		}
+	}
+	for i := range a {
+		d.Add(a[i], b[i])
+	}
+	return nil
+}
+
+// Merge adds the running sum of other into d. other is not modified.
+func (d *DotAccumulator) Merge(other *DotAccumulator) {
+	d.add(other.sum)
+	d.comp += other.comp
+}
+
+// Reset clears the running sum, keeping the compensation setting.
+func (d *DotAccumulator) Reset() {
+	d.sum, d.comp = 0, 0
+}
+
+// Result returns the accumulated dot product rounded once to Float16.
+func (d *DotAccumulator) Result() Float16 {
+	if math.IsNaN(d.sum) {
+		return defaultNaN()
+	}
+	return FromFloat32(roundToOdd64(d.sum, d.comp))
+}
+
+// ResultFloat32 returns the accumulated dot product rounded to float32.
+func (d *DotAccumulator) ResultFloat32() float32 {
+	return float32(d.sum + d.comp)
+}
+
+func (d *DotAccumulator) add(x float64) {
+	if !d.compensated {
+		d.sum += x
+		return
+	}
+	s := d.sum + x
+	if math.IsInf(s, 0) || math.IsNaN(s) {
+		// The error term of an infinite sum is NaN; let the sum speak alone
+		d.sum, d.comp = s, 0
+		return
+	}
+	if math.Abs(d.sum) >= math.Abs(x) {
+		d.comp += (d.sum - s) + x
+	} else {
+		d.comp += (x - s) + d.sum
+	}
+	d.sum = s
+}
+
+// roundToOdd64 rounds the unevaluated sum hi+lo to float32 with round-to-odd,
+// so that a following rounding to Float16 is correctly rounded.
+func roundToOdd64(hi, lo float64) float32 {
+	if math.IsInf(hi, 0) {
+		return float32(hi)
+	}
+	hi, lo = hi+lo, lo-((hi+lo)-hi)
+	f := float32(hi)
+	if math.IsInf(float64(f), 0) {
+		return f
+	}
+	// The difference between hi and its nearest float32 is exact in float64
+	rest := (hi - float64(f)) + lo
+	if rest == 0 || math.Float32bits(f)&1 == 1 {
+		return f
+	}
+	if rest > 0 {
+		return math.Nextafter32(f, float32(math.Inf(1)))
+	}
+	return math.Nextafter32(f, float32(math.Inf(-1)))
+}
@@ -0,0 +1,100 @@
+package float16
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestDotAccumulatorShards(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	for trial := 0; trial < 50; trial++ {
+		n := 1 + rng.Intn(2000)
+		a := make([]Float16, n)
+		b := make([]Float16, n)
+		for i := range a {
+			a[i] = FromFloat64(rng.NormFloat64() * 10)
+			b[i] = FromFloat64(rng.NormFloat64())
+		}
+		want := fromRatRoundToOdd(exactDot(a, b))
+
+		shards := 1 + rng.Intn(8)
+		total := NewDotAccumulator(true)
+		for s := 0; s < shards; s++ {
+			lo, hi := s*n/shards, (s+1)*n/shards
+			acc := NewDotAccumulator(true)
+			if err := acc.AddSlices(a[lo:hi], b[lo:hi]); err != nil {
+				t.Fatal(err)
+			}
+			total.Merge(acc)
+		}
+		if got := total.Result(); got != want {
+			t.Fatalf("trial %d: merged result %v, want %v", trial, got, want)
+		}
+		if got := DotProductCompensated(a, b); got != want {
+			t.Fatalf("trial %d: DotProductCompensated %v, want %v", trial, got, want)
+		}
+		if got := total.ResultFloat32(); math.Abs(float64(got)-want.ToFloat64()) > math.Abs(want.ToFloat64())*1e-3+1e-4 {
+			t.Fatalf("trial %d: ResultFloat32 %v, want about %v", trial, got, want)
+		}
+	}
+}
+
+func TestDotAccumulatorSpecials(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []Float16
+		want Float16
+	}{
+		{"empty", nil, nil, PositiveZero},
+		{"NaN poisons", []Float16{One(), QuietNaN, One()}, []Float16{One(), One(), One()}, QuietNaN},
+		{"inf times zero", []Float16{PositiveInfinity}, []Float16{PositiveZero}, QuietNaN},
+		{"opposite infinities", []Float16{PositiveInfinity, NegativeInfinity}, []Float16{One(), One()}, QuietNaN},
+		{"infinity", []Float16{PositiveInfinity, One()}, []Float16{One(), FromInt(-5)}, PositiveInfinity},
+		{"no intermediate overflow", []Float16{MaxValue, MaxValue}, []Float16{MaxValue, MinValue}, PositiveZero},
+	}
+	for _, compensated := range []bool{false, true} {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				acc := NewDotAccumulator(compensated)
+				if err := acc.AddSlices(tt.a, tt.b); err != nil {
+					t.Fatal(err)
+				}
+				got := acc.Result()
+				if tt.want.IsNaN() {
+					if !got.IsNaN() {
+						t.Errorf("Result() = %v, want NaN", got)
+					}
+					return
+				}
+				if got != tt.want {
+					t.Errorf("Result() = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestDotAccumulatorReset(t *testing.T) {
+	var acc DotAccumulator
+	acc.Add(QuietNaN, One())
+	if !acc.Result().IsNaN() {
+		t.Fatal("expected NaN before Reset")
+	}
+	acc.Reset()
+	acc.Add(FromInt(3), FromInt(4))
+	acc.Add(FromInt(1), FromInt(-2))
+	if got := acc.Result(); got != FromInt(10) {
+		t.Errorf("Result() after Reset = %v, want 10", got)
+	}
+
+	err := acc.AddSlices(make([]Float16, 2), make([]Float16, 3))
+	var fe *Float16Error
+	if !errors.As(err, &fe) || fe.Code != ErrInvalidOperation {
+		t.Errorf("AddSlices length mismatch err = %v", err)
+	}
+	if got := acc.Result(); got != FromInt(10) {
+		t.Errorf("failed AddSlices changed the sum to %v", got)
+	}
+}
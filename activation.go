@@ -0,0 +1,65 @@
+package float16
+
+import "math"
+
+// Activation functions
+//
+// Sigmoid is evaluated in float64 and rounded once. The other functions are
+// the cheap variants used by mobile networks and computed in float32, which
+// is what accelerators implementing them typically do.
+
+// Sigmoid returns the logistic function 1 / (1 + e^-f), evaluated in float64
+// and rounded once to Float16
+func Sigmoid(f Float16) Float16 {
+	if f.IsNaN() {
+		return f
+	}
+	return FromFloat64(1 / (1 + math.Exp(-f.ToFloat64())))
+}
+
+// SigmoidTanh returns the sigmoid of f through the identity
+// sigmoid(x) = (1 + tanh(x/2)) / 2, computed in float32. It matches hardware
+// that derives the sigmoid from a tanh unit and stays within one ULP of
+// Sigmoid.
+func SigmoidTanh(f Float16) Float16 {
+	if f.IsNaN() {
+		return f
+	}
+	t := float32(math.Tanh(float64(f.ToFloat32() / 2)))
+	return FromFloat32(0.5 * (1 + t))
+}
+
+// HardSigmoid returns the piecewise-linear sigmoid clamp((x+3)/6, 0, 1),
+// computed in float32. It is 0 up to -3, 1 from 3 on, and 0.5 at 0.
+func HardSigmoid(f Float16) Float16 {
+	if f.IsNaN() {
+		return f
+	}
+	return FromFloat32(hardSigmoid32(f.ToFloat32()))
+}
+
+// HardTanh returns f clamped to [-1, 1]. Zeros keep their sign.
+func HardTanh(f Float16) Float16 {
+	if f.IsNaN() {
+		return f
+	}
+	return FromFloat32(min(max(f.ToFloat32(), -1), 1))
+}
+
+// HardSwish returns x·HardSigmoid(x), computed in float32: 0 up to -3 (-0
+// for negative inputs), x from 3 on, and a quadratic in between.
+func HardSwish(f Float16) Float16 {
+	if f.IsNaN() {
+		return f
+	}
+	if f.IsInf(-1) {
+		return NegativeZero // -Inf·0 would be NaN
+	}
+	x := f.ToFloat32()
+	return FromFloat32(x * hardSigmoid32(x))
+}
+
+// hardSigmoid32 returns clamp((x+3)/6, 0, 1)
+func hardSigmoid32(x float32) float32 {
+	return min(max((x+3)/6, 0), 1)
+}
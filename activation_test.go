@@ -0,0 +1,81 @@
+package float16
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSigmoidTanh(t *testing.T) {
+	for b := 0; b < 1<<16; b++ {
+		x := Float16(b)
+		got, want := SigmoidTanh(x), Sigmoid(x)
+		if x.IsNaN() {
+			if !got.IsNaN() || !want.IsNaN() {
+				t.Fatalf("Sigmoid, SigmoidTanh(%v) = %v, %v, want NaN", x, want, got)
+			}
+			continue
+		}
+		if d := UlpDistance(got, want); d > 1 {
+			t.Fatalf("SigmoidTanh(%v) = %v, Sigmoid = %v: %d ULP apart", x, got, want, d)
+		}
+	}
+	if got := Sigmoid(PositiveZero); got != Half16 {
+		t.Errorf("Sigmoid(0) = %v, want 0.5", got)
+	}
+	if got := Sigmoid(PositiveInfinity); got != One16 {
+		t.Errorf("Sigmoid(+Inf) = %v, want 1", got)
+	}
+	if got := Sigmoid(NegativeInfinity); got != PositiveZero {
+		t.Errorf("Sigmoid(-Inf) = %v, want 0", got)
+	}
+}
+
+func TestHardActivations(t *testing.T) {
+	v := func(f float32) Float16 { return FromFloat32(f) }
+	tests := []struct {
+		name string
+		fn   func(Float16) Float16
+		in   Float16
+		want Float16
+	}{
+		{"HardSigmoid", HardSigmoid, v(-3), PositiveZero},
+		{"HardSigmoid", HardSigmoid, v(-4), PositiveZero},
+		{"HardSigmoid", HardSigmoid, PositiveZero, Half16},
+		{"HardSigmoid", HardSigmoid, v(1.5), v(0.75)},
+		{"HardSigmoid", HardSigmoid, v(3), One16},
+		{"HardSigmoid", HardSigmoid, PositiveInfinity, One16},
+		{"HardSigmoid", HardSigmoid, NegativeInfinity, PositiveZero},
+		{"HardTanh", HardTanh, v(-1), v(-1)},
+		{"HardTanh", HardTanh, v(-2), v(-1)},
+		{"HardTanh", HardTanh, v(0.5), v(0.5)},
+		{"HardTanh", HardTanh, One16, One16},
+		{"HardTanh", HardTanh, MaxValue, One16},
+		{"HardTanh", HardTanh, NegativeZero, NegativeZero},
+		{"HardSwish", HardSwish, v(-3), NegativeZero},
+		{"HardSwish", HardSwish, v(-5), NegativeZero},
+		{"HardSwish", HardSwish, NegativeInfinity, NegativeZero},
+		{"HardSwish", HardSwish, PositiveZero, PositiveZero},
+		{"HardSwish", HardSwish, v(1), v(float32(4) / 6)},
+		{"HardSwish", HardSwish, v(3), v(3)},
+		{"HardSwish", HardSwish, v(100), v(100)},
+		{"HardSwish", HardSwish, PositiveInfinity, PositiveInfinity},
+	}
+	for _, tt := range tests {
+		if got := tt.fn(tt.in); got != tt.want {
+			t.Errorf("%s(%v) = %v (%#04x), want %v (%#04x)", tt.name, tt.in, got, got.Bits(), tt.want, tt.want.Bits())
+		}
+	}
+	for _, fn := range []func(Float16) Float16{Sigmoid, SigmoidTanh, HardSigmoid, HardTanh, HardSwish} {
+		if got := fn(QuietNaN); !got.IsNaN() {
+			t.Errorf("activation of NaN = %v", got)
+		}
+	}
+
+	// HardSwish is continuous at its breakpoints
+	for _, x := range []float64{-3, 3} {
+		below, above := HardSwish(FromFloat64(x-1.0/256)), HardSwish(FromFloat64(x+1.0/256))
+		if math.Abs(below.ToFloat64()-above.ToFloat64()) > 0.05 {
+			t.Errorf("HardSwish jumps at %v: %v to %v", x, below, above)
+		}
+	}
+}
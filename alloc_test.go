@@ -0,0 +1,112 @@
+package float16
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// Inputs are package variables so the compiler cannot fold the calls away
+var (
+	allocA, allocB = FromFloat32(1.5), FromFloat32(-0.3)
+	allocNaN       = QuietNaN
+	allocF32       = float32(0.1)
+	allocF64       = 0.1
+	allocSink      Float16
+	allocBool      bool
+	allocErr       error
+)
+
+type allocCase struct {
+	name string
+	fn   func()
+}
+
+func TestScalarAllocs(t *testing.T) {
+	a, b := allocA, allocB
+	modes := []ArithmeticMode{ModeIEEEArithmetic, ModeFastArithmetic, ModeExactArithmetic}
+
+	cases := []allocCase{
+		// Conversions
+		{"FromFloat32", func() { allocSink = FromFloat32(allocF32) }},
+		{"FromFloat32WithRounding", func() { allocSink = FromFloat32WithRounding(allocF32, RoundTowardZero) }},
+		{"FromFloat64", func() { allocSink = FromFloat64(allocF64) }},
+		{"FromBits", func() { allocSink = FromBits(uint16(a)) }},
+		{"ToFloat16", func() { allocSink = ToFloat16(allocF64) }},
+		{"ToFloat16WithMode", func() { allocSink, allocErr = ToFloat16WithMode(allocF64, ModeStrict) }},
+		{"FromFloat64WithMode", func() { allocSink, allocErr = FromFloat64WithMode(allocF64, ModeStrict, RoundTowardPositive) }},
+		{"ToFloat32", func() { allocF32 = a.ToFloat32() }},
+		{"ToFloat64", func() { allocF64 = a.ToFloat64() }},
+		{"ToFloat32WithMode", func() { allocF32 = ToFloat32WithMode(a, SubnormalFlushToZero) }},
+
+		// Arithmetic
+		{"Add", func() { allocSink = Add(a, b) }},
+		{"Sub", func() { allocSink = Sub(a, b) }},
+		{"Mul", func() { allocSink = Mul(a, b) }},
+		{"Div", func() { allocSink = Div(a, b) }},
+		{"Sqrt", func() { allocSink = Sqrt(a) }},
+		{"Abs", func() { allocSink = Abs(b) }},
+		{"Float16.Neg", func() { allocSink = a.Neg() }},
+		{"Float16.CopySign", func() { allocSink = a.CopySign(b) }},
+
+		// Comparisons
+		{"Equal", func() { allocBool = Equal(a, b) }},
+		{"Less", func() { allocBool = Less(a, b) }},
+		{"LessEqual", func() { allocBool = LessEqual(a, b) }},
+		{"Greater", func() { allocBool = Greater(a, b) }},
+		{"GreaterEqual", func() { allocBool = GreaterEqual(a, b) }},
+		{"Min", func() { allocSink = Min(a, allocNaN) }},
+		{"Max", func() { allocSink = Max(a, b) }},
+		{"Float16.TotalOrderInt", func() { allocBool = a.TotalOrderInt() > 0 }},
+
+		// Classification
+		{"Float16.Class", func() { allocBool = b.Class() == ClassNegativeNormal }},
+		{"Float16.IsNaN", func() { allocBool = allocNaN.IsNaN() }},
+		{"Float16.IsInf", func() { allocBool = a.IsInf(0) }},
+		{"Float16.IsFinite", func() { allocBool = a.IsFinite() }},
+		{"Float16.IsNormal", func() { allocBool = a.IsNormal() }},
+		{"Float16.IsSubnormal", func() { allocBool = a.IsSubnormal() }},
+		{"Float16.IsZero", func() { allocBool = a.IsZero() }},
+		{"Float16.IsInteger", func() { allocBool = a.IsInteger() }},
+		{"Float16.Signbit", func() { allocBool = b.Signbit() }},
+	}
+	for _, mode := range modes {
+		for _, r := range []RoundingMode{RoundNearestEven, RoundTowardNegative} {
+			suffix := fmt.Sprintf("/mode=%d/rounding=%d", mode, r)
+			cases = append(cases,
+				allocCase{"AddWithMode" + suffix, func() { allocSink, allocErr = AddWithMode(a, b, mode, r) }},
+				allocCase{"SubWithMode" + suffix, func() { allocSink, allocErr = SubWithMode(a, b, mode, r) }},
+				allocCase{"MulWithMode" + suffix, func() { allocSink, allocErr = MulWithMode(a, b, mode, r) }},
+				allocCase{"DivWithMode" + suffix, func() { allocSink, allocErr = DivWithMode(a, b, mode, r) }},
+			)
+		}
+	}
+
+	for _, c := range cases {
+		if n := testing.AllocsPerRun(100, c.fn); n != 0 {
+			t.Errorf("%s allocates %v times per call, want 0", c.name, n)
+		}
+	}
+}
+
+// TestScalarErrorAllocs checks the documented cost of the error paths: one
+// allocation, the *Float16Error itself
+func TestScalarErrorAllocs(t *testing.T) {
+	nan, inf := allocNaN, PositiveInfinity
+	cases := []allocCase{
+		{"AddWithMode", func() { allocSink, allocErr = AddWithMode(nan, One16, ModeExactArithmetic, RoundNearestEven) }},
+		{"SubWithMode", func() { allocSink, allocErr = SubWithMode(inf, inf, ModeExactArithmetic, RoundNearestEven) }},
+		{"MulWithMode", func() { allocSink, allocErr = MulWithMode(inf, PositiveZero, ModeExactArithmetic, RoundNearestEven) }},
+		{"DivWithMode", func() { allocSink, allocErr = DivWithMode(One16, PositiveZero, ModeExactArithmetic, RoundNearestEven) }},
+		{"FromFloat64WithMode", func() { allocSink, allocErr = FromFloat64WithMode(math.Inf(1), ModeStrict, RoundNearestEven) }},
+		{"ToFloat16WithMode", func() { allocSink, allocErr = ToFloat16WithMode(1e6, ModeStrict) }},
+	}
+	for _, c := range cases {
+		if n := testing.AllocsPerRun(100, c.fn); n > 1 {
+			t.Errorf("%s error path allocates %v times per call, want at most 1", c.name, n)
+		}
+		if allocErr == nil {
+			t.Errorf("%s did not take its error path", c.name)
+		}
+	}
+}
@@ -13,13 +13,14 @@ var (
 // ArithmeticMode defines the precision/performance trade-off for arithmetic operations
 type ArithmeticMode int
 
+// The numeric values are part of the wire format and never change.
 const (
 	// ModeIEEE provides full IEEE 754 compliance with proper rounding
-	ModeIEEEArithmetic ArithmeticMode = iota
+	ModeIEEEArithmetic ArithmeticMode = 0
 	// ModeFastArithmetic optimizes for speed, may sacrifice some precision
-	ModeFastArithmetic
+	ModeFastArithmetic ArithmeticMode = 1
 	// ModeExactArithmetic provides exact results when possible, errors on precision loss
-	ModeExactArithmetic
+	ModeExactArithmetic ArithmeticMode = 2
 )
 
 // Add performs addition of two Float16 values
@@ -31,10 +32,12 @@ func Add(a, b Float16) Float16 {
 // AddWithMode performs addition with specified arithmetic and rounding modes
 func AddWithMode(a, b Float16, mode ArithmeticMode, rounding RoundingMode) (Float16, error) {
 	// Handle special cases first for performance
-	if a.IsZero() {
+	switch {
+	case a.IsZero() && b.IsZero():
+		return addZeros(a, b, rounding), nil
+	case a.IsZero():
 		return b, nil
-	}
-	if b.IsZero() {
+	case b.IsZero():
 		return a, nil
 	}
 
@@ -48,7 +51,7 @@ func AddWithMode(a, b Float16, mode ArithmeticMode, rounding RoundingMode) (Floa
 			}
 		}
 		// Return a quiet NaN
-		return QuietNaN, nil
+		return defaultNaN(), nil
 	}
 
 	// Handle infinity cases
@@ -62,7 +65,7 @@ func AddWithMode(a, b Float16, mode ArithmeticMode, rounding RoundingMode) (Floa
 					Code: ErrInvalidOperation,
 				}
 			}
-			return QuietNaN, nil
+			return defaultNaN(), nil
 		}
 		if a.IsInf(-1) && b.IsInf(1) {
 			// (-∞) + (+∞) = NaN
@@ -73,7 +76,7 @@ func AddWithMode(a, b Float16, mode ArithmeticMode, rounding RoundingMode) (Floa
 					Code: ErrInvalidOperation,
 				}
 			}
-			return QuietNaN, nil
+			return defaultNaN(), nil
 		}
 		// Return the infinity
 		if a.IsInf(0) {
@@ -115,11 +118,19 @@ func Mul(a, b Float16) Float16 {
 
 // MulWithMode performs multiplication with specified arithmetic and rounding modes
 func MulWithMode(a, b Float16, mode ArithmeticMode, rounding RoundingMode) (Float16, error) {
-	// Handle special cases
-	// Check for zero times infinity cases first
-	aIsZero := a.IsZero()
-	bIsInf := b.IsInf(0)
-	if (aIsZero && bIsInf) || (a.IsInf(0) && b.IsZero()) {
+	pair := classifyPair(a, b)
+	if pair == pairFiniteFinite {
+		// For high performance, use float32 arithmetic
+		if mode == ModeFastArithmetic {
+			return FromFloat32(a.ToFloat32() * b.ToFloat32()), nil
+		}
+		// Full IEEE 754 implementation
+		return mulIEEE754(a, b, rounding)
+	}
+
+	sign := (a ^ b) & SignMask
+	switch {
+	case pair == pairZeroInf || pair == pairInfZero:
 		// 0 * ∞ = NaN
 		if mode == ModeExactArithmetic {
 			return 0, &Float16Error{
@@ -128,22 +139,11 @@ func MulWithMode(a, b Float16, mode ArithmeticMode, rounding RoundingMode) (Floa
 				Code: ErrInvalidOperation,
 			}
 		}
-		return QuietNaN, nil
-	}
-
-	// Handle zero cases
-	if aIsZero || b.IsZero() {
-		// Handle sign of zero result: 0 * anything = ±0
-		signA := a.Signbit()
-		signB := b.Signbit()
-		if signA != signB {
-			return NegativeZero, nil
-		}
-		return PositiveZero, nil
-	}
-
-	// Handle NaN cases
-	if a.IsNaN() || b.IsNaN() {
+		return defaultNaN(), nil
+	case pair.has(classZero):
+		// 0 * anything = ±0, including NaN
+		return PositiveZero | sign, nil
+	case pair.has(classNaN):
 		if mode == ModeExactArithmetic {
 			return 0, &Float16Error{
 				Op:   "mul",
@@ -151,42 +151,11 @@ func MulWithMode(a, b Float16, mode ArithmeticMode, rounding RoundingMode) (Floa
 				Code: ErrNaN,
 			}
 		}
-		return QuietNaN, nil
-	}
-
-	// Handle infinity cases
-	if a.IsInf(0) || b.IsInf(0) {
-		// Check for 0 * ∞ which is NaN
-		if (a.IsInf(0) && b.IsZero()) || (a.IsZero() && b.IsInf(0)) {
-			if mode == ModeExactArithmetic {
-				return 0, &Float16Error{
-					Op:   "mul",
-					Msg:  "zero times infinity is undefined",
-					Code: ErrInvalidOperation,
-				}
-			}
-			return QuietNaN, nil
-		}
-
-		// ∞ * finite = ±∞ (sign depends on operand signs)
-		signA := a.Signbit()
-		signB := b.Signbit()
-		if signA != signB {
-			return NegativeInfinity, nil
-		}
-		return PositiveInfinity, nil
+		return defaultNaN(), nil
+	default:
+		// ∞ * finite = ±∞
+		return PositiveInfinity | sign, nil
 	}
-
-	// For high performance, use float32 arithmetic
-	if mode == ModeFastArithmetic {
-		f32a := a.ToFloat32()
-		f32b := b.ToFloat32()
-		result := f32a * f32b
-		return FromFloat32(result), nil
-	}
-
-	// Full IEEE 754 implementation
-	return mulIEEE754(a, b, rounding)
 }
 
 // Div performs division of two Float16 values
@@ -197,93 +166,43 @@ func Div(a, b Float16) Float16 {
 
 // DivWithMode performs division with specified arithmetic and rounding modes
 func DivWithMode(a, b Float16, mode ArithmeticMode, rounding RoundingMode) (Float16, error) {
-	// Handle division by zero
-	if b.IsZero() {
-		if a.IsZero() {
-			// 0/0 = NaN
-			if mode == ModeExactArithmetic {
-				return 0, &Float16Error{
-					Op:   "div",
-					Msg:  "zero divided by zero is undefined",
-					Code: ErrInvalidOperation,
-				}
-			}
-			return QuietNaN, nil
+	pair := classifyPair(a, b)
+	if pair == pairFiniteFinite {
+		// For high performance, use float32 arithmetic
+		if mode == ModeFastArithmetic {
+			return FromFloat32(a.ToFloat32() / b.ToFloat32()), nil
 		}
-		// finite/0 = ±∞
+		// Full IEEE 754 implementation
+		return divIEEE754(a, b, rounding)
+	}
+
+	// Zeros are checked before NaN, so x/0 is ±∞ and 0/x is ±0 even when x
+	// is NaN, and likewise ∞ before NaN
+	sign := (a ^ b) & SignMask
+	switch {
+	case pair == pairZeroZero:
+		// 0/0 = NaN
 		if mode == ModeExactArithmetic {
 			return 0, &Float16Error{
 				Op:   "div",
-				Msg:  "division by zero",
-				Code: ErrDivisionByZero,
-			}
-		}
-		signA := a.Signbit()
-		signB := b.Signbit()
-		if signA != signB {
-			return NegativeInfinity, nil
-		}
-		return PositiveInfinity, nil
-	}
-
-	// Handle zero dividend
-	if a.IsZero() {
-		// 0/finite = ±0
-		signA := a.Signbit()
-		signB := b.Signbit()
-		if signA != signB {
-			return NegativeZero, nil
-		}
-		return PositiveZero, nil
-	}
-
-	// Handle infinity cases
-	if a.IsInf(0) || b.IsInf(0) {
-		if a.IsInf(0) && b.IsInf(0) {
-			// ∞/∞ = NaN
-			if mode == ModeExactArithmetic {
-				return 0, &Float16Error{
-					Op:   "div",
-					Msg:  "infinity divided by infinity is undefined",
-					Code: ErrInvalidOperation,
-				}
-			}
-			return QuietNaN, nil
-		}
-
-		if a.IsInf(0) {
-			// ∞/finite = ±∞
-			signA := a.Signbit()
-			signB := b.Signbit()
-			if signA != signB {
-				return NegativeInfinity, nil
+				Msg:  "zero divided by zero is undefined",
+				Code: ErrInvalidOperation,
 			}
-			return PositiveInfinity, nil
-		}
-
-		// finite/∞ = ±0
-		signA := a.Signbit()
-		signB := b.Signbit()
-		if signA != signB {
-			return NegativeZero, nil
 		}
-		return PositiveZero, nil
-	}
-
-	// Handle NaN cases
-	if a.IsNaN() || b.IsNaN() {
+		return defaultNaN(), nil
+	case pair.second() == classZero:
+		// x/0 = ±∞
 		if mode == ModeExactArithmetic {
 			return 0, &Float16Error{
 				Op:   "div",
-				Msg:  "NaN operand in exact mode",
-				Code: ErrNaN,
+				Msg:  "division by zero",
+				Code: ErrDivisionByZero,
 			}
 		}
-		return QuietNaN, nil
-	}
-
-	// Handle infinity cases
-	if a.IsInf(0) && b.IsInf(0) {
+		return PositiveInfinity | sign, nil
+	case pair.first() == classZero:
+		return PositiveZero | sign, nil
+	case pair == pairInfInf:
 		// ∞/∞ = NaN
 		if mode == ModeExactArithmetic {
 			return 0, &Float16Error{
@@ -292,61 +211,105 @@ func DivWithMode(a, b Float16, mode ArithmeticMode, rounding RoundingMode) (Floa
 				Code: ErrInvalidOperation,
 			}
 		}
-		return QuietNaN, nil
-	}
-
-	if a.IsInf(0) {
-		// ∞/finite = ±∞
-		signA := a.Signbit()
-		signB := b.Signbit()
-		if signA != signB {
-			return NegativeInfinity, nil
+		return defaultNaN(), nil
+	case pair.first() == classInf:
+		return PositiveInfinity | sign, nil
+	case pair.second() == classInf:
+		return PositiveZero | sign, nil
+	default:
+		// At least one operand is NaN
+		if mode == ModeExactArithmetic {
+			return 0, &Float16Error{
+				Op:   "div",
+				Msg:  "NaN operand in exact mode",
+				Code: ErrNaN,
+			}
 		}
-		return PositiveInfinity, nil
+		return defaultNaN(), nil
 	}
+}
 
-	if b.IsInf(0) {
-		// finite/∞ = ±0
-		signA := a.Signbit()
-		signB := b.Signbit()
-		if signA != signB {
-			return NegativeZero, nil
-		}
-		return PositiveZero, nil
-	}
+// operandClass is the special-case category of an arithmetic operand
+type operandClass uint8
 
-	// For high performance, use float32 arithmetic
-	if mode == ModeFastArithmetic {
-		f32a := a.ToFloat32()
-		f32b := b.ToFloat32()
-		result := f32a / f32b
-		return FromFloat32(result), nil
+const (
+	classFinite operandClass = iota // nonzero finite, normal or subnormal
+	classZero
+	classInf
+	classNaN
+)
+
+// classify returns the class of f from a single masked comparison
+func classify(f Float16) operandClass {
+	switch m := f &^ SignMask; {
+	case m == 0:
+		return classZero
+	case m < ExponentMask:
+		return classFinite
+	case m == ExponentMask:
+		return classInf
+	default:
+		return classNaN
 	}
+}
 
-	// Full IEEE 754 implementation
-	return divIEEE754(a, b, rounding)
+// pairClass packs the classes of two operands, first operand in the high bits
+type pairClass uint8
+
+const (
+	pairFiniteFinite = pairClass(classFinite<<2 | classFinite)
+	pairZeroZero     = pairClass(classZero<<2 | classZero)
+	pairZeroInf      = pairClass(classZero<<2 | classInf)
+	pairInfZero      = pairClass(classInf<<2 | classZero)
+	pairInfInf       = pairClass(classInf<<2 | classInf)
+)
+
+// classifyPair returns the combined class of a and b
+func classifyPair(a, b Float16) pairClass {
+	return pairClass(classify(a)<<2 | classify(b))
+}
+
+func (p pairClass) first() operandClass  { return operandClass(p >> 2) }
+func (p pairClass) second() operandClass { return operandClass(p & 3) }
+
+// has reports whether either operand is of class c
+func (p pairClass) has(c operandClass) bool {
+	return p.first() == c || p.second() == c
 }
 
 // IEEE 754 compliant arithmetic implementations
 
 // addIEEE754 implements full IEEE 754 addition
 func addIEEE754(a, b Float16, rounding RoundingMode) (Float16, error) {
-	// For addition, we can use the simpler approach of converting to float32
-	// since the intermediate precision is sufficient for exact float16 results
-	f32a := a.ToFloat32()
-	f32b := b.ToFloat32()
-	result := f32a + f32b
-	return FromFloat32WithRounding(result, rounding), nil
+	// Float16 operands span 40 bits of exponent range, so the sum is exact
+	// in float64 and the conversion is the only rounding
+	sum := a.ToFloat64() + b.ToFloat64()
+	if sum == 0 {
+		// Exact cancellation of nonzero operands
+		return addZeros(PositiveZero, NegativeZero, rounding), nil
+	}
+	return fromFloat64Rounded(sum, rounding), nil
+}
+
+// addZeros returns the sum of the zeros a and b, or of an exact cancellation
+// passed as opposite zeros: zeros of the same sign keep it, and opposite
+// signs give +0 except under RoundTowardNegative, as IEEE 754 requires
+func addZeros(a, b Float16, rounding RoundingMode) Float16 {
+	switch {
+	case a == b:
+		return a
+	case rounding == RoundTowardNegative:
+		return NegativeZero
+	}
+	return PositiveZero
 }
 
 // mulIEEE754 implements full IEEE 754 multiplication
 func mulIEEE754(a, b Float16, rounding RoundingMode) (Float16, error) {
-	// For multiplication, we can use the simpler approach of converting to float32
-	// since the intermediate precision is sufficient for exact float16 results
-	f32a := a.ToFloat32()
-	f32b := b.ToFloat32()
-	result := f32a * f32b
-	return FromFloat32WithRounding(result, rounding), nil
+	// The product of two 11-bit significands is exact in float64, so the
+	// conversion is the only rounding, and it saturates at MaxValue under
+	// the directed modes that round toward zero
+	return fromFloat64Rounded(a.ToFloat64()*b.ToFloat64(), rounding), nil
 }
 
 // divIEEE754 implements full IEEE 754 division
@@ -356,7 +319,7 @@ func divIEEE754(a, b Float16, rounding RoundingMode) (Float16, error) {
 	f32a := a.ToFloat32()
 	f32b := b.ToFloat32()
 	result := f32a / f32b
-	return FromFloat32WithRounding(result, rounding), nil
+	return fromFloat32WithRoundingChecked(result, rounding)
 }
 
 // Comparison operations
@@ -453,13 +416,151 @@ func Max(a, b Float16) Float16 {
 	if b.IsNaN() {
 		return a
 	}
-
+	// Handle -0 and +0
+	if a.IsZero() && b.IsZero() {
+		if a.Signbit() {
+			return b // b is +0, or both are -0
+		}
+		return a // a is +0
+	}
 	if Greater(a, b) {
 		return a
 	}
 	return b
 }
 
+// SliceMin returns the smallest element of s, skipping NaNs as Min does. It
+// returns NaN if s is empty or holds only NaNs. Use SliceMinPropagate to have
+// a NaN element poison the result instead.
+func SliceMin(s []Float16) Float16 {
+	result := defaultNaN()
+	for _, v := range s {
+		result = Min(result, v)
+	}
+	return result
+}
+
+// SliceMax returns the largest element of s, skipping NaNs as Max does. It
+// returns NaN if s is empty or holds only NaNs. Use SliceMaxPropagate to have
+// a NaN element poison the result instead.
+func SliceMax(s []Float16) Float16 {
+	result := defaultNaN()
+	for _, v := range s {
+		result = Max(result, v)
+	}
+	return result
+}
+
+// SliceMinPropagate returns the smallest element of s with the IEEE 754-2019
+// minimum semantics: any NaN element makes the result NaN, and -0 is smaller
+// than +0. Unlike SliceMin it lets callers detect NaN contamination from the
+// result alone. It returns NaN if s is empty.
+func SliceMinPropagate(s []Float16) Float16 {
+	return reducePropagate(s, -1)
+}
+
+// SliceMaxPropagate returns the largest element of s with the IEEE 754-2019
+// maximum semantics: any NaN element makes the result NaN, and +0 is larger
+// than -0. Unlike SliceMax it lets callers detect NaN contamination from the
+// result alone. It returns NaN if s is empty.
+func SliceMaxPropagate(s []Float16) Float16 {
+	return reducePropagate(s, 1)
+}
+
+// reducePropagate returns the element of s that compares as dir (-1 for the
+// minimum, +1 for the maximum) under the total order of non-NaN values, or
+// NaN if s is empty or contains a NaN
+func reducePropagate(s []Float16, dir int) Float16 {
+	if len(s) == 0 {
+		return defaultNaN()
+	}
+	// The total order agrees with IEEE ordering on non-NaN values and puts
+	// -0 below +0
+	result, best := s[0], s[0].TotalOrderInt()
+	for _, v := range s {
+		if v.IsNaN() {
+			return defaultNaN()
+		}
+		if k := v.TotalOrderInt(); (dir < 0 && k < best) || (dir > 0 && k > best) {
+			result, best = v, k
+		}
+	}
+	return result
+}
+
+// CompareInt compares f with the integer n exactly, returning -1, 0 or +1
+// as f is less than, equal to or greater than n. The comparison does not round
+// n to Float16, so CompareInt(FromInt(2048), 2049) is -1. The boolean result is
+// false, with a comparison of 0, when f is NaN.
+func CompareInt(f Float16, n int64) (int, bool) {
+	if f.IsNaN() {
+		return 0, false
+	}
+	if f.IsInf(1) {
+		return 1, true
+	}
+	if f.IsInf(-1) {
+		return -1, true
+	}
+
+	// Finite Float16 magnitudes are below 2^16, so larger n need no conversion
+	const limit = 1 << 17
+	if n > limit {
+		return -1, true
+	}
+	if n < -limit {
+		return 1, true
+	}
+
+	// Both sides are exact in float64
+	v, m := f.ToFloat64(), float64(n)
+	switch {
+	case v < m:
+		return -1, true
+	case v > m:
+		return 1, true
+	default:
+		return 0, true
+	}
+}
+
+// EqualsInt reports whether f is exactly equal to the integer n
+func EqualsInt(f Float16, n int64) bool {
+	c, ok := CompareInt(f, n)
+	return ok && c == 0
+}
+
+// IsInRangeInt reports whether lo <= f <= hi using exact comparisons.
+// It returns false if f is NaN.
+func IsInRangeInt(f Float16, lo, hi int64) bool {
+	cLo, ok := CompareInt(f, lo)
+	if !ok || cLo < 0 {
+		return false
+	}
+	cHi, _ := CompareInt(f, hi)
+	return cHi <= 0
+}
+
+// EqualFloat64 reports whether f equals x exactly. f is promoted to float64,
+// which is exact, so unlike Equal(f, FromFloat64(x)) the comparison sees all
+// of x: FromFloat64(0.1).EqualFloat64(0.1) is false, because 0.1 is not a
+// Float16. A float32 converts to float64 exactly and can be passed as well.
+// As with ==, NaN is unequal to everything and -0 equals +0.
+func (f Float16) EqualFloat64(x float64) bool {
+	return f.ToFloat64() == x
+}
+
+// LessFloat64 reports whether f < x, comparing exactly as EqualFloat64 does
+func (f Float16) LessFloat64(x float64) bool {
+	return f.ToFloat64() < x
+}
+
+// GreaterFloat64 reports whether f > x, comparing exactly as EqualFloat64
+// does
+func (f Float16) GreaterFloat64(x float64) bool {
+	return f.ToFloat64() > x
+}
+
 // Batch operations for high-performance computing
 
 // AddSlice performs element-wise addition of two Float16 slices
@@ -523,6 +624,20 @@ func ScaleSlice(s []Float16, scalar Float16) []Float16 {
 	return result
 }
 
+// ScaleSliceWithMode multiplies each element of s by scalar, rounding every
+// product in the given mode with IEEE 754 arithmetic, whatever
+// DefaultArithmeticMode says. It is meant for directed-rounding quantization
+// experiments; with RoundNearestEven it matches ScaleSlice under the default
+// configuration.
+func ScaleSliceWithMode(s []Float16, scalar Float16, mode RoundingMode) []Float16 {
+	result := make([]Float16, len(s))
+	for i := range s {
+		// IEEE arithmetic never returns an error
+		result[i], _ = MulWithMode(s[i], scalar, ModeIEEEArithmetic, mode)
+	}
+	return result
+}
+
 // SumSlice returns the sum of all elements in the slice
 func SumSlice(s []Float16) Float16 {
 	sum := PositiveZero
@@ -546,6 +661,57 @@ func DotProduct(a, b []Float16) Float16 {
 	return sum
 }
 
+// DotProductCompensated computes the dot product of a and b with the Dot2
+// algorithm of Ogita, Rump and Oishi: error-free product (2Prod) and sum
+// (2Sum) transformations in float32 carry the rounding errors of every step,
+// so the result is as accurate as if it were computed in twice float32
+// precision and then rounded once to Float16. It is reproducible for a given
+// input order.
+func DotProductCompensated(a, b []Float16) Float16 {
+	if len(a) != len(b) {
+		panic("float16: slice length mismatch")
+	}
+
+	var p, s float32
+	for i := range a {
+		h, r := twoProd32(a[i].ToFloat32(), b[i].ToFloat32())
+		var q float32
+		p, q = twoSum32(p, h)
+		s += q + r
+	}
+	hi, lo := twoSum32(p, s)
+	return FromFloat32(roundToOdd32(hi, lo))
+}
+
+// twoProd32 returns the rounded product x*y and its exact rounding error
+func twoProd32(x, y float32) (p, e float32) {
+	p = x * y
+	e = float32(math.FMA(float64(x), float64(y), -float64(p)))
+	return p, e
+}
+
+// twoSum32 returns the rounded sum x+y and its exact rounding error
+func twoSum32(x, y float32) (s, e float32) {
+	s = x + y
+	bb := s - x
+	e = (x - (s - bb)) + (y - bb)
+	return s, e
+}
+
+// roundToOdd32 rounds the unevaluated sum hi+lo, where hi is the nearest
+// float32 to the sum, to float32 with round-to-odd. A following rounding to
+// Float16 is then correctly rounded because float32 has more than two extra
+// significand bits.
+func roundToOdd32(hi, lo float32) float32 {
+	if lo == 0 || math.Float32bits(hi)&1 == 1 || math.IsInf(float64(hi), 0) {
+		return hi
+	}
+	if lo > 0 {
+		return math.Nextafter32(hi, float32(math.Inf(1)))
+	}
+	return math.Nextafter32(hi, float32(math.Inf(-1)))
+}
+
 // Norm2 computes the L2 norm (Euclidean norm) of a Float16 slice
 func Norm2(s []Float16) Float16 {
 	sumSquares := PositiveZero
@@ -1,6 +1,10 @@
 package float16
 
 import (
+	"math"
+	"math/big"
+	"math/rand"
+	"slices"
 	"testing"
 )
 
@@ -638,6 +642,36 @@ func TestScaleSlice(t *testing.T) {
 	}
 }
 
+func TestScaleSliceWithMode(t *testing.T) {
+	// 1.5·(1+2^-10) lies halfway between 0x3E01 and 0x3E02
+	s := []Float16{0x3C01, One16, Three16}
+	scalar := Float16(0x3E00) // 1.5
+	nearest := ScaleSliceWithMode(s, scalar, RoundNearestEven)
+	zero := ScaleSliceWithMode(s, scalar, RoundTowardZero)
+	if nearest[0] != 0x3E02 || zero[0] != 0x3E01 {
+		t.Errorf("1.5·(1+2^-10): nearest %#04x, toward zero %#04x, want 0x3e02 and 0x3e01", nearest[0].Bits(), zero[0].Bits())
+	}
+	if !slices.Equal(nearest, ScaleSlice(s, scalar)) {
+		t.Errorf("ScaleSlice = %v, RoundNearestEven gives %v", ScaleSlice(s, scalar), nearest)
+	}
+
+	// Every mode matches the exact product rounded by the reference
+	r := rand.New(rand.NewSource(1))
+	in := make([]Float16, 2000)
+	for i := range in {
+		in[i] = Float16(r.Intn(0x7C00)) | Float16(r.Intn(2))<<15
+	}
+	scalar = FromFloat32(-3.3)
+	for mode := range bigRoundingModes {
+		got := ScaleSliceWithMode(in, scalar, mode)
+		for i, v := range in {
+			if want := ReferenceFromFloat64(v.ToFloat64()*scalar.ToFloat64(), mode); got[i] != want {
+				t.Fatalf("mode %v: %v·%v = %v, want %v", mode, v, scalar, got[i], want)
+			}
+		}
+	}
+}
+
 func TestSumSlice(t *testing.T) {
 	s := []Float16{0x3C00, 0x4000, 0x4200} // [1.0, 2.0, 3.0]
 	expect := Float16(0x4600)              // 6.0
@@ -781,3 +815,482 @@ func TestSlicePanics(t *testing.T) {
 		})
 	}
 }
+
+func TestCompareInt(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Float16
+		n    int64
+		want int
+		ok   bool
+	}{
+		{"2048 vs 2049", FromInt(2048), 2049, -1, true},
+		{"2048 vs 2048", FromInt(2048), 2048, 0, true},
+		{"2050 vs 2049", FromInt(2050), 2049, 1, true},
+		{"0.4 vs 0", FromFloat32(0.4), 0, 1, true},
+		{"-0.4 vs 0", FromFloat32(-0.4), 0, -1, true},
+		{"-0 vs 0", NegativeZero, 0, 0, true},
+		{"-3 vs -3", FromInt(-3), -3, 0, true},
+		{"-2048 vs -2049", FromInt(-2048), -2049, 1, true},
+		{"max vs 65504", MaxValue, 65504, 0, true},
+		{"max vs 65505", MaxValue, 65505, -1, true},
+		{"max vs huge", MaxValue, math.MaxInt64, -1, true},
+		{"min vs -huge", MinValue, math.MinInt64, 1, true},
+		{"max vs 2^53+1", MaxValue, 1<<53 + 1, -1, true},
+		{"+Inf vs huge", PositiveInfinity, math.MaxInt64, 1, true},
+		{"-Inf vs -huge", NegativeInfinity, math.MinInt64, -1, true},
+		{"NaN", QuietNaN, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := CompareInt(tt.f, tt.n)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("CompareInt(%v, %d) = (%d, %v), want (%d, %v)", tt.f, tt.n, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestEqualsIntAndRange(t *testing.T) {
+	if EqualsInt(FromFloat32(0.4), 0) {
+		t.Error("EqualsInt(0.4, 0) should be false")
+	}
+	if !EqualsInt(FromInt(-7), -7) || !EqualsInt(NegativeZero, 0) {
+		t.Error("EqualsInt should hold for exact integers")
+	}
+	if EqualsInt(FromInt(2048), 2049) || EqualsInt(QuietNaN, 0) {
+		t.Error("EqualsInt should be false for 2049 and NaN")
+	}
+
+	if !IsInRangeInt(FromFloat32(0.5), 0, 1) || !IsInRangeInt(FromInt(1), 0, 1) || !IsInRangeInt(PositiveZero, 0, 1) {
+		t.Error("IsInRangeInt should include endpoints and interior")
+	}
+	if IsInRangeInt(FromInt(2048), 2049, 3000) || IsInRangeInt(QuietNaN, math.MinInt64, math.MaxInt64) {
+		t.Error("IsInRangeInt should exclude 2048 from [2049, 3000] and NaN from everything")
+	}
+	if !IsInRangeInt(MaxValue, 0, math.MaxInt64) || IsInRangeInt(PositiveInfinity, 0, math.MaxInt64) {
+		t.Error("IsInRangeInt mishandles the top of the range")
+	}
+}
+
+func TestCompareFloat64(t *testing.T) {
+	tenth := FromFloat64(0.1) // 0.0999755859375
+	tests := []struct {
+		f                    Float16
+		x                    float64
+		equal, less, greater bool
+	}{
+		{tenth, 0.1, false, true, false},
+		{tenth, 0.0999755859375, true, false, false},
+		{One16, 1 + 0x1p-30, false, true, false},
+		{One16, 1 - 0x1p-30, false, false, true},
+		{MaxValue, 65504.5, false, true, false},
+		{PositiveInfinity, math.MaxFloat64, false, false, true},
+		{NegativeZero, 0, true, false, false},
+		{PositiveZero, -0x1p-1000, false, false, true},
+		{QuietNaN, 0, false, false, false},
+		{One16, math.NaN(), false, false, false},
+	}
+	for _, tt := range tests {
+		if got := tt.f.EqualFloat64(tt.x); got != tt.equal {
+			t.Errorf("%v.EqualFloat64(%g) = %v, want %v", tt.f, tt.x, got, tt.equal)
+		}
+		if got := tt.f.LessFloat64(tt.x); got != tt.less {
+			t.Errorf("%v.LessFloat64(%g) = %v, want %v", tt.f, tt.x, got, tt.less)
+		}
+		if got := tt.f.GreaterFloat64(tt.x); got != tt.greater {
+			t.Errorf("%v.GreaterFloat64(%g) = %v, want %v", tt.f, tt.x, got, tt.greater)
+		}
+	}
+
+	// Narrowing x first loses exactly the information the exact comparison
+	// keeps
+	if !Equal(tenth, FromFloat64(0.1)) {
+		t.Error("converting 0.1 to Float16 first should compare equal")
+	}
+	if Less(One16, FromFloat64(1+0x1p-30)) {
+		t.Error("converting 1+2^-30 to Float16 first should lose the difference")
+	}
+}
+
+// exactDot returns the exact dot product of a and b
+func exactDot(a, b []Float16) *big.Rat {
+	sum := new(big.Rat)
+	for i := range a {
+		x := new(big.Rat).SetFloat64(a[i].ToFloat64())
+		y := new(big.Rat).SetFloat64(b[i].ToFloat64())
+		sum.Add(sum, x.Mul(x, y))
+	}
+	return sum
+}
+
+func TestDotProductCompensated(t *testing.T) {
+	rng := rand.New(rand.NewSource(17))
+	const n = 2000
+	a := make([]Float16, n)
+	b := make([]Float16, n)
+
+	for trial := 0; trial < 20; trial++ {
+		for i := range a {
+			a[i] = FromFloat64(rng.NormFloat64())
+			b[i] = FromFloat64(rng.NormFloat64())
+		}
+		// Make later trials ill-conditioned with large cancelling terms
+		if trial >= 10 {
+			for i := 0; i < n; i += 2 {
+				a[i+1], b[i+1] = a[i].Neg(), b[i]
+				a[i+1] = Add(a[i+1], FromFloat64(rng.NormFloat64()*1e-3))
+			}
+		}
+
+		exact := exactDot(a, b)
+		want := fromRatRoundToOdd(exact)
+		got := DotProductCompensated(a, b)
+		if got != want {
+			t.Errorf("trial %d: DotProductCompensated = %v (%#04x), correctly rounded %v (%#04x)",
+				trial, got, got.Bits(), want, want.Bits())
+		}
+	}
+}
+
+func TestDotProductCompensatedBeatsNaive(t *testing.T) {
+	// Many small terms after a large one are lost by Float16 accumulation
+	a := []Float16{FromInt(2048)}
+	b := []Float16{One()}
+	for i := 0; i < 1000; i++ {
+		a = append(a, One())
+		b = append(b, FromFloat32(0.5))
+	}
+	want := FromInt(2548)
+	if got := DotProductCompensated(a, b); got != want {
+		t.Errorf("DotProductCompensated = %v, want %v", got, want)
+	}
+	if naive := DotProduct(a, b); naive == want {
+		t.Logf("naive DotProduct happened to be exact: %v", naive)
+	}
+
+	if got := DotProductCompensated(nil, nil); got != PositiveZero {
+		t.Errorf("empty dot product = %v, want 0", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic on length mismatch")
+		}
+	}()
+	DotProductCompensated(a, b[:1])
+}
+
+func TestRoundToOdd32(t *testing.T) {
+	one := float32(1)
+	up := math.Nextafter32(one, 2)
+	if got := roundToOdd32(one, 1e-20); got != up {
+		t.Errorf("roundToOdd32(1, +tiny) = %v, want %v", got, up)
+	}
+	if got := roundToOdd32(up, 1e-20); got != up {
+		t.Errorf("odd hi should be kept, got %v", got)
+	}
+	if got := roundToOdd32(one, 0); got != one {
+		t.Errorf("exact sum should be kept, got %v", got)
+	}
+	if got := roundToOdd32(one, -1e-20); got != math.Nextafter32(one, 0) {
+		t.Errorf("roundToOdd32(1, -tiny) = %v", got)
+	}
+}
+
+func TestMulDivSpecialCasesUnchanged(t *testing.T) {
+	var values []Float16
+	for _, v := range []Float16{
+		PositiveZero, PositiveInfinity, QuietNaN, FromBits(0x7C01), FromBits(0x7FFF),
+		SmallestSubnormal, FromBits(0x03FF), FromBits(0x0400), One(), FromInt(2),
+		FromFloat32(0.5), FromFloat32(3.14159), MaxValue, FromBits(0x3C01),
+	} {
+		values = append(values, v, v|SignMask)
+	}
+	modes := []ArithmeticMode{ModeIEEEArithmetic, ModeExactArithmetic, ModeFastArithmetic}
+	ops := []struct {
+		name      string
+		got, want func(a, b Float16, mode ArithmeticMode, rounding RoundingMode) (Float16, error)
+	}{
+		{"mul", MulWithMode, legacyMulWithMode},
+		{"div", DivWithMode, legacyDivWithMode},
+	}
+	for _, op := range ops {
+		for _, mode := range modes {
+			for _, a := range values {
+				for _, b := range values {
+					got, gotErr := op.got(a, b, mode, RoundNearestEven)
+					want, wantErr := op.want(a, b, mode, RoundNearestEven)
+					if got != want || (gotErr == nil) != (wantErr == nil) {
+						t.Fatalf("%s(%#04x, %#04x) mode %v = %#04x, %v; want %#04x, %v", op.name, a.Bits(), b.Bits(), mode, got.Bits(), gotErr, want.Bits(), wantErr)
+					}
+					if gotErr != nil && gotErr.Error() != wantErr.Error() {
+						t.Fatalf("%s(%#04x, %#04x) mode %v error %q, want %q", op.name, a.Bits(), b.Bits(), mode, gotErr, wantErr)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestClassify(t *testing.T) {
+	for i := 0; i < 1<<16; i++ {
+		f := Float16(i)
+		var want operandClass
+		switch {
+		case f.IsNaN():
+			want = classNaN
+		case f.IsInf(0):
+			want = classInf
+		case f.IsZero():
+			want = classZero
+		default:
+			want = classFinite
+		}
+		if got := classify(f); got != want {
+			t.Fatalf("classify(%#04x) = %d, want %d", i, got, want)
+		}
+	}
+}
+
+// legacyMulWithMode is MulWithMode before the special cases were unified by
+// classifyPair, kept as a reference for the bit-identical check
+func legacyMulWithMode(a, b Float16, mode ArithmeticMode, rounding RoundingMode) (Float16, error) {
+	// Handle special cases
+	// Check for zero times infinity cases first
+	aIsZero := a.IsZero()
+	bIsInf := b.IsInf(0)
+	if (aIsZero && bIsInf) || (a.IsInf(0) && b.IsZero()) {
+		// 0 * ∞ = NaN
+		if mode == ModeExactArithmetic {
+			return 0, &Float16Error{
+				Op:   "mul",
+				Msg:  "zero times infinity is undefined",
+				Code: ErrInvalidOperation,
+			}
+		}
+		return defaultNaN(), nil
+	}
+
+	// Handle zero cases
+	if aIsZero || b.IsZero() {
+		// Handle sign of zero result: 0 * anything = ±0
+		signA := a.Signbit()
+		signB := b.Signbit()
+		if signA != signB {
+			return NegativeZero, nil
+		}
+		return PositiveZero, nil
+	}
+
+	// Handle NaN cases
+	if a.IsNaN() || b.IsNaN() {
+		if mode == ModeExactArithmetic {
+			return 0, &Float16Error{
+				Op:   "mul",
+				Msg:  "NaN operand in exact mode",
+				Code: ErrNaN,
+			}
+		}
+		return defaultNaN(), nil
+	}
+
+	// Handle infinity cases
+	if a.IsInf(0) || b.IsInf(0) {
+		// Check for 0 * ∞ which is NaN
+		if (a.IsInf(0) && b.IsZero()) || (a.IsZero() && b.IsInf(0)) {
+			if mode == ModeExactArithmetic {
+				return 0, &Float16Error{
+					Op:   "mul",
+					Msg:  "zero times infinity is undefined",
+					Code: ErrInvalidOperation,
+				}
+			}
+			return defaultNaN(), nil
+		}
+
+		// ∞ * finite = ±∞ (sign depends on operand signs)
+		signA := a.Signbit()
+		signB := b.Signbit()
+		if signA != signB {
+			return NegativeInfinity, nil
+		}
+		return PositiveInfinity, nil
+	}
+
+	// For high performance, use float32 arithmetic
+	if mode == ModeFastArithmetic {
+		f32a := a.ToFloat32()
+		f32b := b.ToFloat32()
+		result := f32a * f32b
+		return FromFloat32(result), nil
+	}
+
+	// Full IEEE 754 implementation
+	return mulIEEE754(a, b, rounding)
+}
+
+// legacyDivWithMode is the matching reference for DivWithMode
+func legacyDivWithMode(a, b Float16, mode ArithmeticMode, rounding RoundingMode) (Float16, error) {
+	// Handle division by zero
+	if b.IsZero() {
+		if a.IsZero() {
+			// 0/0 = NaN
+			if mode == ModeExactArithmetic {
+				return 0, &Float16Error{
+					Op:   "div",
+					Msg:  "zero divided by zero is undefined",
+					Code: ErrInvalidOperation,
+				}
+			}
+			return defaultNaN(), nil
+		}
+		// finite/0 = ±∞
+		if mode == ModeExactArithmetic {
+			return 0, &Float16Error{
+				Op:   "div",
+				Msg:  "division by zero",
+				Code: ErrDivisionByZero,
+			}
+		}
+		signA := a.Signbit()
+		signB := b.Signbit()
+		if signA != signB {
+			return NegativeInfinity, nil
+		}
+		return PositiveInfinity, nil
+	}
+
+	// Handle zero dividend
+	if a.IsZero() {
+		// 0/finite = ±0
+		signA := a.Signbit()
+		signB := b.Signbit()
+		if signA != signB {
+			return NegativeZero, nil
+		}
+		return PositiveZero, nil
+	}
+
+	// Handle infinity cases
+	if a.IsInf(0) || b.IsInf(0) {
+		if a.IsInf(0) && b.IsInf(0) {
+			// ∞/∞ = NaN
+			if mode == ModeExactArithmetic {
+				return 0, &Float16Error{
+					Op:   "div",
+					Msg:  "infinity divided by infinity is undefined",
+					Code: ErrInvalidOperation,
+				}
+			}
+			return defaultNaN(), nil
+		}
+
+		if a.IsInf(0) {
+			// ∞/finite = ±∞
+			signA := a.Signbit()
+			signB := b.Signbit()
+			if signA != signB {
+				return NegativeInfinity, nil
+			}
+			return PositiveInfinity, nil
+		}
+
+		// finite/∞ = ±0
+		signA := a.Signbit()
+		signB := b.Signbit()
+		if signA != signB {
+			return NegativeZero, nil
+		}
+		return PositiveZero, nil
+	}
+
+	// Handle NaN cases
+	if a.IsNaN() || b.IsNaN() {
+		if mode == ModeExactArithmetic {
+			return 0, &Float16Error{
+				Op:   "div",
+				Msg:  "NaN operand in exact mode",
+				Code: ErrNaN,
+			}
+		}
+		return defaultNaN(), nil
+	}
+
+	// Handle infinity cases
+	if a.IsInf(0) && b.IsInf(0) {
+		// ∞/∞ = NaN
+		if mode == ModeExactArithmetic {
+			return 0, &Float16Error{
+				Op:   "div",
+				Msg:  "infinity divided by infinity is undefined",
+				Code: ErrInvalidOperation,
+			}
+		}
+		return defaultNaN(), nil
+	}
+
+	if a.IsInf(0) {
+		// ∞/finite = ±∞
+		signA := a.Signbit()
+		signB := b.Signbit()
+		if signA != signB {
+			return NegativeInfinity, nil
+		}
+		return PositiveInfinity, nil
+	}
+
+	if b.IsInf(0) {
+		// finite/∞ = ±0
+		signA := a.Signbit()
+		signB := b.Signbit()
+		if signA != signB {
+			return NegativeZero, nil
+		}
+		return PositiveZero, nil
+	}
+
+	// For high performance, use float32 arithmetic
+	if mode == ModeFastArithmetic {
+		f32a := a.ToFloat32()
+		f32b := b.ToFloat32()
+		result := f32a / f32b
+		return FromFloat32(result), nil
+	}
+
+	// Full IEEE 754 implementation
+	return divIEEE754(a, b, rounding)
+}
+
+func TestSliceMinMax(t *testing.T) {
+	tests := []struct {
+		name                       string
+		s                          []Float16
+		min, max                   Float16
+		minPropagate, maxPropagate Float16
+	}{
+		{"clean", []Float16{Three16, One16.Neg(), Ten16, Half16}, One16.Neg(), Ten16, One16.Neg(), Ten16},
+		{"infinities", []Float16{NegativeInfinity, MaxValue, PositiveInfinity}, NegativeInfinity, PositiveInfinity, NegativeInfinity, PositiveInfinity},
+		{"signed zeros", []Float16{PositiveZero, NegativeZero}, NegativeZero, PositiveZero, NegativeZero, PositiveZero},
+		{"single NaN", []Float16{Two16, QuietNaN, One16}, One16, Two16, QuietNaN, QuietNaN},
+		{"leading NaN", []Float16{SignalingNaN, Four16}, Four16, Four16, QuietNaN, QuietNaN},
+		{"only NaN", []Float16{QuietNaN}, QuietNaN, QuietNaN, QuietNaN, QuietNaN},
+		{"empty", nil, QuietNaN, QuietNaN, QuietNaN, QuietNaN},
+	}
+	same := func(got, want Float16) bool { return got == want || (got.IsNaN() && want.IsNaN()) }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SliceMin(tt.s); !same(got, tt.min) {
+				t.Errorf("SliceMin(%v) = %v, want %v", tt.s, got, tt.min)
+			}
+			if got := SliceMax(tt.s); !same(got, tt.max) {
+				t.Errorf("SliceMax(%v) = %v, want %v", tt.s, got, tt.max)
+			}
+			if got := SliceMinPropagate(tt.s); !same(got, tt.minPropagate) {
+				t.Errorf("SliceMinPropagate(%v) = %v, want %v", tt.s, got, tt.minPropagate)
+			}
+			if got := SliceMaxPropagate(tt.s); !same(got, tt.maxPropagate) {
+				t.Errorf("SliceMaxPropagate(%v) = %v, want %v", tt.s, got, tt.maxPropagate)
+			}
+		})
+	}
+}
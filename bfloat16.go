@@ -565,12 +565,12 @@ func BFloat16FromSlice64(s []float64) []BFloat16 {
 }
 
 // Convenience constants for common BFloat16 values
-var (
-	BFloat16Zero  = BFloat16PositiveZero
-	BFloat16One   = BFloat16FromFloat32(1.0)
-	BFloat16Two   = BFloat16FromFloat32(2.0)
-	BFloat16Half  = BFloat16FromFloat32(0.5)
-	BFloat16E     = BFloat16FromFloat32(float32(math.E))
-	BFloat16Pi    = BFloat16FromFloat32(float32(math.Pi))
-	BFloat16Sqrt2 = BFloat16FromFloat32(float32(math.Sqrt2))
+const (
+	BFloat16Zero           = BFloat16PositiveZero
+	BFloat16One   BFloat16 = 0x3F80
+	BFloat16Two   BFloat16 = 0x4000
+	BFloat16Half  BFloat16 = 0x3F00
+	BFloat16E     BFloat16 = 0x402E
+	BFloat16Pi    BFloat16 = 0x4049
+	BFloat16Sqrt2 BFloat16 = 0x3FB5
 )
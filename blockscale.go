@@ -0,0 +1,88 @@
+package float16
+
+import (
+	"fmt"
+	"math"
+)
+
+// Block scaling
+//
+// BlockScale splits the input into groups of groupSize elements, the last
+// group taking whatever remains, and stores each group as Float16 payload
+// values together with one power-of-two Float16 scale. The scale places the
+// group's largest finite magnitude in [2^14, 2^15), the top of the Float16
+// range, so small members keep as much precision as the format allows. As
+// the scale is a power of two, dividing by it and multiplying back are
+// exact, and the only error is the rounding of the payload to Float16.
+
+// blockTargetExp is the binade the largest magnitude of a group is scaled
+// into: [2^(blockTargetExp-1), 2^blockTargetExp)
+const blockTargetExp = 15
+
+// BlockScale returns one scale per group of groupSize elements of src and
+// the scaled payload, such that src[i] ≈ data[i]·scales[i/groupSize]. Scales
+// are powers of two between SmallestSubnormal and 2^15, derived from the
+// largest finite magnitude in the group; a group with no finite nonzero
+// member gets scale 1. Infinities and NaNs pass through to the payload
+// unchanged. It returns an ErrInvalidOperation error if groupSize is not
+// positive and an ErrOverflow error if a group's largest magnitude is too
+// large even for the largest scale, from about 2^31.
+func BlockScale(src []float32, groupSize int) (scales []Float16, data []Float16, err error) {
+	if groupSize <= 0 {
+		return nil, nil, &Float16Error{
+			Op:   "BlockScale",
+			Msg:  fmt.Sprintf("group size %d is not positive", groupSize),
+			Code: ErrInvalidOperation,
+		}
+	}
+
+	groups := (len(src) + groupSize - 1) / groupSize
+	scales = make([]Float16, groups)
+	data = make([]Float16, len(src))
+	for g := range scales {
+		group := src[g*groupSize : min((g+1)*groupSize, len(src))]
+		var amax float64
+		for _, v := range group {
+			if a := math.Abs(float64(v)); !math.IsInf(a, 0) && a > amax {
+				amax = a
+			}
+		}
+
+		k := 0
+		if amax != 0 {
+			_, e := math.Frexp(amax)
+			k = min(max(e-blockTargetExp, -24), 15)
+		}
+		scales[g] = FromFloat64(math.Ldexp(1, k))
+		out := data[g*groupSize:]
+		for i, v := range group {
+			out[i] = FromFloat64(math.Ldexp(float64(v), -k))
+			if out[i].IsInf(0) && !math.IsInf(float64(v), 0) {
+				return nil, nil, &Float16Error{
+					Op:   "BlockScale",
+					Msg:  fmt.Sprintf("group %d: %g overflows at scale 2^%d", g, v, k),
+					Code: ErrOverflow,
+				}
+			}
+		}
+	}
+	return scales, data, nil
+}
+
+// BlockDescale reconstructs the float32 values from the scales and payload
+// produced by BlockScale with the same groupSize. Every product is exact. It
+// panics if groupSize is not positive or the number of scales does not match
+// the number of groups in data.
+func BlockDescale(scales, data []Float16, groupSize int) []float32 {
+	if groupSize <= 0 {
+		panic("float16: block group size must be positive")
+	}
+	if len(scales) != (len(data)+groupSize-1)/groupSize {
+		panic("float16: block scale count does not match the data length")
+	}
+	dst := make([]float32, len(data))
+	for i, v := range data {
+		dst[i] = v.ToFloat32() * scales[i/groupSize].ToFloat32()
+	}
+	return dst
+}
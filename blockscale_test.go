@@ -0,0 +1,104 @@
+package float16
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestBlockScaleRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, groupSize := range []int{1, 32, 64, 50} {
+		src := make([]float32, 1000)
+		for i := range src {
+			// Magnitudes vary between groups over many binades
+			src[i] = float32(r.NormFloat64() * math.Ldexp(1, i/64*3-24))
+		}
+		scales, data, err := BlockScale(src, groupSize)
+		if err != nil {
+			t.Fatalf("groupSize %d: %v", groupSize, err)
+		}
+		if want := (len(src) + groupSize - 1) / groupSize; len(scales) != want {
+			t.Fatalf("groupSize %d: %d scales, want %d", groupSize, len(scales), want)
+		}
+
+		back := BlockDescale(scales, data, groupSize)
+		for i, v := range src {
+			scale := scales[i/groupSize].ToFloat64()
+			if frac, _ := math.Frexp(scale); frac != 0.5 {
+				t.Fatalf("scale %v is not a power of two", scale)
+			}
+			// Half an ulp of the payload, measured at the group's scale
+			bound := ulpAt(float64(v)/scale) / 2 * scale
+			if err := math.Abs(float64(back[i]) - float64(v)); err > bound {
+				t.Errorf("groupSize %d [%d]: %v came back as %v, error %g > %g", groupSize, i, v, back[i], err, bound)
+			}
+			if float64(back[i]) != data[i].ToFloat64()*scale {
+				t.Errorf("groupSize %d [%d]: descale is not exact", groupSize, i)
+			}
+		}
+	}
+}
+
+func TestBlockScaleSpecialGroups(t *testing.T) {
+	inf, nan := float32(math.Inf(1)), float32(math.NaN())
+	src := []float32{
+		0, float32(math.Copysign(0, -1)), 0, // all-zero group
+		inf, 3, nan, // non-finite members beside a finite one
+		nan, float32(math.Inf(-1)), 0, // nothing finite and nonzero
+		1e-30, // partial last group, far below Float16 range
+	}
+	scales, data, err := BlockScale(src, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantScales := []Float16{One16, FromFloat64(0x1p-13), One16, SmallestSubnormal}
+	for g, want := range wantScales {
+		if scales[g] != want {
+			t.Errorf("scale[%d] = %v, want %v", g, scales[g], want)
+		}
+	}
+	if !data[1].IsNegativeZero() {
+		t.Errorf("-0 scaled to %v", data[1])
+	}
+	if !data[3].IsInf(1) || !data[5].IsNaN() || !data[6].IsNaN() || !data[7].IsInf(-1) {
+		t.Errorf("non-finite members scaled to %v", data[3:8])
+	}
+
+	back := BlockDescale(scales, data, 3)
+	if back[4] != 3 || !math.IsInf(float64(back[3]), 1) || !math.IsNaN(float64(back[5])) {
+		t.Errorf("BlockDescale = %v", back[3:6])
+	}
+	if math.Float32bits(back[1]) != 1<<31 {
+		t.Errorf("BlockDescale lost the sign of -0: %v", back[1])
+	}
+
+	scales, data, err = BlockScale(nil, 32)
+	if err != nil || len(scales) != 0 || len(data) != 0 {
+		t.Errorf("BlockScale(nil) = (%v, %v, %v)", scales, data, err)
+	}
+}
+
+func TestBlockScaleErrors(t *testing.T) {
+	var fe *Float16Error
+	if _, _, err := BlockScale([]float32{1}, 0); !errors.As(err, &fe) || fe.Code != ErrInvalidOperation {
+		t.Errorf("groupSize 0: err = %v, want ErrInvalidOperation", err)
+	}
+	if _, _, err := BlockScale([]float32{1, 1e10}, 2); !errors.As(err, &fe) || fe.Code != ErrOverflow {
+		t.Errorf("1e10: err = %v, want ErrOverflow", err)
+	}
+	// The largest magnitude that fits at scale 2^15 still succeeds
+	if _, _, err := BlockScale([]float32{65504 * 32768}, 1); err != nil {
+		t.Errorf("MaxValue·2^15: %v", err)
+	}
+
+	for name, fn := range map[string]func(){
+		"group size": func() { BlockDescale([]Float16{One16}, []Float16{One16}, 0) },
+		"scales":     func() { BlockDescale([]Float16{One16}, make([]Float16, 3), 2) },
+	} {
+		if !panics(fn) {
+			t.Errorf("BlockDescale with bad %s did not panic", name)
+		}
+	}
+}
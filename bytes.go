@@ -0,0 +1,132 @@
+package float16
+
+import (
+	"encoding/binary"
+	"fmt"
+	"unsafe"
+)
+
+// Byte-level encoding helpers
+//
+// Every encoder and decoder here takes its byte order explicitly, or fixes
+// it in its name, so encoded data means the same thing on every host. When
+// the requested order matches the host's, PutSlice and ReadSlice copy the
+// slice memory directly instead of converting element by element.
+
+// hostOrder is the byte order of the running machine
+var hostOrder = func() binary.ByteOrder {
+	x := uint16(1)
+	if *(*byte)(unsafe.Pointer(&x)) == 1 {
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}()
+
+// isHostOrder reports whether data in the given order has the in-memory
+// layout of a []Float16, so it can be copied without per-element swapping.
+func isHostOrder(order binary.ByteOrder) bool {
+	return order == hostOrder || order == binary.NativeEndian
+}
+
+// float16Bytes returns the memory of s as a byte slice
+func float16Bytes(s []Float16) []byte {
+	if len(s) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(&s[0])), 2*len(s))
+}
+
+// PackFloat32ToFloat16Bytes converts each element of src to Float16 and writes
+// its 2-byte encoding into dst using the given byte order. No intermediate
+// []Float16 is allocated. It returns the number of bytes written, or an error
+// if dst is shorter than 2*len(src).
+func PackFloat32ToFloat16Bytes(dst []byte, src []float32, order binary.ByteOrder) (int, error) {
+	n := 2 * len(src)
+	if len(dst) < n {
+		return 0, &Float16Error{
+			Op:   "PackFloat32ToFloat16Bytes",
+			Msg:  fmt.Sprintf("destination too small: need %d bytes, have %d", n, len(dst)),
+			Code: ErrInvalidOperation,
+		}
+	}
+	h := metricsHook()
+	var events conversionEvents
+	for i, v := range src {
+		f := FromFloat32(v)
+		order.PutUint16(dst[2*i:], uint16(f))
+		if h != nil {
+			events.observe(float64(v), f)
+		}
+	}
+	if h != nil {
+		events.report(h)
+	}
+	return n, nil
+}
+
+// PutSlice writes the 2-byte encoding of each element of s into dst using the
+// given byte order. It returns the number of bytes written, or an error if dst
+// is shorter than 2*len(s).
+func PutSlice(dst []byte, s []Float16, order binary.ByteOrder) (int, error) {
+	n := 2 * len(s)
+	if len(dst) < n {
+		return 0, &Float16Error{
+			Op:   "PutSlice",
+			Msg:  fmt.Sprintf("destination too small: need %d bytes, have %d", n, len(dst)),
+			Code: ErrInvalidOperation,
+		}
+	}
+	if isHostOrder(order) {
+		copy(dst, float16Bytes(s))
+		return n, nil
+	}
+	for i, v := range s {
+		order.PutUint16(dst[2*i:], uint16(v))
+	}
+	return n, nil
+}
+
+// ReadSlice decodes src, which must hold a whole number of 2-byte values in
+// the given byte order, into a new slice.
+func ReadSlice(src []byte, order binary.ByteOrder) ([]Float16, error) {
+	if len(src)%2 != 0 {
+		return nil, &Float16Error{
+			Op:   "ReadSlice",
+			Msg:  fmt.Sprintf("odd source length %d", len(src)),
+			Code: ErrInvalidOperation,
+		}
+	}
+	s := make([]Float16, len(src)/2)
+	if isHostOrder(order) {
+		copy(float16Bytes(s), src)
+		return s, nil
+	}
+	for i := range s {
+		s[i] = Float16(order.Uint16(src[2*i:]))
+	}
+	return s, nil
+}
+
+// PutSliceBE is PutSlice in big-endian (network) byte order.
+func PutSliceBE(dst []byte, s []Float16) (int, error) {
+	return PutSlice(dst, s, binary.BigEndian)
+}
+
+// ReadSliceBE is ReadSlice in big-endian (network) byte order.
+func ReadSliceBE(src []byte) ([]Float16, error) {
+	return ReadSlice(src, binary.BigEndian)
+}
+
+// MarshalBinaryBE returns the 2-byte big-endian (network order) encoding of f.
+func (f Float16) MarshalBinaryBE() []byte {
+	return binary.BigEndian.AppendUint16(nil, uint16(f))
+}
+
+// UnmarshalBinaryBE sets f from its 2-byte big-endian encoding.
+func (f *Float16) UnmarshalBinaryBE(data []byte) error {
+	if len(data) != 2 {
+		return fmt.Errorf("float16 Float16.UnmarshalBinaryBE: expected 2 bytes, got %d", len(data))
+	}
+	*f = Float16(binary.BigEndian.Uint16(data))
+	return nil
+}
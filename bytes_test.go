@@ -0,0 +1,186 @@
+package float16
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestPackFloat32ToFloat16Bytes(t *testing.T) {
+	src := []float32{0, -0.0, 1, -2.5, 65504, 1e6, 1e-8, float32(math.NaN()), float32(math.Inf(-1)), 0.1}
+
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		t.Run(order.String(), func(t *testing.T) {
+			want := make([]byte, 2*len(src))
+			for i, v := range ToSlice16(src) {
+				order.PutUint16(want[2*i:], v.Bits())
+			}
+
+			dst := make([]byte, len(want)+3)
+			n, err := PackFloat32ToFloat16Bytes(dst, src, order)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if n != len(want) {
+				t.Fatalf("wrote %d bytes, want %d", n, len(want))
+			}
+			if string(dst[:n]) != string(want) {
+				t.Errorf("bytes = %x, want %x", dst[:n], want)
+			}
+		})
+	}
+}
+
+func TestPackFloat32ToFloat16BytesShortDst(t *testing.T) {
+	n, err := PackFloat32ToFloat16Bytes(make([]byte, 3), []float32{1, 2}, binary.LittleEndian)
+	if err == nil {
+		t.Fatal("expected error for short destination")
+	}
+	if n != 0 {
+		t.Errorf("n = %d, want 0", n)
+	}
+	var fe *Float16Error
+	if !errors.As(err, &fe) || fe.Code != ErrInvalidOperation {
+		t.Errorf("error = %v, want Float16Error with ErrInvalidOperation", err)
+	}
+
+	if n, err := PackFloat32ToFloat16Bytes(nil, nil, binary.LittleEndian); n != 0 || err != nil {
+		t.Errorf("empty input: got (%d, %v), want (0, nil)", n, err)
+	}
+}
+
+func TestSliceByteOrder(t *testing.T) {
+	s := []Float16{PositiveZero, NegativeZero, One16, MaxValue, SmallestSubnormal, QuietNaN, NegativeInfinity, 0x1234}
+
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		t.Run(order.String(), func(t *testing.T) {
+			dst := make([]byte, 2*len(s)+1)
+			n, err := PutSlice(dst, s, order)
+			if err != nil || n != 2*len(s) {
+				t.Fatalf("PutSlice = (%d, %v)", n, err)
+			}
+			for i, v := range s {
+				if got := order.Uint16(dst[2*i:]); got != v.Bits() {
+					t.Errorf("element %d encodes as 0x%04x, want 0x%04x", i, got, v.Bits())
+				}
+			}
+			back, err := ReadSlice(dst[:n], order)
+			if err != nil {
+				t.Fatalf("ReadSlice: %v", err)
+			}
+			for i := range s {
+				if back[i] != s[i] {
+					t.Errorf("round trip [%d] = 0x%04x, want 0x%04x", i, back[i].Bits(), s[i].Bits())
+				}
+			}
+		})
+	}
+
+	var fe *Float16Error
+	if _, err := PutSlice(make([]byte, 3), s[:2], binary.BigEndian); !errors.As(err, &fe) || fe.Code != ErrInvalidOperation {
+		t.Errorf("PutSlice short dst: err = %v, want ErrInvalidOperation", err)
+	}
+	if _, err := ReadSlice(make([]byte, 3), binary.BigEndian); !errors.As(err, &fe) || fe.Code != ErrInvalidOperation {
+		t.Errorf("ReadSlice odd length: err = %v, want ErrInvalidOperation", err)
+	}
+}
+
+func TestSliceForeignOrder(t *testing.T) {
+	if isHostOrder(binary.LittleEndian) == isHostOrder(binary.BigEndian) {
+		t.Fatal("exactly one of little- and big-endian must be the host order")
+	}
+	if !isHostOrder(binary.NativeEndian) || !isHostOrder(hostOrder) {
+		t.Error("native order not recognized as the host order")
+	}
+
+	s := []Float16{One16, FromBits(0xabcd), NegativeZero, QuietNaN}
+	fixtures := map[binary.ByteOrder][]byte{
+		binary.LittleEndian: {0x00, 0x3c, 0xcd, 0xab, 0x00, 0x80, 0x00, 0x7e},
+		binary.BigEndian:    {0x3c, 0x00, 0xab, 0xcd, 0x80, 0x00, 0x7e, 0x00},
+	}
+	fixtures[binary.NativeEndian] = fixtures[hostOrder]
+
+	// Whichever order the host has, one of the two fixtures is foreign to it
+	// and must go through the swapping path.
+	for order, want := range fixtures {
+		t.Run(order.String(), func(t *testing.T) {
+			dst := make([]byte, len(want))
+			if _, err := PutSlice(dst, s, order); err != nil || string(dst) != string(want) {
+				t.Errorf("PutSlice = %x, %v, want %x", dst, err, want)
+			}
+			// Decode from an odd offset so the copy path sees unaligned input
+			buf := append([]byte{0xff}, want...)
+			got, err := ReadSlice(buf[1:], order)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range s {
+				if got[i] != s[i] {
+					t.Errorf("ReadSlice[%d] = %#04x, want %#04x", i, got[i].Bits(), s[i].Bits())
+				}
+			}
+		})
+	}
+
+	// A buffer written in one order and read in the other comes back swapped
+	got, err := ReadSlice(fixtures[binary.LittleEndian], binary.BigEndian)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range s {
+		if want := v.Bits()>>8 | v.Bits()<<8; got[i].Bits() != want {
+			t.Errorf("swapped[%d] = %#04x, want %#04x", i, got[i].Bits(), want)
+		}
+	}
+}
+
+func BenchmarkReadSlice(b *testing.B) {
+	src := make([]byte, 1<<14)
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		b.Run(order.String(), func(b *testing.B) {
+			b.SetBytes(int64(len(src)))
+			for i := 0; i < b.N; i++ {
+				if _, err := ReadSlice(src, order); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestBigEndianHelpers(t *testing.T) {
+	s := []Float16{One16, FromBits(0xabcd), NegativeZero}
+
+	dst := make([]byte, 2*len(s))
+	if _, err := PutSliceBE(dst, s); err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{0x3c, 0x00, 0xab, 0xcd, 0x80, 0x00}; string(dst) != string(want) {
+		t.Errorf("PutSliceBE = %x, want %x", dst, want)
+	}
+	back, err := ReadSliceBE(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range s {
+		if back[i] != s[i] {
+			t.Errorf("ReadSliceBE[%d] = %v, want %v", i, back[i], s[i])
+		}
+	}
+
+	for _, v := range s {
+		data := v.MarshalBinaryBE()
+		if binary.BigEndian.Uint16(data) != v.Bits() || len(data) != 2 {
+			t.Errorf("%v.MarshalBinaryBE() = %x", v, data)
+		}
+		var got Float16
+		if err := got.UnmarshalBinaryBE(data); err != nil || got != v {
+			t.Errorf("UnmarshalBinaryBE(%x) = (%v, %v), want %v", data, got, err, v)
+		}
+	}
+	var f Float16
+	if err := f.UnmarshalBinaryBE([]byte{1}); err == nil {
+		t.Error("UnmarshalBinaryBE accepted 1 byte")
+	}
+}
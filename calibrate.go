@@ -0,0 +1,61 @@
+package float16
+
+import "math"
+
+// Quantization calibration
+
+// CalibrateClipThreshold returns the candidate clipping threshold that gives
+// the lowest mean-squared reconstruction error when s is clipped to [-t, t]
+// and quantized to signed 8-bit integers. See CalibrateClipThresholdBits.
+func CalibrateClipThreshold(s []Float16, candidateThresholds []Float16) (best Float16, mse float64) {
+	return CalibrateClipThresholdBits(s, candidateThresholds, 8)
+}
+
+// CalibrateClipThresholdBits is CalibrateClipThreshold for a bit width
+// between 2 and 16. Each candidate t defines symmetric uniform quantization
+// with 2^(bits-1)-1 steps on each side of zero; values beyond ±t are clipped.
+// The error is computed in float64 against the unclipped data. NaNs and
+// infinities in s are ignored, as are candidates that are not finite and
+// positive. If there is no usable candidate or no usable data, best is 0 and
+// mse is +Inf or 0 respectively. Ties go to the earlier candidate.
+func CalibrateClipThresholdBits(s []Float16, candidateThresholds []Float16, bits int) (best Float16, mse float64) {
+	if bits < 2 || bits > 16 {
+		panic("float16: quantization bit width must be between 2 and 16")
+	}
+	levels := float64(int(1)<<(bits-1) - 1)
+
+	data := make([]float64, 0, len(s))
+	for _, v := range s {
+		if v.IsFinite() {
+			data = append(data, v.ToFloat64())
+		}
+	}
+
+	mse = math.Inf(1)
+	for _, t := range candidateThresholds {
+		if !t.IsFinite() || t.ToFloat64() <= 0 {
+			continue
+		}
+		e := quantizationMSE(data, t.ToFloat64(), levels)
+		if e < mse {
+			best, mse = t, e
+		}
+	}
+	return best, mse
+}
+
+// quantizationMSE returns the mean-squared error of clipping data to
+// [-limit, limit] and rounding to levels steps per side
+func quantizationMSE(data []float64, limit, levels float64) float64 {
+	if len(data) == 0 {
+		return 0
+	}
+	scale := limit / levels
+	var sum float64
+	for _, x := range data {
+		q := math.RoundToEven(math.Max(-levels, math.Min(levels, x/scale))) * scale
+		d := x - q
+		sum += d * d
+	}
+	return sum / float64(len(data))
+}
@@ -0,0 +1,76 @@
+package float16
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestCalibrateClipThreshold(t *testing.T) {
+	rng := rand.New(rand.NewSource(9))
+	gaussian := make([]Float16, 20000)
+	for i := range gaussian {
+		gaussian[i] = FromFloat64(rng.NormFloat64())
+	}
+	var candidates []Float16
+	for c := 0.25; c <= 8; c += 0.25 {
+		candidates = append(candidates, FromFloat64(c))
+	}
+
+	// The MSE-optimal clip for a unit Gaussian is about 2.5σ at 4 bits and
+	// about 4σ at 8 bits; clipping at the extreme sample wastes resolution
+	tests := []struct {
+		name   string
+		bits   int
+		lo, hi float64
+	}{
+		{"4 bits", 4, 2, 3},
+		{"8 bits", 8, 3.25, 4.75},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			best, mse := CalibrateClipThresholdBits(gaussian, candidates, tt.bits)
+			if b := best.ToFloat64(); b < tt.lo || b > tt.hi {
+				t.Errorf("best threshold %v, want in [%v, %v]", b, tt.lo, tt.hi)
+			}
+			for _, c := range candidates {
+				if _, other := CalibrateClipThresholdBits(gaussian, []Float16{c}, tt.bits); other < mse {
+					t.Errorf("candidate %v has MSE %v below the chosen %v", c, other, mse)
+				}
+			}
+		})
+	}
+
+	if best, _ := CalibrateClipThreshold(gaussian, candidates); best != mustCalibrate(gaussian, candidates, 8) {
+		t.Error("CalibrateClipThreshold should use 8 bits")
+	}
+}
+
+func mustCalibrate(s, candidates []Float16, bits int) Float16 {
+	best, _ := CalibrateClipThresholdBits(s, candidates, bits)
+	return best
+}
+
+func TestCalibrateClipThresholdEdgeCases(t *testing.T) {
+	grid := []Float16{FromInt(-1), PositiveZero, One(), QuietNaN, PositiveInfinity}
+	best, mse := CalibrateClipThreshold(grid, []Float16{FromFloat32(0.5), One(), FromInt(2)})
+	if best != One() || mse != 0 {
+		t.Errorf("exactly representable data: best %v mse %v, want 1 and 0", best, mse)
+	}
+
+	best, mse = CalibrateClipThreshold(grid, []Float16{PositiveZero, FromInt(-1), QuietNaN, PositiveInfinity})
+	if best != PositiveZero || !math.IsInf(mse, 1) {
+		t.Errorf("no usable candidates: best %v mse %v, want 0 and +Inf", best, mse)
+	}
+
+	if _, mse := CalibrateClipThreshold(nil, []Float16{One()}); mse != 0 {
+		t.Errorf("empty data mse = %v, want 0", mse)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for 1-bit quantization")
+		}
+	}()
+	CalibrateClipThresholdBits(grid, []Float16{One()}, 1)
+}
@@ -0,0 +1,164 @@
+package float16
+
+import (
+	"fmt"
+	"math"
+)
+
+// Two-dimensional image kernels
+
+// BorderMode selects how pixels outside the image are sampled
+type BorderMode int
+
+const (
+	// BorderClamp repeats the nearest edge pixel (aaa|abcd|ddd)
+	BorderClamp BorderMode = iota
+	// BorderReflect mirrors about the edge pixel without repeating it (cb|abcd|cb)
+	BorderReflect
+	// BorderZero treats pixels outside the image as zero
+	BorderZero
+)
+
+// Conv2DSeparable convolves the w×h row-major plane src with the horizontal
+// kernel kx followed by the vertical kernel ky, using BorderClamp at the
+// edges. See Conv2DSeparableWithBorder.
+func Conv2DSeparable(src []Float16, w, h int, kx, ky []Float16) ([]Float16, error) {
+	return Conv2DSeparableWithBorder(src, w, h, kx, ky, BorderClamp)
+}
+
+// Conv2DSeparableWithBorder convolves the w×h row-major plane src with the
+// horizontal kernel kx followed by the vertical kernel ky. Both kernels must
+// have odd length and are centered on the output pixel. Accumulation and the
+// intermediate plane use float32; each output is rounded to Float16 once.
+func Conv2DSeparableWithBorder(src []Float16, w, h int, kx, ky []Float16, border BorderMode) ([]Float16, error) {
+	if w < 0 || h < 0 || len(src) != w*h {
+		return nil, &Float16Error{
+			Op:   "Conv2DSeparable",
+			Msg:  fmt.Sprintf("plane of length %d does not match %dx%d", len(src), w, h),
+			Code: ErrInvalidOperation,
+		}
+	}
+	if len(kx)%2 == 0 || len(ky)%2 == 0 {
+		return nil, &Float16Error{
+			Op:   "Conv2DSeparable",
+			Msg:  "kernel lengths must be odd",
+			Code: ErrInvalidOperation,
+		}
+	}
+	if border < BorderClamp || border > BorderZero {
+		return nil, &Float16Error{
+			Op:   "Conv2DSeparable",
+			Msg:  fmt.Sprintf("unknown border mode %d", border),
+			Code: ErrInvalidOperation,
+		}
+	}
+
+	kx32 := ToSlice32(kx)
+	ky32 := ToSlice32(ky)
+	rx, ry := len(kx)/2, len(ky)/2
+
+	// Horizontal pass into a float32 plane
+	tmp := make([]float32, len(src))
+	for y := 0; y < h; y++ {
+		row := src[y*w : (y+1)*w]
+		for x := 0; x < w; x++ {
+			var acc float32
+			for k, kv := range kx32 {
+				if i, ok := borderIndex(x+k-rx, w, border); ok {
+					acc += kv * row[i].ToFloat32()
+				}
+			}
+			tmp[y*w+x] = acc
+		}
+	}
+
+	// Vertical pass and a single rounding to Float16
+	dst := make([]Float16, len(src))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var acc float32
+			for k, kv := range ky32 {
+				if j, ok := borderIndex(y+k-ry, h, border); ok {
+					acc += kv * tmp[j*w+x]
+				}
+			}
+			dst[y*w+x] = FromFloat32(acc)
+		}
+	}
+	return dst, nil
+}
+
+// borderIndex maps a possibly out-of-range index into [0, n) according to the
+// border mode. It reports false when the sample should be treated as zero.
+func borderIndex(i, n int, border BorderMode) (int, bool) {
+	if i >= 0 && i < n {
+		return i, true
+	}
+	switch border {
+	case BorderClamp:
+		if i < 0 {
+			return 0, true
+		}
+		return n - 1, true
+	case BorderReflect:
+		if n == 1 {
+			return 0, true
+		}
+		period := 2 * (n - 1)
+		i %= period
+		if i < 0 {
+			i += period
+		}
+		if i >= n {
+			i = period - i
+		}
+		return i, true
+	default:
+		return 0, false
+	}
+}
+
+// GaussianKernel returns a normalized 1-D Gaussian kernel of length
+// 2*radius+1 with standard deviation sigma. The taps are Float16 values whose
+// exact sum is 1: the outer taps are rounded to a multiple of the center tap's
+// spacing and the center tap absorbs the remainder. It panics if sigma is not
+// positive and finite or radius is negative.
+func GaussianKernel(sigma Float16, radius int) []Float16 {
+	s := sigma.ToFloat64()
+	if !(s > 0) || math.IsInf(s, 0) || radius < 0 {
+		panic("float16: invalid Gaussian kernel parameters")
+	}
+
+	ideal := make([]float64, 2*radius+1)
+	var total float64
+	for i := range ideal {
+		d := float64(i - radius)
+		ideal[i] = math.Exp(-d * d / (2 * s * s))
+		total += ideal[i]
+	}
+	for i := range ideal {
+		ideal[i] /= total
+	}
+
+	// Start from the spacing of Float16 values around the ideal center tap and
+	// coarsen it if the adjusted center lands in a coarser binade.
+	_, exp := math.Frexp(ideal[radius])
+	quantum := math.Ldexp(1, exp-1-MantissaLen)
+	kernel := make([]Float16, len(ideal))
+	for {
+		var outer float64
+		for i, v := range ideal {
+			if i == radius {
+				continue
+			}
+			kernel[i] = FromFloat64(math.RoundToEven(v/quantum) * quantum)
+			outer += kernel[i].ToFloat64()
+		}
+		center := 1 - outer
+		kernel[radius] = FromFloat64(center)
+		if kernel[radius].ToFloat64() == center {
+			return kernel
+		}
+		quantum *= 2
+	}
+}
@@ -0,0 +1,154 @@
+package float16
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// naiveConv2D computes the full 2-D convolution with the outer-product kernel in float64
+func naiveConv2D(src []Float16, w, h int, kx, ky []Float16, border BorderMode) []float64 {
+	rx, ry := len(kx)/2, len(ky)/2
+	out := make([]float64, len(src))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var acc float64
+			for j := range ky {
+				yy, okY := borderIndex(y+j-ry, h, border)
+				for i := range kx {
+					xx, okX := borderIndex(x+i-rx, w, border)
+					if okX && okY {
+						acc += ky[j].ToFloat64() * kx[i].ToFloat64() * src[yy*w+xx].ToFloat64()
+					}
+				}
+			}
+			out[y*w+x] = acc
+		}
+	}
+	return out
+}
+
+func TestConv2DSeparableMatchesNaive(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	const w, h = 9, 7
+	src := make([]Float16, w*h)
+	for i := range src {
+		src[i] = FromFloat64(rng.Float64()*8 - 4)
+	}
+	kx := []Float16{FromFloat32(0.25), FromFloat32(0.5), FromFloat32(0.25)}
+	ky := GaussianKernel(FromFloat32(1.2), 2)
+
+	for _, border := range []BorderMode{BorderClamp, BorderReflect, BorderZero} {
+		got, err := Conv2DSeparableWithBorder(src, w, h, kx, ky, border)
+		if err != nil {
+			t.Fatalf("border %d: %v", border, err)
+		}
+		want := naiveConv2D(src, w, h, kx, ky, border)
+		for i := range got {
+			ulp := math.Max(math.Abs(NextAfter(got[i], PositiveInfinity).ToFloat64()-got[i].ToFloat64()), SmallestSubnormal.ToFloat64())
+			if diff := math.Abs(got[i].ToFloat64() - want[i]); diff > ulp {
+				t.Errorf("border %d pixel %d: got %v, want %v (diff %g > ulp %g)", border, i, got[i], want[i], diff, ulp)
+			}
+		}
+	}
+}
+
+func TestConv2DSeparableBorders(t *testing.T) {
+	// A single row with a pure shift kernel exposes the sampled border pixel
+	src := []Float16{FromInt(1), FromInt(2), FromInt(3), FromInt(4)}
+	shiftLeft := []Float16{0, 0, One()} // out[x] = src[x+1]
+	identity := []Float16{One()}
+
+	tests := []struct {
+		border BorderMode
+		last   Float16
+	}{
+		{BorderClamp, FromInt(4)},
+		{BorderReflect, FromInt(3)},
+		{BorderZero, PositiveZero},
+	}
+	for _, tt := range tests {
+		got, err := Conv2DSeparableWithBorder(src, 4, 1, shiftLeft, identity, tt.border)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got[0] != FromInt(2) || got[3] != tt.last {
+			t.Errorf("border %d: got %v, want [2 ... %v]", tt.border, got, tt.last)
+		}
+	}
+
+	for _, c := range []struct{ i, n, want int }{{-1, 4, 1}, {-3, 4, 3}, {4, 4, 2}, {7, 4, 1}, {-2, 1, 0}} {
+		if got, _ := borderIndex(c.i, c.n, BorderReflect); got != c.want {
+			t.Errorf("reflect index(%d, %d) = %d, want %d", c.i, c.n, got, c.want)
+		}
+	}
+}
+
+func TestConv2DSeparableErrors(t *testing.T) {
+	k := []Float16{One()}
+	cases := []struct {
+		name string
+		run  func() error
+	}{
+		{"size mismatch", func() error { _, err := Conv2DSeparable(make([]Float16, 5), 2, 2, k, k); return err }},
+		{"even kernel", func() error {
+			_, err := Conv2DSeparable(make([]Float16, 4), 2, 2, []Float16{One(), One()}, k)
+			return err
+		}},
+		{"empty kernel", func() error { _, err := Conv2DSeparable(make([]Float16, 4), 2, 2, k, nil); return err }},
+		{"bad border", func() error {
+			_, err := Conv2DSeparableWithBorder(make([]Float16, 4), 2, 2, k, k, BorderMode(9))
+			return err
+		}},
+	}
+	for _, c := range cases {
+		var fe *Float16Error
+		if err := c.run(); !errors.As(err, &fe) || fe.Code != ErrInvalidOperation {
+			t.Errorf("%s: err = %v, want ErrInvalidOperation", c.name, err)
+		}
+	}
+}
+
+func TestGaussianKernel(t *testing.T) {
+	for _, sigma := range []float32{0.3, 0.8, 1, 1.5, 2.5, 6} {
+		for _, radius := range []int{0, 1, 2, 4, 9} {
+			k := GaussianKernel(FromFloat32(sigma), radius)
+			if len(k) != 2*radius+1 {
+				t.Fatalf("sigma %v radius %d: len = %d", sigma, radius, len(k))
+			}
+			var sum float64
+			for i, v := range k {
+				sum += v.ToFloat64()
+				if v != k[len(k)-1-i] {
+					t.Errorf("sigma %v radius %d: kernel not symmetric", sigma, radius)
+				}
+			}
+			if sum != 1 {
+				t.Errorf("sigma %v radius %d: sum = %v, want exactly 1", sigma, radius, sum)
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for non-positive sigma")
+		}
+	}()
+	GaussianKernel(PositiveZero, 1)
+}
+
+func BenchmarkConv2DSeparableGaussian5(b *testing.B) {
+	const n = 1024
+	src := make([]Float16, n*n)
+	for i := range src {
+		src[i] = FromInt(i % 255)
+	}
+	k := GaussianKernel(One(), 2)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Conv2DSeparable(src, n, n, k, k); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
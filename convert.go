@@ -1,8 +1,13 @@
 package float16
 
 import (
+	"errors"
+	"fmt"
 	"math"
+	"math/big"
+	"math/bits"
 	"strconv"
+	"strings"
 )
 
 // FromFloat32 converts a float32 value to a Float16 value.
@@ -17,6 +22,13 @@ func FromFloat32(f32 float32) Float16 {
 // It mirrors fromFloat32New but respects the explicit rounding mode instead of always
 // rounding to nearest-even.
 func FromFloat32WithRounding(f32 float32, mode RoundingMode) Float16 {
+	result, _ := fromFloat32WithRoundingChecked(f32, mode)
+	return result
+}
+
+// fromFloat32WithRoundingChecked is FromFloat32WithRounding with the
+// Config.DebugChecks error from packing the result
+func fromFloat32WithRoundingChecked(f32 float32, mode RoundingMode) (Float16, error) {
 	bits := math.Float32bits(f32)
 	sign := uint16(bits >> 31)
 	exp := int32((bits >> 23) & 0xff)
@@ -25,14 +37,14 @@ func FromFloat32WithRounding(f32 float32, mode RoundingMode) Float16 {
 	// Special cases
 	if exp == 0xff {
 		if mant == 0 {
-			return Float16(sign<<15 | 0x7c00) // infinity
+			return Float16(sign<<15 | 0x7c00), nil // infinity
 		}
-		return Float16(sign<<15 | 0x7e00) // qNaN
+		return nanFromPayload(sign, uint16(mant>>13)), nil
 	}
 
 	// Zero (preserve sign)
 	if exp == 0 && mant == 0 {
-		return Float16(sign << 15)
+		return Float16(sign << 15), nil
 	}
 
 	// Adjust exponent bias: float32 (127) -> float16 (15)
@@ -40,22 +52,32 @@ func FromFloat32WithRounding(f32 float32, mode RoundingMode) Float16 {
 
 	// Overflow to infinity
 	if exp >= 0x1f {
-		return Float16(sign<<15 | 0x7c00)
+		return Float16(sign<<15 | 0x7c00), nil
 	}
 
 	// Underflow and subnormals
 	if exp <= 0 {
 		if exp < -10 {
-			// Too small for subnormal even after rounding; return signed zero
-			return Float16(sign << 15)
+			// Below half the smallest subnormal: only the directed modes
+			// that round away from zero produce a nonzero result
+			if (mode == RoundTowardPositive && sign == 0) || (mode == RoundTowardNegative && sign != 0) {
+				return Float16(sign<<15 | 1), nil
+			}
+			return Float16(sign << 15), nil
+		}
+		// Convert to subnormal, folding the bits shifted out into a sticky bit
+		full := mant | 1<<23
+		mant = full >> uint(1-exp)
+		if full&(1<<uint(1-exp)-1) != 0 {
+			mant |= 1
 		}
-		// Convert to subnormal
-		mant = (mant | 1<<23) >> uint(1-exp)
 		// Round mantissa down to 10 bits using the requested mode
 		if shouldRoundWithMode(mant, 13, sign<<15, mode) {
 			mant += 1 << 13
 		}
-		return Float16(uint16(sign<<15) | uint16(mant>>13))
+		// A carry out of the mantissa produces the smallest normal
+		mant >>= 13
+		return composeResult(sign, uint16(mant>>MantissaLen), uint16(mant&MantissaMask))
 	}
 
 	// Normal numbers
@@ -74,11 +96,11 @@ func FromFloat32WithRounding(f32 float32, mode RoundingMode) Float16 {
 
 	// Exponent overflow => infinity
 	if exp >= 0x1f {
-		return Float16(sign<<15 | 0x7c00)
+		return Float16(sign<<15 | 0x7c00), nil
 	}
 
 	mantissa10 := (mant >> 13) & 0x3ff
-	return Float16(uint16(sign<<15) | uint16(exp<<10) | uint16(mantissa10))
+	return composeResult(sign, uint16(exp), uint16(mantissa10))
 }
 
 // shouldRoundWithMode is like shouldRound but uses an explicit rounding mode
@@ -99,7 +121,7 @@ func shouldRoundWithMode(mantissa uint32, shift int, sign uint16, mode RoundingM
 	case RoundNearestEven:
 		return guard == 1 && (sticky != 0 || lsb == 1)
 	case RoundNearestAway:
-		return guard == 1 || sticky != 0
+		return guard == 1
 	case RoundTowardZero:
 		return false
 	case RoundTowardPositive:
@@ -121,8 +143,8 @@ func (f Float16) ToFloat32() float32 {
 
 	// Handle special cases
 	if exp == ExponentInfinity {
-		if mant != 0 { // NaN
-			return float32(math.NaN())
+		if mant != 0 { // NaN: keep sign and payload
+			return math.Float32frombits(uint32(bits&SignMask)<<16 | 0x7f800000 | uint32(mant)<<13)
 		}
 		if sign {
 			return float32(math.Inf(-1))
@@ -153,10 +175,18 @@ func (f Float16) ToFloat32() float32 {
 	return val
 }
 
-// FromFloat64 converts a float64 value to a Float16 value.
-// It handles special cases like NaN, infinities, and zeros.
+// FromFloat64 converts a float64 value to a Float16 value with round to
+// nearest even. It handles special cases like NaN, infinities, and zeros.
 func FromFloat64(f64 float64) Float16 {
-	return FromFloat32(float32(f64)) // Simplified: convert via float32
+	if f64 != f64 {
+		return nanFromFloat64(f64)
+	}
+	if f64 == 0 {
+		return FromFloat32(float32(f64)) // keeps the sign of zero
+	}
+	// Narrowing to float32 with round-to-odd first keeps this a single
+	// rounding; a plain float32(f64) could round onto a Float16 midpoint
+	return FromFloat32(roundToOdd64(f64, 0))
 }
 
 // ToFloat16 converts a float64 to a Float16 value.
@@ -165,6 +195,30 @@ func ToFloat16(f64 float64) Float16 {
 	return FromFloat64(f64)
 }
 
+// ToFloat16NoInline is FromFloat32 behind a call the compiler may not
+// inline. It exists only for benchmarks that measure the full call and
+// conversion cost, which inlining and constant folding would otherwise hide.
+//
+//go:noinline
+func ToFloat16NoInline(f32 float32) Float16 {
+	return FromFloat32(f32)
+}
+
+// ToFloat32NoInline is Float16.ToFloat32 behind a call the compiler may not
+// inline. Like ToFloat16NoInline, it exists only for benchmarking.
+//
+//go:noinline
+func ToFloat32NoInline(f Float16) float32 {
+	return f.ToFloat32()
+}
+
+// ToFloat16WithMode is the error-reporting counterpart of ToFloat16; it is
+// FromFloat64WithMode with round to nearest even. In ModeStrict it returns an
+// error for NaN, infinities, overflow and underflow.
+func ToFloat16WithMode(f64 float64, convMode ConversionMode) (Float16, error) {
+	return FromFloat64WithMode(f64, convMode, RoundNearestEven)
+}
+
 // ToSlice16 converts a slice of float32 to a slice of Float16.
 // This is a convenience wrapper used in tests and utilities.
 func ToSlice16(s []float32) []Float16 {
@@ -172,13 +226,41 @@ func ToSlice16(s []float32) []Float16 {
 	for i, v := range s {
 		result[i] = FromFloat32(v)
 	}
+	if h := metricsHook(); h != nil {
+		reportSlice32(h, s, result)
+	}
 	return result
 }
 
-// FromFloat64WithMode converts a float64 to Float16 with specified conversion and rounding modes
+// FromFloat64WithMode converts a float64 to Float16 with a single rounding in
+// roundMode. In ModeStrict it returns an error for NaN, infinities, overflow
+// and underflow.
 func FromFloat64WithMode(f64 float64, convMode ConversionMode, roundMode RoundingMode) (Float16, error) {
-	// Basic conversion first
-	result := FromFloat64(f64)
+	var result Float16
+	switch {
+	case f64 != f64:
+		result = nanFromFloat64(f64)
+	case math.IsInf(f64, 0):
+		result = FromFloat64(f64)
+	default:
+		// One rounding in the requested mode, with the fields validated like
+		// any other kernel result
+		r := fromFloat64Rounded(f64, roundMode)
+		var err error
+		result, err = composeResult(uint16(r>>15), uint16(r&ExponentMask>>MantissaLen), uint16(r&MantissaMask))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if h := metricsHook(); h != nil {
+		var e conversionEvents
+		e.observe(f64, result)
+		e.report(h)
+	}
+	if h := inexactHook(); h != nil && isInexact(f64, result) {
+		h(f64)
+	}
 
 	if convMode == ModeStrict {
 		// NaN
@@ -203,12 +285,195 @@ func FromFloat64WithMode(f64 float64, convMode ConversionMode, roundMode Roundin
 	return result, nil
 }
 
+// ConversionEvent describes what happened in a single conversion to Float16
+type ConversionEvent int
+
+// The numeric values are part of the wire format and never change.
+const (
+	// ConversionExact means the input was represented exactly
+	ConversionExact ConversionEvent = 0
+	// ConversionRounded means a finite normal result differs from the input
+	ConversionRounded ConversionEvent = 1
+	// ConversionOverflowed means a finite input was too large in magnitude
+	// and became an infinity, or saturated at MaxValue under a directed
+	// rounding mode
+	ConversionOverflowed ConversionEvent = 2
+	// ConversionUnderflowed means a tiny input lost precision, becoming a
+	// subnormal or zero
+	ConversionUnderflowed ConversionEvent = 3
+	// ConversionSpecialValue means the input was NaN or an infinity
+	ConversionSpecialValue ConversionEvent = 4
+)
+
+// String returns the name of the event
+func (e ConversionEvent) String() string {
+	switch e {
+	case ConversionExact:
+		return "Exact"
+	case ConversionRounded:
+		return "Rounded"
+	case ConversionOverflowed:
+		return "Overflowed"
+	case ConversionUnderflowed:
+		return "Underflowed"
+	case ConversionSpecialValue:
+		return "SpecialValue"
+	default:
+		return fmt.Sprintf("ConversionEvent(%d)", int(e))
+	}
+}
+
+// FromFloat64Counted converts f64 to Float16 with the given rounding mode
+// and reports what the conversion did instead of returning an error. The
+// value is always the IEEE result with a single rounding, saturating at
+// MaxValue when the mode rounds toward zero, so mode only exists to match the
+// signature of FromFloat64WithMode; the event carries what ModeStrict would
+// have reported. Overflow and underflow follow IEEE 754: underflow needs a
+// tiny and inexact result, so exactly representable subnormals are Exact.
+func FromFloat64Counted(f64 float64, mode ConversionMode, rounding RoundingMode) (Float16, ConversionEvent) {
+	if math.IsNaN(f64) {
+		return nanFromFloat64(f64), ConversionSpecialValue
+	}
+	if math.IsInf(f64, 0) {
+		return FromFloat64(f64), ConversionSpecialValue
+	}
+
+	result := fromFloat64Rounded(f64, rounding)
+	abs := math.Abs(f64)
+	switch {
+	case result.IsInf(0) || abs >= 65536:
+		// 65536 is the first value whose rounding with an unbounded exponent
+		// exceeds MaxValue in every mode
+		return result, ConversionOverflowed
+	case result.ToFloat64() == f64:
+		return result, ConversionExact
+	case abs < SmallestNormal.ToFloat64():
+		return result, ConversionUnderflowed
+	default:
+		return result, ConversionRounded
+	}
+}
+
+// Bracket returns the two Float16 values nearest f64: lo is the largest
+// Float16 <= f64 and hi the smallest Float16 >= f64, so lo == hi exactly when
+// f64 is representable. They are the results of rounding toward -Inf and
+// +Inf, the candidates for directed and stochastic rounding. Past the finite
+// range one side is an infinity: f64 > MaxValue gives (MaxValue, +Inf) and
+// f64 < -MaxValue gives (-Inf, -MaxValue). Zeros and infinities bracket
+// themselves, and a positive tiny f64 gives (+0, SmallestSubnormal) while a
+// negative one gives (-SmallestSubnormal, -0). A NaN gives NaN for both,
+// following the package NaN policy.
+func Bracket(f64 float64) (lo, hi Float16) {
+	switch {
+	case math.IsNaN(f64):
+		nan := nanFromFloat64(f64)
+		return nan, nan
+	case math.IsInf(f64, 0):
+		inf := FromFloat64(f64)
+		return inf, inf
+	}
+	return fromFloat64Rounded(f64, RoundTowardNegative), fromFloat64Rounded(f64, RoundTowardPositive)
+}
+
+// fromFloat64Rounded converts a finite f64 to Float16 with a single rounding
+// in the given mode, including saturation at MaxValue and the smallest
+// subnormal under the directed modes
+func fromFloat64Rounded(f64 float64, mode RoundingMode) Float16 {
+	if f64 == 0 {
+		return FromFloat64(f64) // signed zero
+	}
+	var sign Float16
+	if f64 < 0 {
+		sign = SignMask
+	}
+	abs := math.Abs(f64)
+
+	down, lo, hi := bracketMagnitude(abs)
+	if lo == abs {
+		return down | sign
+	}
+	up := down + 1
+
+	// Both differences are exact by Sterbenz's lemma unless down is zero,
+	// where only their comparison matters
+	below, above := abs-lo, hi-abs
+	var roundUp bool
+	switch mode {
+	case RoundTowardZero:
+	case RoundTowardPositive:
+		roundUp = sign == 0
+	case RoundTowardNegative:
+		roundUp = sign != 0
+	case RoundNearestAway:
+		roundUp = above <= below
+	default:
+		roundUp = above < below || (above == below && down&1 == 1)
+	}
+	if roundUp {
+		return up | sign
+	}
+	return down | sign
+}
+
+// bracketMagnitude returns the largest Float16 magnitude down whose value lo
+// does not exceed the finite positive abs, along with the value hi of the
+// next magnitude down+1. Past MaxValue that next magnitude is infinity and hi
+// is 65536, where the next binade would start, so that midpoints stay
+// meaningful.
+func bracketMagnitude(abs float64) (down Float16, lo, hi float64) {
+	// Magnitude bit patterns are ordered, so an estimate needs at most a
+	// step or two
+	down = FromFloat32(float32(abs)).Abs()
+	if down.IsInf(0) {
+		down = MaxValue
+	}
+	for down.ToFloat64() > abs {
+		down--
+	}
+	for down < MaxValue && (down+1).ToFloat64() <= abs {
+		down++
+	}
+	lo, hi = down.ToFloat64(), (down + 1).ToFloat64()
+	if down == MaxValue {
+		hi = 65536
+	}
+	return down, lo, hi
+}
+
 // ToFloat64 converts a Float16 value to a float64 value.
 // It handles special cases like NaN, infinities, and zeros.
 func (f Float16) ToFloat64() float64 {
+	if f.IsNaN() {
+		// Built from bits, since a float32 to float64 conversion may quiet
+		// a signaling NaN
+		return math.Float64frombits(uint64(f&SignMask)<<48 | 0x7ff0000000000000 | uint64(f&MantissaMask)<<42)
+	}
 	return float64(f.ToFloat32()) // Simplified: convert via float32
 }
 
+// NaN conversion policy
+//
+// Conversions between Float16, float32 and float64 keep the sign and payload
+// of a NaN, working on the bits so that the hardware never quiets a signaling
+// NaN. Narrowing keeps the most significant payload bits. If those are all
+// zero the result would be an infinity, so the default NaN payload (see
+// Config.DefaultNaN) is used instead, with the sign kept.
+
+// nanFromPayload returns the Float16 NaN with the given sign bit and 10-bit
+// payload, falling back to the default NaN when the payload is zero
+func nanFromPayload(sign, payload uint16) Float16 {
+	if payload == 0 {
+		return Float16(sign<<15) | defaultNaN()
+	}
+	return Float16(sign<<15 | 0x7c00 | payload)
+}
+
+// nanFromFloat64 narrows a float64 NaN to Float16 by its bits
+func nanFromFloat64(f64 float64) Float16 {
+	bits := math.Float64bits(f64)
+	return nanFromPayload(uint16(bits>>63), uint16(bits>>42)&MantissaMask)
+}
+
 // shouldRound determines whether to round up during conversion
 // This is a helper function used in conversion algorithms
 func shouldRound(mantissa uint32, shift int, sign uint16) bool {
@@ -227,9 +492,9 @@ func shouldRound(mantissa uint32, shift int, sign uint16) bool {
 		// Round up if guard=1 and (sticky!=0 or LSB is 1) => ties to even
 		return guard == 1 && (sticky != 0 || lsb == 1)
 	case RoundNearestAway:
-		// Round up on half or more (guard=1). If less than half (guard=0), do not round.
-		// sticky doesn't affect decision except that if sticky>0, it's strictly more than half.
-		return guard == 1 || sticky != 0
+		// Round up on half or more (guard=1); the sticky bits only decide
+		// between ties and values above half, which round up alike
+		return guard == 1
 	case RoundTowardZero:
 		return false
 	case RoundTowardPositive:
@@ -252,11 +517,40 @@ func boolToUint(b bool) uint32 {
 	return 0
 }
 
-// Parse converts a string to a Float16 value
-// This is a simplified implementation for testing
+// Parse converts a decimal string such as "1.5", "-0" or "6.1e-5" to the
+// nearest Float16, rounding once with ties to even. "NaN", "Inf", "+Inf" and
+// "-Inf" are also accepted. Magnitudes beyond the Float16 range become
+// infinities or signed zeros rather than errors; malformed input returns an
+// ErrInvalidOperation error.
 func Parse(s string) (Float16, error) {
-	// Minimal parser: return error for standard numeric strings (not implemented)
-	return 0, &Float16Error{Op: "Parse", Msg: "parsing not implemented for numeric strings", Code: ErrInvalidOperation}
+	switch s {
+	case "NaN":
+		return defaultNaN(), nil
+	case "Inf", "+Inf":
+		return PositiveInfinity, nil
+	case "-Inf":
+		return NegativeInfinity, nil
+	}
+
+	body, sign := strings.TrimPrefix(s, "+"), Float16(0)
+	if strings.HasPrefix(s, "-") {
+		body, sign = s[1:], SignMask
+	}
+	f64, err := strconv.ParseFloat(body, 64)
+	if !isDecimal(body) || (err != nil && !errors.Is(err, strconv.ErrRange)) {
+		return 0, &Float16Error{
+			Op:   "Parse",
+			Msg:  fmt.Sprintf("invalid syntax %q", s),
+			Code: ErrInvalidOperation,
+		}
+	}
+	switch {
+	case f64 == 0:
+		return sign, nil // below every subnormal
+	case f64 > 65520:
+		return PositiveInfinity | sign, nil
+	}
+	return parseDecimalExact(body) | sign, nil
 }
 
 // FromInt converts an integer to Float16
@@ -264,15 +558,46 @@ func FromInt(i int) Float16 {
 	return FromFloat32(float32(i))
 }
 
+// ToSlice16Mode converts a slice of float32 to Float16, choosing between the
+// fast and the accurate conversion. With fast set it is ToSlice16: a
+// branch-light loop over the float32 bits that rounds to nearest even, about
+// as fast as scalar conversion gets. Without fast every element is bracketed
+// between its two neighboring Float16 values in float64 and rounded from
+// there, an independent derivation that is several times slower. Both paths
+// are correctly rounded and agree on every input; the accurate one is meant
+// for validating the fast one and for code that must not depend on its bit
+// manipulation.
+func ToSlice16Mode(s []float32, fast bool) []Float16 {
+	if fast {
+		return ToSlice16(s)
+	}
+	result := make([]Float16, len(s))
+	for i, v := range s {
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			result[i] = FromFloat32(v)
+		} else {
+			result[i] = fromFloat64Rounded(float64(v), RoundNearestEven)
+		}
+	}
+	if h := metricsHook(); h != nil {
+		reportSlice32(h, s, result)
+	}
+	return result
+}
+
 // ToSlice16WithMode converts a slice of float32 to Float16 with specified modes
 func ToSlice16WithMode(s []float32, convMode ConversionMode, roundMode RoundingMode) ([]Float16, []error) {
 	result := make([]Float16, len(s))
 	errs := make([]error, len(s))
+	onInexact := inexactHook()
 
 	for i, v := range s {
 		// Convert
 		result[i] = FromFloat32(v)
 		errs[i] = nil
+		if onInexact != nil && isInexact(float64(v), result[i]) {
+			onInexact(float64(v))
+		}
 
 		if convMode == ModeStrict {
 			// Overflow if magnitude exceeds max finite Float16
@@ -287,9 +612,28 @@ func ToSlice16WithMode(s []float32, convMode ConversionMode, roundMode RoundingM
 			}
 		}
 	}
+	if h := metricsHook(); h != nil {
+		reportSlice32(h, s, result)
+	}
 	return result, errs
 }
 
+// ToSlice16Joined is ToSlice16WithMode with the per-element failures combined
+// by errors.Join into a single error, nil when every element converted. Each
+// joined error is wrapped with the index of its element, so errors.As still
+// finds the *Float16Error of the first failure and the Unwrap() []error
+// method of the joined error yields them all.
+func ToSlice16Joined(f32s []float32, mode ConversionMode, rounding RoundingMode) ([]Float16, error) {
+	result, errs := ToSlice16WithMode(f32s, mode, rounding)
+	var failed []error
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, fmt.Errorf("element %d: %w", i, err))
+		}
+	}
+	return result, errors.Join(failed...)
+}
+
 // ToSlice32 converts a slice of Float16 to a slice of float32
 func ToSlice32(s []Float16) []float32 {
 	result := make([]float32, len(s))
@@ -299,6 +643,51 @@ func ToSlice32(s []Float16) []float32 {
 	return result
 }
 
+// SubnormalMode selects how up-conversion treats subnormal Float16 inputs
+type SubnormalMode int
+
+// The numeric values are part of the wire format and never change.
+const (
+	// SubnormalExact converts subnormals to their exact float32 value
+	SubnormalExact SubnormalMode = 0
+	// SubnormalFlushToZero converts subnormals to a zero of the same sign,
+	// as accelerators that flush half-precision subnormals on load do
+	SubnormalFlushToZero SubnormalMode = 1
+)
+
+// String returns the name of the mode
+func (m SubnormalMode) String() string {
+	switch m {
+	case SubnormalExact:
+		return "Exact"
+	case SubnormalFlushToZero:
+		return "FlushToZero"
+	default:
+		return fmt.Sprintf("SubnormalMode(%d)", int(m))
+	}
+}
+
+// ToFloat32WithMode converts f to float32, treating a subnormal f as mode
+// says. It only affects loads; arithmetic and down-conversion keep producing
+// subnormals. With SubnormalExact, or any unknown mode, it is ToFloat32.
+func ToFloat32WithMode(f Float16, mode SubnormalMode) float32 {
+	if mode == SubnormalFlushToZero && f&ExponentMask == 0 {
+		f &= SignMask
+	}
+	return f.ToFloat32()
+}
+
+// ToSlice32FTZ converts a slice of Float16 to float32 like ToSlice32, flushing
+// subnormal elements to zeros of the same sign, for comparing against
+// hardware that does so on load
+func ToSlice32FTZ(s []Float16) []float32 {
+	result := make([]float32, len(s))
+	for i, v := range s {
+		result[i] = ToFloat32WithMode(v, SubnormalFlushToZero)
+	}
+	return result
+}
+
 // ToSlice64 converts a slice of Float16 to a slice of float64
 func ToSlice64(s []Float16) []float64 {
 	result := make([]float64, len(s))
@@ -314,6 +703,9 @@ func FromSlice64(s []float64) []Float16 {
 	for i, v := range s {
 		result[i] = FromFloat64(v)
 	}
+	if h := metricsHook(); h != nil {
+		reportSlice64(h, s, result)
+	}
 	return result
 }
 
@@ -327,6 +719,87 @@ func FromInt64(i int64) Float16 {
 	return FromFloat64(float64(i))
 }
 
+// FromInt64Round converts i to Float16 using the given rounding mode when i
+// is not exactly representable. Integers up to 2048 in magnitude are always
+// exact; above that the spacing is 2, 4, ... 32, so for example 2049 becomes
+// 2048 or 2050 depending on mode. Magnitudes beyond MaxValue overflow to
+// infinity or saturate at MaxValue as IEEE 754 prescribes for the mode.
+func FromInt64Round(i int64, mode RoundingMode) Float16 {
+	var sign Float16
+	mag := uint64(i)
+	if i < 0 {
+		sign, mag = SignMask, -mag
+	}
+	if mag <= 1<<11 {
+		return FromInt64(i) // exact
+	}
+
+	overflow := bits.Len64(mag) > 16
+	var q uint64
+	shift := 0
+	if !overflow {
+		shift = bits.Len64(mag) - 11
+		q = mag >> shift
+		rem, half := mag&(1<<shift-1), uint64(1)<<(shift-1)
+		var up bool
+		switch mode {
+		case RoundNearestEven:
+			up = rem > half || (rem == half && q&1 == 1)
+		case RoundNearestAway:
+			up = rem >= half
+		case RoundTowardPositive:
+			up = rem != 0 && sign == 0
+		case RoundTowardNegative:
+			up = rem != 0 && sign != 0
+		}
+		if up {
+			q++
+			if q == 1<<11 {
+				q, shift = q>>1, shift+1
+			}
+		}
+		// q·2^shift with q in [2^10, 2^11) has exponent shift+10
+		overflow = shift+10 > 15
+	}
+	if overflow {
+		toInf := mode == RoundNearestEven || mode == RoundNearestAway ||
+			(mode == RoundTowardPositive && sign == 0) || (mode == RoundTowardNegative && sign != 0)
+		if toInf {
+			return PositiveInfinity | sign
+		}
+		return MaxValue | sign
+	}
+	return sign | Float16(shift+10+ExponentBias)<<MantissaLen | Float16(q)&MantissaMask
+}
+
+// FromRatio returns the correctly rounded Float16 value of num/den, rounding
+// to nearest with ties to even. The quotient is evaluated exactly with big.Rat,
+// so the result carries a single rounding. It returns an error if den is zero.
+func FromRatio(num, den int64) (Float16, error) {
+	if den == 0 {
+		return 0, &Float16Error{Op: "FromRatio", Msg: "zero denominator", Code: ErrDivisionByZero}
+	}
+	r := new(big.Rat).SetFrac(big.NewInt(num), big.NewInt(den))
+	return fromRatRoundToOdd(r), nil
+}
+
+// fromRatRoundToOdd narrows r to Float16 via float32 using round-to-odd for
+// the intermediate step. float32 carries at least 13 more significand bits
+// than Float16, so the second rounding cannot create a false tie.
+func fromRatRoundToOdd(r *big.Rat) Float16 {
+	f32, exact := r.Float32()
+	if !exact && !math.IsInf(float64(f32), 0) {
+		bits := math.Float32bits(f32)
+		// Step back toward zero if the nearest float32 overshot |r|
+		back := new(big.Rat)
+		if back.SetFloat64(float64(f32)); back.Abs(back).Cmp(new(big.Rat).Abs(r)) > 0 {
+			bits--
+		}
+		f32 = math.Float32frombits(bits | 1)
+	}
+	return FromFloat32(f32)
+}
+
 // ParseFloat converts a string to a Float16 value.
 // The precision parameter is ignored for Float16.
 // It returns the Float16 value and an error if the string cannot be parsed.
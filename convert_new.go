@@ -3,6 +3,13 @@ package float16
 import "math"
 
 func fromFloat32New(f32 float32) Float16 {
+	result, _ := fromFloat32NewChecked(f32)
+	return result
+}
+
+// fromFloat32NewChecked is fromFloat32New with the Config.DebugChecks error
+// from packing the result
+func fromFloat32NewChecked(f32 float32) (Float16, error) {
 	bits := math.Float32bits(f32)
 	sign := uint16(bits >> 31)
 	exp := int32((bits >> 23) & 0xff)
@@ -11,14 +18,14 @@ func fromFloat32New(f32 float32) Float16 {
 	// Handle special cases (infinity and NaN)
 	if exp == 0xff {
 		if mant == 0 {
-			return Float16(sign<<15 | 0x7c00) // infinity
+			return Float16(sign<<15 | 0x7c00), nil // infinity
 		}
-		return Float16(sign<<15 | 0x7e00) // qNaN
+		return nanFromPayload(sign, uint16(mant>>13)), nil
 	}
 
 	// Handle zero
 	if exp == 0 && mant == 0 {
-		return Float16(sign << 15)
+		return Float16(sign << 15), nil
 	}
 
 	// Adjust exponent from float32 bias (127) to float16 bias (15)
@@ -26,21 +33,28 @@ func fromFloat32New(f32 float32) Float16 {
 
 	// Handle overflow (exponent too large)
 	if exp >= 0x1f {
-		return Float16(sign<<15 | 0x7c00) // infinity
+		return Float16(sign<<15 | 0x7c00), nil // infinity
 	}
 
 	// Handle underflow and subnormal numbers
 	if exp <= 0 {
 		if exp < -10 {
-			return Float16(sign << 15) // zero
+			return Float16(sign << 15), nil // zero
+		}
+		// Convert to subnormal, folding the bits shifted out into a sticky bit
+		// so that values just above a midpoint still round up
+		full := mant | 1<<23
+		mant = full >> uint(1-exp)
+		if full&(1<<uint(1-exp)-1) != 0 {
+			mant |= 1
 		}
-		// Convert to subnormal
-		mant = (mant | 1<<23) >> uint(1-exp)
 		// Round to nearest even
 		if mant&0x1fff > 0x1000 || (mant&0x1fff == 0x1000 && mant&0x2000 != 0) {
 			mant += 0x2000
 		}
-		return Float16(uint16(sign<<15) | uint16(mant>>13))
+		// A carry out of the mantissa produces the smallest normal
+		mant >>= 13
+		return composeResult(sign, uint16(mant>>MantissaLen), uint16(mant&MantissaMask))
 	}
 
 	// Handle normal numbers
@@ -70,11 +84,11 @@ func fromFloat32New(f32 float32) Float16 {
 
 	// Check for exponent overflow after rounding
 	if exp >= 0x1f {
-		return Float16(sign<<15 | 0x7c00) // infinity
+		return Float16(sign<<15 | 0x7c00), nil // infinity
 	}
 
 	// Extract the 10-bit mantissa (bits 22-13 of the original 23-bit mantissa)
 	mantissa10 := (mant >> 13) & 0x3FF
 
-	return Float16(uint16(sign<<15) | uint16(exp<<10) | uint16(mantissa10))
+	return composeResult(sign, uint16(exp), uint16(mantissa10))
 }
@@ -1,7 +1,12 @@
 package float16
 
 import (
+	"errors"
+	"fmt"
 	"math"
+	"math/big"
+	"math/rand"
+	"slices"
 	"testing"
 )
 
@@ -134,8 +139,499 @@ func TestShouldRound(t *testing.T) {
 }
 
 func TestParse(t *testing.T) {
-	_, err := Parse("1.0")
-	if err == nil {
-		t.Error("Expected error, got nil")
+	tests := []struct {
+		in   string
+		want Float16
+	}{
+		{"1.0", One16},
+		{"+1.5", 0x3e00},
+		{"-2", 0xc000},
+		{"0", PositiveZero},
+		{"-0", NegativeZero},
+		{"0.1", 0x2e66},
+		{"65504", MaxValue},
+		{"65519.99", MaxValue},
+		{"65519.999999999999", MaxValue}, // rounds to 65520 in float64
+		{"65520.000000000001", PositiveInfinity},
+		{"65520", PositiveInfinity},
+		{"-1e400", NegativeInfinity},
+		{"1e-400", PositiveZero},
+		{"-1e-10", NegativeZero},
+		{"5.960464477539063e-08", SmallestSubnormal},
+		{"2.98023224e-08", SmallestSubnormal},    // just above the midpoint
+		{"2.9802322387695312e-08", PositiveZero}, // the midpoint, ties to even
+		{".5e1", 0x4500},
+		{"Inf", PositiveInfinity},
+		{"-Inf", NegativeInfinity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if err != nil || got != tt.want {
+				t.Errorf("Parse(%q) = (%v, %v), want %v", tt.in, got, err, tt.want)
+			}
+		})
+	}
+
+	if got, err := Parse("NaN"); err != nil || !got.IsNaN() {
+		t.Errorf("Parse(NaN) = (%v, %v)", got, err)
+	}
+	for _, in := range []string{"", "-", "+-1", "abc", "1.0x", "0x1p3", "1_000", "inf", "nan", "--1", " 1"} {
+		var fe *Float16Error
+		if _, err := Parse(in); !errors.As(err, &fe) || fe.Code != ErrInvalidOperation {
+			t.Errorf("Parse(%q): err = %v, want ErrInvalidOperation", in, err)
+		}
+	}
+}
+
+func TestFromRatio(t *testing.T) {
+	tests := []struct {
+		name     string
+		num, den int64
+		want     Float16
+	}{
+		{"1/3", 1, 3, FromFloat64(1.0 / 3.0)},
+		{"-1/3", -1, 3, FromFloat64(-1.0 / 3.0)},
+		{"22/7 is one ulp above Pi", 22, 7, NextAfter(Pi, PositiveInfinity)},
+		{"355/113 rounds to Pi", 355, 113, Pi},
+		{"zero", 0, -5, PositiveZero},
+		{"exact tie rounds to even", 2049, 2048, One()},
+		// 1 + 2^-11 + 2^-40 rounds to 1 + 2^-11 in float32, a false tie for Float16
+		{"tie plus epsilon rounds up", 2049<<29 + 1, 1 << 40, NextAfter(One(), PositiveInfinity)},
+		{"overflow", 1 << 20, 1, PositiveInfinity},
+		{"smallest subnormal", 1, 1 << 24, SmallestSubnormal},
+		{"below half subnormal", 1, 1<<25 + 1, PositiveZero},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromRatio(tt.num, tt.den)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FromRatio(%d, %d) = %v (%#04x), want %v (%#04x)", tt.num, tt.den, got, got.Bits(), tt.want, tt.want.Bits())
+			}
+		})
+	}
+
+	_, err := FromRatio(1, 0)
+	var fe *Float16Error
+	if !errors.As(err, &fe) || fe.Code != ErrDivisionByZero {
+		t.Errorf("FromRatio(1, 0) err = %v, want ErrDivisionByZero", err)
+	}
+}
+
+func TestFromRatioCorrectlyRounded(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	dist := func(r *big.Rat, f Float16) *big.Rat {
+		d := new(big.Rat).SetFloat64(f.ToFloat64())
+		return d.Abs(d.Sub(d, r))
+	}
+	for i := 0; i < 5000; i++ {
+		num := rng.Int63n(1<<40) - 1<<39
+		den := rng.Int63n(1<<30) + 1
+		got, _ := FromRatio(num, den)
+		if got.IsInf(0) {
+			continue
+		}
+		r := new(big.Rat).SetFrac64(num, den)
+		d := dist(r, got)
+		for _, n := range []Float16{NextAfter(got, PositiveInfinity), NextAfter(got, NegativeInfinity)} {
+			if n.IsInf(0) {
+				continue
+			}
+			c := dist(r, n).Cmp(d)
+			if c < 0 || (c == 0 && n.Bits()&1 == 0) {
+				t.Fatalf("FromRatio(%d, %d) = %v, but %v is nearer", num, den, got, n)
+			}
+		}
+	}
+}
+
+func TestNaNConversionPreservesSignAndPayload(t *testing.T) {
+	for i := 0; i < 1<<16; i++ {
+		f := Float16(i)
+		if !f.IsNaN() {
+			continue
+		}
+		if got := FromFloat32(f.ToFloat32()); got != f {
+			t.Fatalf("float32 round trip of %#04x = %#04x", i, got.Bits())
+		}
+		if got := FromFloat64(f.ToFloat64()); got != f {
+			t.Fatalf("float64 round trip of %#04x = %#04x", i, got.Bits())
+		}
+		if got, _ := FromFloat64WithMode(f.ToFloat64(), ModeIEEE, RoundNearestEven); got != f {
+			t.Fatalf("FromFloat64WithMode round trip of %#04x = %#04x", i, got.Bits())
+		}
+		if got := FromFloat32WithRounding(f.ToFloat32(), RoundTowardZero); got != f {
+			t.Fatalf("FromFloat32WithRounding round trip of %#04x = %#04x", i, got.Bits())
+		}
+		if bits32 := math.Float32bits(f.ToFloat32()); bits32>>31 != uint32(i>>15) || bits32>>13&0x3ff != uint32(i&0x3ff) {
+			t.Fatalf("ToFloat32(%#04x) bits %#08x lost sign or payload", i, bits32)
+		}
+		if s := f.String(); s != "NaN" {
+			t.Fatalf("String(%#04x) = %q, want unsigned NaN", i, s)
+		}
+	}
+
+	// Payload bits below Float16 precision are dropped; an all-zero result
+	// falls back to the default NaN so it does not become an infinity
+	if got := FromFloat32(math.Float32frombits(0xff800001)); got != QuietNaN|SignMask {
+		t.Errorf("low-payload negative NaN = %#04x, want %#04x", got.Bits(), (QuietNaN | SignMask).Bits())
+	}
+	if got := FromFloat64(math.Float64frombits(0x7ff0000000000123 | 0x5<<42)); got != FromBits(0x7c05) {
+		t.Errorf("float64 payload narrowing = %#04x, want 0x7c05", got.Bits())
+	}
+}
+
+func TestFromInt64Round(t *testing.T) {
+	modes := []RoundingMode{RoundNearestEven, RoundNearestAway, RoundTowardZero, RoundTowardPositive, RoundTowardNegative}
+	tests := []struct {
+		in   int64
+		want [5]float64 // in the order of modes
+	}{
+		{2048, [5]float64{2048, 2048, 2048, 2048, 2048}},
+		{2049, [5]float64{2048, 2050, 2048, 2050, 2048}},
+		{2051, [5]float64{2052, 2052, 2050, 2052, 2050}},
+		{-2049, [5]float64{-2048, -2050, -2048, -2048, -2050}},
+		// Spacing 4 above 4096: 4099 is above the midpoint 4098
+		{4099, [5]float64{4100, 4100, 4096, 4100, 4096}},
+		{4098, [5]float64{4096, 4100, 4096, 4100, 4096}},
+		{4102, [5]float64{4104, 4104, 4100, 4104, 4100}},
+		// Carry into the next binade
+		{4095, [5]float64{4096, 4096, 4094, 4096, 4094}},
+		{65504, [5]float64{65504, 65504, 65504, 65504, 65504}},
+		{65519, [5]float64{65504, 65504, 65504, math.Inf(1), 65504}},
+		{65520, [5]float64{math.Inf(1), math.Inf(1), 65504, math.Inf(1), 65504}},
+		{-70000, [5]float64{math.Inf(-1), math.Inf(-1), -65504, -65504, math.Inf(-1)}},
+		{math.MaxInt64, [5]float64{math.Inf(1), math.Inf(1), 65504, math.Inf(1), 65504}},
+		{math.MinInt64, [5]float64{math.Inf(-1), math.Inf(-1), -65504, -65504, math.Inf(-1)}},
+		{-7, [5]float64{-7, -7, -7, -7, -7}},
+		{0, [5]float64{0, 0, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		for m, mode := range modes {
+			got := FromInt64Round(tt.in, mode)
+			if got.ToFloat64() != tt.want[m] {
+				t.Errorf("FromInt64Round(%d, %v) = %v, want %v", tt.in, mode, got, tt.want[m])
+			}
+		}
+	}
+
+	// Exhaustive agreement with the float32 path in the representable range
+	for i := int64(-65504); i <= 65504; i++ {
+		if got, want := FromInt64Round(i, RoundNearestEven), FromInt64(i); got != want {
+			t.Fatalf("FromInt64Round(%d) = %v, FromInt64 gives %v", i, got, want)
+		}
+		for _, mode := range []RoundingMode{RoundTowardZero, RoundTowardPositive, RoundTowardNegative} {
+			got := FromInt64Round(i, mode).ToFloat64()
+			if (mode == RoundTowardZero && math.Abs(got) > math.Abs(float64(i))) ||
+				(mode == RoundTowardPositive && got < float64(i)) ||
+				(mode == RoundTowardNegative && got > float64(i)) {
+				t.Fatalf("FromInt64Round(%d, %v) = %v rounds the wrong way", i, mode, got)
+			}
+		}
+	}
+}
+
+func TestFromFloat64Counted(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       float64
+		rounding RoundingMode
+		want     Float16
+		event    ConversionEvent
+	}{
+		{"exact half", 0.5, RoundNearestEven, 0x3800, ConversionExact},
+		{"exact zero", 0, RoundNearestEven, PositiveZero, ConversionExact},
+		{"exact negative zero", math.Copysign(0, -1), RoundNearestEven, NegativeZero, ConversionExact},
+		{"exact subnormal", math.Ldexp(1, -24), RoundNearestEven, SmallestSubnormal, ConversionExact},
+		{"exact max", 65504, RoundNearestEven, MaxValue, ConversionExact},
+		{"rounded tenth", 0.1, RoundNearestEven, 0x2e66, ConversionRounded},
+		{"rounded near max", 65519, RoundTowardZero, MaxValue, ConversionRounded},
+		{"overflow", 1e10, RoundNearestEven, PositiveInfinity, ConversionOverflowed},
+		{"overflow just past max", 65520, RoundNearestEven, PositiveInfinity, ConversionOverflowed},
+		{"overflow saturates toward zero", 1e10, RoundTowardZero, MaxValue, ConversionOverflowed},
+		{"overflow saturates toward negative", 1e10, RoundTowardNegative, MaxValue, ConversionOverflowed},
+		{"negative overflow", -1e10, RoundTowardPositive, MinValue, ConversionOverflowed},
+		{"underflow to zero", 1e-10, RoundNearestEven, PositiveZero, ConversionUnderflowed},
+		{"underflow upward", 1e-10, RoundTowardPositive, SmallestSubnormal, ConversionUnderflowed},
+		{"underflow to subnormal", 1e-7, RoundNearestEven, 0x0002, ConversionUnderflowed},
+		{"infinity", math.Inf(-1), RoundNearestEven, NegativeInfinity, ConversionSpecialValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, mode := range []ConversionMode{ModeIEEE, ModeStrict} {
+				got, event := FromFloat64Counted(tt.in, mode, tt.rounding)
+				if got != tt.want || event != tt.event {
+					t.Errorf("FromFloat64Counted(%g, %v, %v) = (%v, %v), want (%v, %v)",
+						tt.in, mode, tt.rounding, got, event, tt.want, tt.event)
+				}
+			}
+		})
+	}
+
+	t.Run("NaN", func(t *testing.T) {
+		got, event := FromFloat64Counted(math.NaN(), ModeIEEE, RoundNearestEven)
+		if !got.IsNaN() || event != ConversionSpecialValue {
+			t.Errorf("FromFloat64Counted(NaN) = (%v, %v), want (NaN, SpecialValue)", got, event)
+		}
+	})
+
+	t.Run("single rounding", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 20000; i++ {
+			x := math.Ldexp(r.Float64()-0.5, r.Intn(60)-30)
+			got, event := FromFloat64Counted(x, ModeIEEE, RoundNearestEven)
+			if want := FromFloat32(roundToOdd64(x, 0)); got != want {
+				t.Fatalf("FromFloat64Counted(%g) = %v, want %v", x, got, want)
+			}
+			if (event == ConversionExact) != (got.ToFloat64() == x) {
+				t.Fatalf("FromFloat64Counted(%g) = %v reported %v", x, got, event)
+			}
+			down, _ := FromFloat64Counted(x, ModeIEEE, RoundTowardNegative)
+			up, _ := FromFloat64Counted(x, ModeIEEE, RoundTowardPositive)
+			if down.ToFloat64() > x || up.ToFloat64() < x || (got != down && got != up) {
+				t.Fatalf("FromFloat64Counted(%g): bracket [%v, %v] does not hold %v", x, down, up, got)
+			}
+		}
+	})
+
+	t.Run("ties", func(t *testing.T) {
+		// 2049 lies halfway between 2048 and 2050
+		tests := []struct {
+			rounding RoundingMode
+			want     float64
+		}{
+			{RoundNearestEven, 2048},
+			{RoundNearestAway, 2050},
+			{RoundTowardZero, 2048},
+			{RoundTowardPositive, 2050},
+			{RoundTowardNegative, 2048},
+		}
+		for _, tt := range tests {
+			got, event := FromFloat64Counted(2049, ModeIEEE, tt.rounding)
+			if got.ToFloat64() != tt.want || event != ConversionRounded {
+				t.Errorf("FromFloat64Counted(2049, %v) = (%v, %v), want (%v, Rounded)", tt.rounding, got, event, tt.want)
+			}
+		}
+	})
+}
+
+func TestConversionEventString(t *testing.T) {
+	tests := map[ConversionEvent]string{
+		ConversionExact:        "Exact",
+		ConversionRounded:      "Rounded",
+		ConversionOverflowed:   "Overflowed",
+		ConversionUnderflowed:  "Underflowed",
+		ConversionSpecialValue: "SpecialValue",
+		ConversionEvent(7):     "ConversionEvent(7)",
+	}
+	for e, want := range tests {
+		if got := e.String(); got != want {
+			t.Errorf("ConversionEvent(%d).String() = %q, want %q", int(e), got, want)
+		}
+	}
+}
+
+func TestToSlice16Mode(t *testing.T) {
+	src := append(MixedData32(4096, DefaultDataMix(), 1),
+		0, float32(math.Copysign(0, -1)), 1, -1, 0.1, 65504, 65519, 65520, 1e10, -1e10,
+		float32(math.Inf(1)), float32(math.Inf(-1)), float32(math.NaN()),
+		float32(math.Ldexp(1, -24)), float32(math.Ldexp(3, -26)), 1e-10)
+	fast, accurate := ToSlice16Mode(src, true), ToSlice16Mode(src, false)
+	for i, v := range src {
+		if fast[i] != accurate[i] && !(fast[i].IsNaN() && accurate[i].IsNaN()) {
+			t.Errorf("ToSlice16Mode(%g): fast %v, accurate %v", v, fast[i], accurate[i])
+		}
+		if v != v || math.IsInf(float64(v), 0) {
+			continue
+		}
+		// Correctly rounded: no Float16 is closer to v
+		want, got := float64(v), accurate[i]
+		if got.IsInf(0) {
+			if math.Abs(want) < 65520 {
+				t.Errorf("ToSlice16Mode(%g) overflowed", v)
+			}
+			continue
+		}
+		d := math.Abs(got.ToFloat64() - want)
+		for _, n := range []Float16{NextAfter(got, PositiveInfinity), NextAfter(got, NegativeInfinity)} {
+			if n.IsFinite() && math.Abs(n.ToFloat64()-want) < d {
+				t.Errorf("ToSlice16Mode(%g) = %v, but %v is closer", v, got, n)
+			}
+		}
+	}
+
+	// Just above the midpoint between 0 and the smallest subnormal
+	v := math.Float32frombits(math.Float32bits(float32(math.Ldexp(1, -25))) + 1)
+	if got := ToSlice16Mode([]float32{v}, false)[0]; got != SmallestSubnormal {
+		t.Errorf("ToSlice16Mode(%g, false) = %v, want %v", v, got, SmallestSubnormal)
+	}
+	if len(ToSlice16Mode(nil, false)) != 0 {
+		t.Error("ToSlice16Mode(nil) is not empty")
+	}
+}
+
+func TestToFloat32WithModeFTZ(t *testing.T) {
+	in := make([]Float16, 1<<16)
+	for i := range in {
+		in[i] = Float16(i)
+	}
+	exact, ftz := ToSlice32(in), ToSlice32FTZ(in)
+	differ := 0
+	for i, f := range in {
+		if got := ToFloat32WithMode(f, SubnormalExact); math.Float32bits(got) != math.Float32bits(exact[i]) {
+			t.Fatalf("ToFloat32WithMode(%#04x, SubnormalExact) = %v, want %v", i, got, exact[i])
+		}
+		if got := ToFloat32WithMode(f, SubnormalFlushToZero); math.Float32bits(got) != math.Float32bits(ftz[i]) {
+			t.Fatalf("ToFloat32WithMode(%#04x, SubnormalFlushToZero) = %v, ToSlice32FTZ gives %v", i, got, ftz[i])
+		}
+		if math.Float32bits(ftz[i]) == math.Float32bits(exact[i]) {
+			continue
+		}
+		differ++
+		if !f.IsSubnormal() {
+			t.Errorf("%#04x is not subnormal but flushed to %v", i, ftz[i])
+		}
+		if ftz[i] != 0 || math.Signbit(float64(ftz[i])) != f.Signbit() {
+			t.Errorf("%#04x flushed to %v, want a zero of the same sign", i, ftz[i])
+		}
+	}
+	if differ != 2046 {
+		t.Errorf("%d patterns differ, want the 2046 subnormals", differ)
+	}
+	if s := SubnormalFlushToZero.String(); s != "FlushToZero" {
+		t.Errorf("SubnormalFlushToZero.String() = %q", s)
+	}
+}
+
+func TestBracket(t *testing.T) {
+	tests := []struct {
+		name   string
+		f64    float64
+		lo, hi Float16
+	}{
+		{"exact", 1.5, FromFloat32(1.5), FromFloat32(1.5)},
+		{"exact subnormal", 0x1p-24, SmallestSubnormal, SmallestSubnormal},
+		{"between", 1 + 0x1p-12, One16, One16 + 1},
+		{"negative between", -(1 + 0x1p-12), (One16 + 1).Neg(), One16.Neg()},
+		{"positive zero", 0, PositiveZero, PositiveZero},
+		{"negative zero", math.Copysign(0, -1), NegativeZero, NegativeZero},
+		{"positive tiny", 0x1p-30, PositiveZero, SmallestSubnormal},
+		{"negative tiny", -0x1p-30, SmallestSubnormal.Neg(), NegativeZero},
+		{"above MaxValue", 65519, MaxValue, PositiveInfinity},
+		{"far above MaxValue", 1e300, MaxValue, PositiveInfinity},
+		{"below -MaxValue", -70000, NegativeInfinity, MinValue},
+		{"+Inf", math.Inf(1), PositiveInfinity, PositiveInfinity},
+		{"-Inf", math.Inf(-1), NegativeInfinity, NegativeInfinity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi := Bracket(tt.f64)
+			if lo != tt.lo || hi != tt.hi {
+				t.Errorf("Bracket(%g) = (%v, %v), want (%v, %v)", tt.f64, lo, hi, tt.lo, tt.hi)
+			}
+		})
+	}
+	if lo, hi := Bracket(math.NaN()); !lo.IsNaN() || !hi.IsNaN() {
+		t.Errorf("Bracket(NaN) = (%v, %v), want NaNs", lo, hi)
+	}
+
+	// Random values lie inside their bracket, which is one step wide
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		f64 := r.NormFloat64() * math.Pow(2, float64(r.Intn(40)-25))
+		lo, hi := Bracket(f64)
+		if lo.ToFloat64() > f64 || hi.ToFloat64() < f64 {
+			t.Fatalf("Bracket(%g) = (%v, %v) does not contain it", f64, lo, hi)
+		}
+		if lo != hi && NextAfter(lo, PositiveInfinity) != hi && !(lo.IsZero() && hi.IsZero()) {
+			t.Fatalf("Bracket(%g) = (%v, %v) is not adjacent", f64, lo, hi)
+		}
+	}
+}
+
+func TestToSlice16Joined(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    []float32
+		codes map[int]ErrorCode // failing index -> code
+	}{
+		{"none", []float32{1, 2.5, -3}, nil},
+		{"one", []float32{1, 1e6, 2}, map[int]ErrorCode{1: ErrOverflow}},
+		{"several", []float32{1e-10, 1, -1e9, 1e-7}, map[int]ErrorCode{0: ErrUnderflow, 2: ErrOverflow, 3: ErrUnderflow}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToSlice16Joined(tt.in, ModeStrict, RoundNearestEven)
+			want, _ := ToSlice16WithMode(tt.in, ModeStrict, RoundNearestEven)
+			if !slices.Equal(got, want) {
+				t.Errorf("values = %v, want %v", got, want)
+			}
+			if len(tt.codes) == 0 {
+				if err != nil {
+					t.Errorf("err = %v, want nil", err)
+				}
+				return
+			}
+			joined, ok := err.(interface{ Unwrap() []error })
+			if !ok {
+				t.Fatalf("err = %T %v, want a joined error", err, err)
+			}
+			parts := joined.Unwrap()
+			if len(parts) != len(tt.codes) {
+				t.Fatalf("%d joined errors, want %d: %v", len(parts), len(tt.codes), err)
+			}
+			for _, part := range parts {
+				var i int
+				if _, scanErr := fmt.Sscanf(part.Error(), "element %d:", &i); scanErr != nil {
+					t.Fatalf("%q does not start with its index", part)
+				}
+				var fe *Float16Error
+				if !errors.As(part, &fe) || fe.Code != tt.codes[i] {
+					t.Errorf("element %d: %v, want code %v", i, part, tt.codes[i])
+				}
+			}
+			var fe *Float16Error
+			if !errors.As(err, &fe) {
+				t.Errorf("errors.As on the joined error found no *Float16Error")
+			}
+		})
+	}
+	if got, err := ToSlice16Joined(nil, ModeIEEE, RoundNearestEven); len(got) != 0 || err != nil {
+		t.Errorf("ToSlice16Joined(nil) = (%v, %v)", got, err)
 	}
 }
+
+func TestNoInlineConversions(t *testing.T) {
+	for i := 0; i < 1<<16; i++ {
+		f := Float16(i)
+		got, want := ToFloat32NoInline(f), f.ToFloat32()
+		if math.Float32bits(got) != math.Float32bits(want) {
+			t.Fatalf("ToFloat32NoInline(%#04x) = %v, want %v", i, got, want)
+		}
+		// Every float32 between and around the Float16 values
+		v := math.Float32frombits(uint32(i) << 16)
+		if got, want := ToFloat16NoInline(v), FromFloat32(v); got != want {
+			t.Fatalf("ToFloat16NoInline(%v) = %#04x, want %#04x", v, got.Bits(), want.Bits())
+		}
+	}
+}
+
+func BenchmarkNoInlineConversion(b *testing.B) {
+	b.Run("ToFloat16", func(b *testing.B) {
+		var sink Float16
+		for i := 0; i < b.N; i++ {
+			sink ^= ToFloat16NoInline(float32(i&1023) * 0.37)
+		}
+		_ = sink
+	})
+	b.Run("ToFloat32", func(b *testing.B) {
+		var sink float32
+		for i := 0; i < b.N; i++ {
+			sink += ToFloat32NoInline(Float16(i))
+		}
+		_ = sink
+	})
+}
@@ -0,0 +1,47 @@
+//go:build amd64 && !purego
+
+package float16
+
+// cpuid executes CPUID with the given leaf and subleaf
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+// xgetbv reads the XCR0 register, which says which register states the
+// operating system saves
+func xgetbv() (eax, edx uint32)
+
+// detectCPUFeatures reports the x86 extensions relevant to half-precision
+// kernels. AVX-class features count only when the operating system saves
+// the wider registers.
+func detectCPUFeatures() []string {
+	maxLeaf, _, _, _ := cpuid(0, 0)
+	if maxLeaf < 1 {
+		return nil
+	}
+	_, _, ecx1, _ := cpuid(1, 0)
+	has := func(reg uint32, bit uint) bool { return reg&(1<<bit) != 0 }
+
+	osAVX, osAVX512 := false, false
+	if has(ecx1, 27) { // OSXSAVE
+		xcr0, _ := xgetbv()
+		osAVX = xcr0&0x6 == 0x6
+		osAVX512 = osAVX && xcr0&0xe0 == 0xe0
+	}
+
+	var features []string
+	add := func(name string, ok bool) {
+		if ok {
+			features = append(features, name)
+		}
+	}
+	add("sse4.1", has(ecx1, 19))
+	add("avx", osAVX && has(ecx1, 28))
+	add("fma", osAVX && has(ecx1, 12))
+	add("f16c", osAVX && has(ecx1, 29))
+	if maxLeaf >= 7 {
+		_, ebx7, _, edx7 := cpuid(7, 0)
+		add("avx2", osAVX && has(ebx7, 5))
+		add("avx512f", osAVX512 && has(ebx7, 16))
+		add("avx512fp16", osAVX512 && has(edx7, 23))
+	}
+	return features
+}
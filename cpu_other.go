@@ -0,0 +1,9 @@
+//go:build !amd64 || purego
+
+package float16
+
+// detectCPUFeatures reports no features where the package has no detection
+// code; DebugInfo still reports the architecture
+func detectCPUFeatures() []string {
+	return nil
+}
@@ -0,0 +1,95 @@
+package float16
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Delta encoding for Float16 streams
+//
+// EncodeDeltas writes the element count as a uvarint followed by one zigzag
+// varint per element holding the difference between consecutive OrderedKey
+// values (the first difference is taken from key 0). Sorted or slowly varying
+// sequences produce small differences that fit in one byte; any input,
+// including NaN payloads and signed zeros, round-trips bit-exactly. Each
+// element costs at most 3 bytes.
+
+// maxDeltaBytes is the largest varint needed for a key difference in
+// [-65535, 65535]
+const maxDeltaBytes = 3
+
+// EncodeDeltas returns the delta encoding of s.
+func EncodeDeltas(s []Float16) []byte {
+	buf := make([]byte, 0, DeltaEncodedSize(s))
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	prev := int64(0)
+	for _, v := range s {
+		k := int64(v.OrderedKey())
+		buf = binary.AppendVarint(buf, k-prev)
+		prev = k
+	}
+	return buf
+}
+
+// DecodeDeltas reconstructs the slice encoded by EncodeDeltas. It returns an
+// error if the data is truncated, has trailing bytes, or decodes to keys
+// outside the 16-bit range.
+func DecodeDeltas(data []byte) ([]Float16, error) {
+	n, read := binary.Uvarint(data)
+	if read <= 0 {
+		return nil, deltaError("invalid length prefix")
+	}
+	data = data[read:]
+	if n > uint64(len(data)) {
+		return nil, deltaError(fmt.Sprintf("length %d exceeds available data", n))
+	}
+
+	result := make([]Float16, n)
+	prev := int64(0)
+	for i := range result {
+		d, read := binary.Varint(data)
+		if read <= 0 {
+			return nil, deltaError(fmt.Sprintf("truncated delta at element %d", i))
+		}
+		data = data[read:]
+		k := prev + d
+		if k < 0 || k > 0xFFFF {
+			return nil, deltaError(fmt.Sprintf("key out of range at element %d", i))
+		}
+		result[i] = FromOrderedKey(uint16(k))
+		prev = k
+	}
+	if len(data) != 0 {
+		return nil, deltaError(fmt.Sprintf("%d trailing bytes", len(data)))
+	}
+	return result, nil
+}
+
+// DeltaEncodedSize returns the exact number of bytes EncodeDeltas would
+// produce for s, without allocating.
+func DeltaEncodedSize(s []Float16) int {
+	size := uvarintLen(uint64(len(s)))
+	prev := int64(0)
+	for _, v := range s {
+		k := int64(v.OrderedKey())
+		d := k - prev
+		size += uvarintLen(uint64(d<<1) ^ uint64(d>>63))
+		prev = k
+	}
+	return size
+}
+
+// uvarintLen returns the encoded length of x as a uvarint
+func uvarintLen(x uint64) int {
+	n := 1
+	for x >= 0x80 {
+		x >>= 7
+		n++
+	}
+	return n
+}
+
+// deltaError builds the error returned for malformed delta streams
+func deltaError(msg string) error {
+	return &Float16Error{Op: "DecodeDeltas", Msg: msg, Code: ErrInvalidOperation}
+}
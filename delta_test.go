@@ -0,0 +1,118 @@
+package float16
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func checkDeltaRoundTrip(t *testing.T, s []Float16) []byte {
+	t.Helper()
+	enc := EncodeDeltas(s)
+	if len(enc) != DeltaEncodedSize(s) {
+		t.Fatalf("DeltaEncodedSize = %d, encoded %d bytes", DeltaEncodedSize(s), len(enc))
+	}
+	if bound := uvarintLen(uint64(len(s))) + maxDeltaBytes*len(s); len(enc) > bound {
+		t.Fatalf("encoded %d bytes, exceeds worst-case bound %d", len(enc), bound)
+	}
+	dec, err := DecodeDeltas(enc)
+	if err != nil {
+		t.Fatalf("DecodeDeltas: %v", err)
+	}
+	if len(dec) != len(s) {
+		t.Fatalf("decoded %d values, want %d", len(dec), len(s))
+	}
+	for i := range s {
+		if dec[i] != s[i] {
+			t.Fatalf("element %d: got %#04x, want %#04x", i, dec[i].Bits(), s[i].Bits())
+		}
+	}
+	return enc
+}
+
+func TestDeltaRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	random := make([]Float16, 3000)
+	for i := range random {
+		random[i] = Float16(rng.Uint32())
+	}
+	checkDeltaRoundTrip(t, random)
+
+	sorted := slices.Clone(random)
+	slices.SortFunc(sorted, func(a, b Float16) int { return int(a.OrderedKey()) - int(b.OrderedKey()) })
+	checkDeltaRoundTrip(t, sorted)
+
+	specials := []Float16{NegativeZero, PositiveZero, QuietNaN, NegativeQNaN, SignalingNaN | 0x0013,
+		PositiveInfinity, NegativeInfinity, MaxValue, MinValue, SmallestSubnormal}
+	checkDeltaRoundTrip(t, specials)
+	checkDeltaRoundTrip(t, nil)
+
+	// Alternating extremes produce the largest possible deltas
+	worst := make([]Float16, 100)
+	for i := range worst {
+		if i%2 == 0 {
+			worst[i] = FromBits(0x7FFF)
+		} else {
+			worst[i] = FromBits(0xFFFF)
+		}
+	}
+	if enc := checkDeltaRoundTrip(t, worst); len(enc) != 1+maxDeltaBytes*len(worst) {
+		t.Errorf("worst case encoded %d bytes, want %d", len(enc), 1+maxDeltaBytes*len(worst))
+	}
+}
+
+func TestDeltaCompressesSmoothTraces(t *testing.T) {
+	trace := sensorTrace(4096)
+	enc := checkDeltaRoundTrip(t, trace)
+	if raw := 2 * len(trace); len(enc) > raw*6/10 {
+		t.Errorf("smooth trace encoded to %d bytes, raw is %d", len(enc), raw)
+	}
+}
+
+func TestDecodeDeltasErrors(t *testing.T) {
+	valid := EncodeDeltas([]Float16{One(), FromInt(2)})
+	cases := map[string][]byte{
+		"empty":          nil,
+		"truncated":      valid[:len(valid)-1],
+		"trailing bytes": append(slices.Clone(valid), 0),
+		"length too big": {0x05, 0x02},
+		"key underflow":  {0x01, 0x01}, // delta -1 from key 0
+		"key overflow":   {0x01, 0x80, 0x80, 0x08},
+	}
+	for name, data := range cases {
+		if _, err := DecodeDeltas(data); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+// sensorTrace returns a slowly varying signal typical of sensor data
+func sensorTrace(n int) []Float16 {
+	s := make([]Float16, n)
+	for i := range s {
+		s[i] = FromFloat64(20 + 5*math.Sin(float64(i)/200) + 0.01*math.Sin(float64(i)))
+	}
+	return s
+}
+
+func BenchmarkEncodeDeltas(b *testing.B) {
+	s := sensorTrace(4096)
+	b.ReportMetric(float64(DeltaEncodedSize(s))/float64(2*len(s)), "ratio")
+	b.SetBytes(int64(2 * len(s)))
+	for i := 0; i < b.N; i++ {
+		EncodeDeltas(s)
+	}
+}
+
+func BenchmarkRawFloat16Bytes(b *testing.B) {
+	s := sensorTrace(4096)
+	b.SetBytes(int64(2 * len(s)))
+	for i := 0; i < b.N; i++ {
+		buf := make([]byte, 2*len(s))
+		for j, v := range s {
+			buf[2*j] = byte(v)
+			buf[2*j+1] = byte(v >> 8)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package float16
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Exponential moving averages
+
+// NaNPolicy controls how streaming kernels treat NaN inputs
+type NaNPolicy int
+
+const (
+	// NaNPropagate lets a NaN input poison the state until it is reset
+	NaNPropagate NaNPolicy = iota
+	// NaNSkip ignores NaN inputs, leaving the state unchanged
+	NaNSkip
+)
+
+// EMA returns prev + alpha·(x - prev), the next value of an exponential
+// moving average, with a single rounding to Float16. It returns NaN if any
+// argument is NaN or alpha is outside [0, 1]; NewEMAFilter reports the latter
+// as an error. Feeding the result back in as prev rounds the state to half
+// precision at every step, so once alpha·(x - prev) drops below half an ulp of
+// prev the average stops moving; use an EMAFilter for long runs.
+func EMA(prev, x, alpha Float16) Float16 {
+	if prev.IsNaN() || x.IsNaN() || !validAlpha(alpha) {
+		return defaultNaN()
+	}
+	p, d := prev.ToFloat64(), x.ToFloat64()-prev.ToFloat64()
+	if math.IsInf(p, 0) || math.IsInf(d, 0) {
+		return FromFloat64(p + alpha.ToFloat64()*d)
+	}
+	// The difference and the product are exact in float64, leaving one sum
+	f32 := roundToOdd64(alpha.ToFloat64()*d, p)
+	if math.IsInf(float64(f32), 0) {
+		return FromFloat32(f32)
+	}
+	return fromFloat64Rounded(float64(f32), RoundNearestEven)
+}
+
+// validAlpha reports whether alpha is a usable smoothing factor
+func validAlpha(alpha Float16) bool {
+	a := alpha.ToFloat32()
+	return a >= 0 && a <= 1
+}
+
+// EMAFilter is a stateful exponential moving average that keeps its state in
+// float32, so rounding error does not build up over long runs the way it does
+// when EMA results are fed back in half precision. The first input seeds the
+// state. The zero value is not usable; create filters with NewEMAFilter.
+type EMAFilter struct {
+	alpha  float32
+	policy NaNPolicy
+	state  float32
+	primed bool
+}
+
+// NewEMAFilter returns a filter with smoothing factor alpha, which must lie in
+// [0, 1], and the given NaN policy.
+func NewEMAFilter(alpha Float16, policy NaNPolicy) (*EMAFilter, error) {
+	if !validAlpha(alpha) {
+		return nil, &Float16Error{
+			Op:   "NewEMAFilter",
+			Msg:  fmt.Sprintf("alpha %v outside [0, 1]", alpha),
+			Code: ErrInvalidOperation,
+		}
+	}
+	return &EMAFilter{alpha: alpha.ToFloat32(), policy: policy}, nil
+}
+
+// Update adds x to the average and returns the new value
+func (e *EMAFilter) Update(x Float16) Float16 {
+	switch {
+	case x.IsNaN() && e.policy == NaNSkip:
+	case !e.primed:
+		e.state, e.primed = x.ToFloat32(), true
+	default:
+		e.state += e.alpha * (x.ToFloat32() - e.state)
+	}
+	return e.Value()
+}
+
+// ApplySlice feeds every element of s through the filter and returns the
+// average after each one
+func (e *EMAFilter) ApplySlice(s []Float16) []Float16 {
+	result := make([]Float16, len(s))
+	for i, v := range s {
+		result[i] = e.Update(v)
+	}
+	return result
+}
+
+// Value returns the current average rounded to Float16, or PositiveZero
+// before the first input
+func (e *EMAFilter) Value() Float16 {
+	return FromFloat32(e.state)
+}
+
+// Reset clears the state so that the next input seeds the filter again
+func (e *EMAFilter) Reset() {
+	e.state, e.primed = 0, false
+}
+
+// State returns the float32 accumulator and whether it has been seeded, for
+// checkpointing a filter
+func (e *EMAFilter) State() (value float32, primed bool) {
+	return e.state, e.primed
+}
+
+// SetState restores an accumulator previously returned by State
+func (e *EMAFilter) SetState(value float32, primed bool) {
+	e.state, e.primed = value, primed
+}
+
+// emaFilterSize is the length of the MarshalBinary encoding
+const emaFilterSize = 10
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding holds
+// alpha and the state as little-endian float32 bits, followed by the NaN
+// policy and the seeded flag, one byte each.
+func (e *EMAFilter) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, emaFilterSize)
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(e.alpha))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(e.state))
+	buf[8] = byte(e.policy)
+	if e.primed {
+		buf[9] = 1
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (e *EMAFilter) UnmarshalBinary(data []byte) error {
+	if len(data) != emaFilterSize {
+		return fmt.Errorf("float16 EMAFilter.UnmarshalBinary: expected %d bytes, got %d", emaFilterSize, len(data))
+	}
+	alpha := math.Float32frombits(binary.LittleEndian.Uint32(data[0:]))
+	policy := NaNPolicy(data[8])
+	if !(alpha >= 0 && alpha <= 1) || (policy != NaNPropagate && policy != NaNSkip) || data[9] > 1 {
+		return fmt.Errorf("float16 EMAFilter.UnmarshalBinary: invalid encoding")
+	}
+	*e = EMAFilter{
+		alpha:  alpha,
+		policy: policy,
+		state:  math.Float32frombits(binary.LittleEndian.Uint32(data[4:])),
+		primed: data[9] == 1,
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package float16
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestEMA(t *testing.T) {
+	tests := []struct {
+		name           string
+		prev, x, alpha Float16
+		want           Float16
+	}{
+		{"halfway", Zero(), Two16, Half16, One16},
+		{"alpha zero", Three16, Ten16, PositiveZero, Three16},
+		{"alpha one", Three16, Ten16, One16, Ten16},
+		{"negative step", Ten16, Two16, Quarter16, FromFloat32(8)},
+		{"NaN input", One16, QuietNaN, Half16, QuietNaN},
+		{"NaN prev", QuietNaN, One16, Half16, QuietNaN},
+		{"alpha above one", One16, Two16, Two16, QuietNaN},
+		{"negative alpha", One16, Two16, Half16.Neg(), QuietNaN},
+		{"overflow", MaxValue, MaxValue, Half16, MaxValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EMA(tt.prev, tt.x, tt.alpha)
+			if got != tt.want && !(got.IsNaN() && tt.want.IsNaN()) {
+				t.Errorf("EMA(%v, %v, %v) = %v, want %v", tt.prev, tt.x, tt.alpha, got, tt.want)
+			}
+		})
+	}
+
+	// Single rounding of the exact result
+	alpha := FromFloat32(0.1)
+	for _, pair := range [][2]float32{{1, 2049}, {0.3, -0.7}, {1000, 1001}, {6e-5, -6e-5}} {
+		prev, x := FromFloat32(pair[0]), FromFloat32(pair[1])
+		exact := new(big.Rat).Sub(ratOf(x), ratOf(prev))
+		exact.Mul(exact, ratOf(alpha)).Add(exact, ratOf(prev))
+		if got, want := EMA(prev, x, alpha), fromRatRoundToOdd(exact); got != want {
+			t.Errorf("EMA(%v, %v, %v) = %v, want %v", prev, x, alpha, got, want)
+		}
+	}
+}
+
+// ratOf returns the exact value of a finite f
+func ratOf(f Float16) *big.Rat {
+	return new(big.Rat).SetFloat64(f.ToFloat64())
+}
+
+func TestNewEMAFilter(t *testing.T) {
+	for _, alpha := range []Float16{FromFloat32(1.5), One16.Neg(), QuietNaN, PositiveInfinity} {
+		var fe *Float16Error
+		if _, err := NewEMAFilter(alpha, NaNPropagate); !errors.As(err, &fe) || fe.Code != ErrInvalidOperation {
+			t.Errorf("NewEMAFilter(%v): err = %v, want ErrInvalidOperation", alpha, err)
+		}
+	}
+	for _, alpha := range []Float16{PositiveZero, NegativeZero, Half16, One16} {
+		if _, err := NewEMAFilter(alpha, NaNPropagate); err != nil {
+			t.Errorf("NewEMAFilter(%v): %v", alpha, err)
+		}
+	}
+}
+
+func TestEMAFilterLongRun(t *testing.T) {
+	// A small alpha and a signal far from zero: each naive half-precision step
+	// is rounded away once alpha·(x - prev) falls below half an ulp of prev
+	alpha := FromFloat32(1.0 / 1024)
+	const target, steps = 1000.0, 20000
+
+	filter, err := NewEMAFilter(alpha, NaNPropagate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter.Update(Zero())
+	ref, naive := 0.0, Zero()
+	x := FromFloat64(target)
+	for i := 0; i < steps; i++ {
+		filter.Update(x)
+		naive = EMA(naive, x, alpha)
+		ref += alpha.ToFloat64() * (target - ref)
+	}
+
+	if d := math.Abs(filter.Value().ToFloat64() - ref); d > 0.5 {
+		t.Errorf("filter = %v, reference %v", filter.Value(), ref)
+	}
+	if d := math.Abs(naive.ToFloat64() - ref); d < 100 {
+		t.Errorf("naive EMA = %v stayed close to reference %v; the test no longer shows drift", naive, ref)
+	}
+}
+
+func TestEMAFilterNaNPolicy(t *testing.T) {
+	input := []Float16{Two16, QuietNaN, Four16}
+
+	skip, _ := NewEMAFilter(Half16, NaNSkip)
+	got := skip.ApplySlice(input)
+	want := []Float16{Two16, Two16, Three16}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("NaNSkip: output[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	poison, _ := NewEMAFilter(Half16, NaNPropagate)
+	got = poison.ApplySlice(input)
+	if got[0] != Two16 || !got[1].IsNaN() || !got[2].IsNaN() {
+		t.Errorf("NaNPropagate: outputs = %v", got)
+	}
+	poison.Reset()
+	if v := poison.Update(Four16); v != Four16 {
+		t.Errorf("after Reset, Update(4) = %v, want 4", v)
+	}
+
+	// A leading NaN does not seed a skipping filter
+	skip.Reset()
+	skip.Update(QuietNaN)
+	if v := skip.Update(One16); v != One16 {
+		t.Errorf("after a leading NaN, Update(1) = %v, want 1", v)
+	}
+}
+
+func TestEMAFilterSerialization(t *testing.T) {
+	a, _ := NewEMAFilter(FromFloat32(0.125), NaNSkip)
+	a.ApplySlice(ToSlice16([]float32{1, 5, -3, 7}))
+
+	data, err := a.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b EMAFilter
+	if err := b.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if *a != b {
+		t.Fatalf("UnmarshalBinary = %+v, want %+v", b, *a)
+	}
+	next := ToSlice16([]float32{2, QuietNaN.ToFloat32(), 9})
+	ga, gb := a.ApplySlice(next), b.ApplySlice(next)
+	for i := range ga {
+		if ga[i] != gb[i] {
+			t.Errorf("restored filter diverged at %d: %v vs %v", i, gb[i], ga[i])
+		}
+	}
+
+	// State and SetState carry the float32 accumulator exactly
+	c, _ := NewEMAFilter(FromFloat32(0.125), NaNSkip)
+	c.SetState(a.State())
+	if *c != *a {
+		t.Errorf("SetState(State()) = %+v, want %+v", *c, *a)
+	}
+
+	bad := [][]byte{
+		nil,
+		make([]byte, emaFilterSize+1),
+		{0, 0, 0xc0, 0x3f, 0, 0, 0, 0, 0, 0}, // alpha 1.5
+		{0, 0, 0, 0, 0, 0, 0, 0, 2, 0},       // unknown policy
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, 2},       // bad flag
+	}
+	for _, data := range bad {
+		if err := b.UnmarshalBinary(data); err == nil {
+			t.Errorf("UnmarshalBinary(%x) succeeded", data)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package float16
+
+import "fmt"
+
+// Stable enum values
+//
+// RoundingMode, ConversionMode, ArithmeticMode, ErrorCode, FloatClass,
+// ConversionEvent, DataProfile, SubnormalMode and the Flags bits have
+// explicit numeric values that are guaranteed not to change, so they may be
+// persisted or sent over the wire as integers. New constants are only ever
+// appended with new values. Decode persisted integers with the *FromInt
+// constructors below, which reject values this version does not know. See
+// docs/stable-enums.md for the table of values.
+
+// Compile-time checks that the stable values have not been renumbered. An
+// out-of-range constant index fails the build.
+func _() {
+	var x [1]struct{}
+	_ = x[RoundNearestEven-0]
+	_ = x[RoundTowardZero-1]
+	_ = x[RoundTowardPositive-2]
+	_ = x[RoundTowardNegative-3]
+	_ = x[RoundNearestAway-4]
+
+	_ = x[ModeIEEE-0]
+	_ = x[ModeStrict-1]
+
+	_ = x[ModeIEEEArithmetic-0]
+	_ = x[ModeFastArithmetic-1]
+	_ = x[ModeExactArithmetic-2]
+
+	_ = x[ErrInvalidOperation-0]
+	_ = x[ErrNaN-1]
+	_ = x[ErrInfinity-2]
+	_ = x[ErrOverflow-3]
+	_ = x[ErrUnderflow-4]
+	_ = x[ErrDivisionByZero-5]
+	_ = x[ErrNotImplemented-6]
+
+	_ = x[ClassPositiveZero-0]
+	_ = x[ClassNegativeZero-1]
+	_ = x[ClassPositiveSubnormal-2]
+	_ = x[ClassNegativeSubnormal-3]
+	_ = x[ClassPositiveNormal-4]
+	_ = x[ClassNegativeNormal-5]
+	_ = x[ClassPositiveInfinity-6]
+	_ = x[ClassNegativeInfinity-7]
+	_ = x[ClassQuietNaN-8]
+	_ = x[ClassSignalingNaN-9]
+
+	_ = x[ConversionExact-0]
+	_ = x[ConversionRounded-1]
+	_ = x[ConversionOverflowed-2]
+	_ = x[ConversionUnderflowed-3]
+	_ = x[ConversionSpecialValue-4]
+
+	_ = x[FlagInexact-1]
+	_ = x[FlagOverflow-2]
+	_ = x[FlagUnderflow-4]
+	_ = x[FlagInvalid-8]
+	_ = x[FlagDivByZero-16]
+
+	_ = x[ProfileUniformNormal-0]
+	_ = x[ProfileHeavySubnormal-1]
+	_ = x[ProfileMixedSpecial-2]
+	_ = x[ProfileIntegerHeavy-3]
+
+	_ = x[SubnormalExact-0]
+	_ = x[SubnormalFlushToZero-1]
+}
+
+// RoundingModeFromInt decodes a persisted RoundingMode value.
+func RoundingModeFromInt(v int) (RoundingMode, error) {
+	if v < int(RoundNearestEven) || v > int(RoundNearestAway) {
+		return 0, enumError("RoundingMode", v)
+	}
+	return RoundingMode(v), nil
+}
+
+// ConversionModeFromInt decodes a persisted ConversionMode value.
+func ConversionModeFromInt(v int) (ConversionMode, error) {
+	if v < int(ModeIEEE) || v > int(ModeStrict) {
+		return 0, enumError("ConversionMode", v)
+	}
+	return ConversionMode(v), nil
+}
+
+// ArithmeticModeFromInt decodes a persisted ArithmeticMode value.
+func ArithmeticModeFromInt(v int) (ArithmeticMode, error) {
+	if v < int(ModeIEEEArithmetic) || v > int(ModeExactArithmetic) {
+		return 0, enumError("ArithmeticMode", v)
+	}
+	return ArithmeticMode(v), nil
+}
+
+// ErrorCodeFromInt decodes a persisted ErrorCode value.
+func ErrorCodeFromInt(v int) (ErrorCode, error) {
+	if v < int(ErrInvalidOperation) || v > int(ErrNotImplemented) {
+		return 0, enumError("ErrorCode", v)
+	}
+	return ErrorCode(v), nil
+}
+
+// FloatClassFromInt decodes a persisted FloatClass value.
+func FloatClassFromInt(v int) (FloatClass, error) {
+	if v < int(ClassPositiveZero) || v > int(ClassSignalingNaN) {
+		return 0, enumError("FloatClass", v)
+	}
+	return FloatClass(v), nil
+}
+
+// ConversionEventFromInt decodes a persisted ConversionEvent value.
+func ConversionEventFromInt(v int) (ConversionEvent, error) {
+	if v < int(ConversionExact) || v > int(ConversionSpecialValue) {
+		return 0, enumError("ConversionEvent", v)
+	}
+	return ConversionEvent(v), nil
+}
+
+// DataProfileFromInt decodes a persisted DataProfile value.
+func DataProfileFromInt(v int) (DataProfile, error) {
+	if v < int(ProfileUniformNormal) || v > int(ProfileIntegerHeavy) {
+		return 0, enumError("DataProfile", v)
+	}
+	return DataProfile(v), nil
+}
+
+// SubnormalModeFromInt decodes a persisted SubnormalMode value.
+func SubnormalModeFromInt(v int) (SubnormalMode, error) {
+	if v < int(SubnormalExact) || v > int(SubnormalFlushToZero) {
+		return 0, enumError("SubnormalMode", v)
+	}
+	return SubnormalMode(v), nil
+}
+
+// FlagsFromInt decodes a persisted Flags set, rejecting unknown bits.
+func FlagsFromInt(v int) (Flags, error) {
+	if v < 0 || v > int(allFlags) {
+		return 0, enumError("Flags", v)
+	}
+	return Flags(v), nil
+}
+
+// enumError reports an integer that does not name a known enum constant
+func enumError(typ string, v int) error {
+	return &Float16Error{
+		Op:   typ + "FromInt",
+		Msg:  fmt.Sprintf("unknown %s value %d", typ, v),
+		Code: ErrInvalidOperation,
+	}
+}
@@ -0,0 +1,120 @@
+package float16
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStableEnumValues(t *testing.T) {
+	locked := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{"RoundNearestEven", int(RoundNearestEven), 0},
+		{"RoundTowardZero", int(RoundTowardZero), 1},
+		{"RoundTowardPositive", int(RoundTowardPositive), 2},
+		{"RoundTowardNegative", int(RoundTowardNegative), 3},
+		{"RoundNearestAway", int(RoundNearestAway), 4},
+		{"ModeIEEE", int(ModeIEEE), 0},
+		{"ModeStrict", int(ModeStrict), 1},
+		{"ModeIEEEArithmetic", int(ModeIEEEArithmetic), 0},
+		{"ModeFastArithmetic", int(ModeFastArithmetic), 1},
+		{"ModeExactArithmetic", int(ModeExactArithmetic), 2},
+		{"ErrInvalidOperation", int(ErrInvalidOperation), 0},
+		{"ErrNaN", int(ErrNaN), 1},
+		{"ErrInfinity", int(ErrInfinity), 2},
+		{"ErrOverflow", int(ErrOverflow), 3},
+		{"ErrUnderflow", int(ErrUnderflow), 4},
+		{"ErrDivisionByZero", int(ErrDivisionByZero), 5},
+		{"ErrNotImplemented", int(ErrNotImplemented), 6},
+		{"ClassPositiveZero", int(ClassPositiveZero), 0},
+		{"ClassNegativeZero", int(ClassNegativeZero), 1},
+		{"ClassPositiveSubnormal", int(ClassPositiveSubnormal), 2},
+		{"ClassNegativeSubnormal", int(ClassNegativeSubnormal), 3},
+		{"ClassPositiveNormal", int(ClassPositiveNormal), 4},
+		{"ClassNegativeNormal", int(ClassNegativeNormal), 5},
+		{"ClassPositiveInfinity", int(ClassPositiveInfinity), 6},
+		{"ClassNegativeInfinity", int(ClassNegativeInfinity), 7},
+		{"ClassQuietNaN", int(ClassQuietNaN), 8},
+		{"ClassSignalingNaN", int(ClassSignalingNaN), 9},
+		{"ConversionExact", int(ConversionExact), 0},
+		{"ConversionRounded", int(ConversionRounded), 1},
+		{"ConversionOverflowed", int(ConversionOverflowed), 2},
+		{"ConversionUnderflowed", int(ConversionUnderflowed), 3},
+		{"ConversionSpecialValue", int(ConversionSpecialValue), 4},
+		{"FlagInexact", int(FlagInexact), 1},
+		{"FlagOverflow", int(FlagOverflow), 2},
+		{"FlagUnderflow", int(FlagUnderflow), 4},
+		{"FlagInvalid", int(FlagInvalid), 8},
+		{"FlagDivByZero", int(FlagDivByZero), 16},
+		{"ProfileUniformNormal", int(ProfileUniformNormal), 0},
+		{"ProfileHeavySubnormal", int(ProfileHeavySubnormal), 1},
+		{"ProfileMixedSpecial", int(ProfileMixedSpecial), 2},
+		{"ProfileIntegerHeavy", int(ProfileIntegerHeavy), 3},
+		{"SubnormalExact", int(SubnormalExact), 0},
+		{"SubnormalFlushToZero", int(SubnormalFlushToZero), 1},
+	}
+	for _, c := range locked {
+		if c.got != c.want {
+			t.Errorf("%s = %d, want stable value %d", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestDecodePersistedEnums(t *testing.T) {
+	// A Config persisted as integers by an earlier release
+	persisted := struct{ conversion, rounding, arithmetic int }{1, 3, 1}
+
+	conv, err := ConversionModeFromInt(persisted.conversion)
+	if err != nil || conv != ModeStrict {
+		t.Errorf("ConversionModeFromInt(1) = (%v, %v), want ModeStrict", conv, err)
+	}
+	round, err := RoundingModeFromInt(persisted.rounding)
+	if err != nil || round != RoundTowardNegative {
+		t.Errorf("RoundingModeFromInt(3) = (%v, %v), want RoundTowardNegative", round, err)
+	}
+	arith, err := ArithmeticModeFromInt(persisted.arithmetic)
+	if err != nil || arith != ModeFastArithmetic {
+		t.Errorf("ArithmeticModeFromInt(1) = (%v, %v), want ModeFastArithmetic", arith, err)
+	}
+	if code, err := ErrorCodeFromInt(5); err != nil || code != ErrDivisionByZero {
+		t.Errorf("ErrorCodeFromInt(5) = (%v, %v), want ErrDivisionByZero", code, err)
+	}
+	if class, err := FloatClassFromInt(9); err != nil || class != ClassSignalingNaN {
+		t.Errorf("FloatClassFromInt(9) = (%v, %v), want ClassSignalingNaN", class, err)
+	}
+}
+
+func TestDecodeUnknownEnums(t *testing.T) {
+	decoders := map[string]func(int) error{
+		"RoundingMode":    func(v int) error { _, err := RoundingModeFromInt(v); return err },
+		"ConversionMode":  func(v int) error { _, err := ConversionModeFromInt(v); return err },
+		"ArithmeticMode":  func(v int) error { _, err := ArithmeticModeFromInt(v); return err },
+		"ErrorCode":       func(v int) error { _, err := ErrorCodeFromInt(v); return err },
+		"FloatClass":      func(v int) error { _, err := FloatClassFromInt(v); return err },
+		"ConversionEvent": func(v int) error { _, err := ConversionEventFromInt(v); return err },
+		"DataProfile":     func(v int) error { _, err := DataProfileFromInt(v); return err },
+		"SubnormalMode":   func(v int) error { _, err := SubnormalModeFromInt(v); return err },
+	}
+	for name, decode := range decoders {
+		for _, v := range []int{-1, 10, 99} {
+			var fe *Float16Error
+			if err := decode(v); !errors.As(err, &fe) || fe.Code != ErrInvalidOperation {
+				t.Errorf("%s(%d): err = %v, want ErrInvalidOperation", name, v, err)
+			}
+		}
+	}
+
+	// Flags is a bitset, so every combination of known bits decodes
+	for v := 0; v < 32; v++ {
+		if f, err := FlagsFromInt(v); err != nil || int(f) != v {
+			t.Errorf("FlagsFromInt(%d) = (%v, %v)", v, f, err)
+		}
+	}
+	for _, v := range []int{-1, 32, 99} {
+		if _, err := FlagsFromInt(v); err == nil {
+			t.Errorf("FlagsFromInt(%d) succeeded", v)
+		}
+	}
+}
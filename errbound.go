@@ -0,0 +1,140 @@
+package float16
+
+import "math"
+
+// Reductions with error bounds
+//
+// SumSliceWithError, DotProductWithError and Norm2WithError accumulate in
+// float64 and round the result once to Float16, like DotAccumulator. Next to
+// the result they return a rigorous bound on |result - exact|, where exact is
+// the mathematical value of the reduction of the Float16 inputs. The bound is
+// gathered in the same pass as the result.
+//
+// With u = 2^-53 and γ(k) = k·u / (1 - k·u), the float64 recursive sum s of n
+// terms xᵢ satisfies |s - Σxᵢ| <= γ(n-1)·Σ|xᵢ| (Higham, Accuracy and
+// Stability of Numerical Algorithms, §4.2). The running Σ|xᵢ| is itself a
+// float64 sum, so the code uses the slightly larger
+//
+//	δ = γ(2n)·t,  t = computed Σ|xᵢ|
+//
+// Every term is an integer multiple of a quantum q (2^-24 for Float16 values,
+// 2^-48 for their products), so while t < 2^53·q every partial sum is exact
+// and δ = 0. The reported bounds are
+//
+//	sum, dot:  |r - s| + δ
+//	norm:      |r - v| + u·v + δ/v,  v = computed √s
+//
+// where r is the Float16 result and the δ/v term bounds |√s - √Σxᵢ²|; u·v is
+// dropped when v·v = s exactly. When the other terms are nonzero the bound is
+// scaled up by 2^-49 relative and one float64 ulp to cover the roundings made
+// while evaluating it. A non-finite result has an infinite bound.
+
+// boundedSum is a float64 running sum that tracks what is needed to bound its
+// rounding error
+type boundedSum struct {
+	s, t float64 // the sum and the sum of magnitudes
+	n    int
+}
+
+func (b *boundedSum) add(x float64) {
+	b.s += x
+	b.t += math.Abs(x)
+	b.n++
+}
+
+// accumulationError returns δ, the bound on |s - exact sum| for terms that
+// are all integer multiples of quantum
+func (b *boundedSum) accumulationError(quantum float64) float64 {
+	if b.t < 0x1p53*quantum {
+		return 0
+	}
+	const u = 0x1p-53
+	k := 2 * float64(b.n)
+	return k * u / (1 - k*u) * b.t
+}
+
+// roundWithBound rounds v to Float16 and returns the result with the bound
+// |r - v| + extra, rounded up
+func roundWithBound(v, extra float64) (Float16, float64) {
+	if math.IsNaN(v) {
+		return defaultNaN(), math.Inf(1)
+	}
+	r := fromFloat64Rounded(v, RoundNearestEven)
+	if !r.IsFinite() {
+		return r, math.Inf(1)
+	}
+	// Both v and r are multiples of the quantum and close together, so the
+	// difference is exact and only a nonzero extra needs widening
+	e := math.Abs(r.ToFloat64() - v)
+	if extra == 0 {
+		return r, e
+	}
+	return r, math.Nextafter((e+extra)*(1+0x1p-49), math.Inf(1))
+}
+
+// SumSliceWithError returns the sum of s, rounded once from a float64
+// accumulation, together with a rigorous bound on its absolute error. When
+// Σ|sᵢ| < 2^29 the float64 sum is exact and the bound is the exact rounding
+// error of the result.
+func SumSliceWithError(s []Float16) (Float16, float64) {
+	var b boundedSum
+	for _, v := range s {
+		b.add(v.ToFloat64())
+	}
+	return roundWithBound(b.s, b.accumulationError(0x1p-24))
+}
+
+// DotProductWithError returns the dot product of a and b, rounded once from a
+// float64 accumulation of the exact products, together with a rigorous bound
+// on its absolute error. It panics if the lengths differ.
+func DotProductWithError(a, b []Float16) (Float16, float64) {
+	if len(a) != len(b) {
+		panic("float16: slice length mismatch")
+	}
+	var acc boundedSum
+	for i := range a {
+		acc.add(a[i].ToFloat64() * b[i].ToFloat64())
+	}
+	return roundWithBound(acc.s, acc.accumulationError(0x1p-48))
+}
+
+// Norm2WithError returns the Euclidean norm of s, computed as the square
+// root of a float64 sum of exact squares and rounded once, together with a
+// rigorous bound on its absolute error.
+func Norm2WithError(s []Float16) (Float16, float64) {
+	var acc boundedSum
+	for _, v := range s {
+		x := v.ToFloat64()
+		acc.add(x * x)
+	}
+	v := math.Sqrt(acc.s)
+	var extra float64
+	if v > 0 {
+		extra = acc.accumulationError(0x1p-48) / v
+		if math.FMA(v, v, -acc.s) != 0 {
+			extra += 0x1p-53 * v // the square root was rounded
+		}
+	}
+	return roundWithBound(v, extra)
+}
+
+// SumCondition returns the condition number of summing s, Σ|sᵢ| / |Σsᵢ|,
+// computed in float64. It is 1 when all elements share a sign and grows with
+// cancellation; values far above 1 suggest DotProductCompensated-style
+// compensated summation or SumSliceWithError. It is +Inf when the sum is zero
+// but some element is not, 1 for an empty or all-zero slice, and NaN when s
+// contains a NaN or an infinity. The float64 sums are exact while
+// Σ|sᵢ| < 2^29, so the zero-sum test is exact for all practical input.
+func SumCondition(s []Float16) float64 {
+	var b boundedSum
+	for _, v := range s {
+		b.add(v.ToFloat64())
+	}
+	switch {
+	case b.t == 0:
+		return 1
+	case b.s == 0:
+		return math.Inf(1)
+	}
+	return b.t / math.Abs(b.s)
+}
@@ -0,0 +1,183 @@
+package float16
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// bigOf returns the exact value of a finite f at the precision used by the
+// error-bound tests
+func bigOf(f Float16) *big.Float {
+	return new(big.Float).SetPrec(512).SetFloat64(f.ToFloat64())
+}
+
+// checkBound fails if |got - exact| exceeds bound
+func checkBound(t *testing.T, name string, got Float16, bound float64, exact *big.Float) {
+	t.Helper()
+	if !got.IsFinite() {
+		if !math.IsInf(bound, 1) {
+			t.Fatalf("%s = %v with finite bound %g", name, got, bound)
+		}
+		return
+	}
+	diff := new(big.Float).SetPrec(512).Sub(bigOf(got), exact)
+	if diff.Abs(diff).Cmp(big.NewFloat(bound)) > 0 {
+		t.Fatalf("%s = %v, exact %s: error %s exceeds bound %g", name, got, exact.Text('g', 20), diff.Text('g', 6), bound)
+	}
+}
+
+func exactSum(s []Float16) *big.Float {
+	sum := new(big.Float).SetPrec(512)
+	for _, v := range s {
+		sum.Add(sum, bigOf(v))
+	}
+	return sum
+}
+
+// errorBoundInputs returns cancellation-heavy and random slices
+func errorBoundInputs() [][]Float16 {
+	r := rand.New(rand.NewSource(7))
+	inputs := [][]Float16{
+		nil,
+		{One16, Two16, Three16},
+		{MaxValue, One16, MaxValue.Neg(), SmallestSubnormal},
+		{MaxValue, MaxValue, MaxValue.Neg(), MaxValue.Neg(), FromFloat32(0.1)},
+		{SmallestSubnormal, SmallestSubnormal.Neg(), FromFloat32(1e-3)},
+	}
+
+	// Long alternating runs of large values with small residues push the
+	// magnitude sum past the range where float64 accumulation is exact
+	long := make([]Float16, 1<<17)
+	for i := range long {
+		switch i % 4 {
+		case 0:
+			long[i] = MaxValue
+		case 1:
+			long[i] = FromFloat32(0x1p-24 * float32(1+i%7))
+		case 2:
+			long[i] = MaxValue.Neg()
+		default:
+			long[i] = FromFloat32(-0.00033)
+		}
+	}
+	inputs = append(inputs, long)
+
+	for n := 1; n <= 4096; n *= 4 {
+		s := make([]Float16, n)
+		for i := range s {
+			s[i] = FromFloat64(r.NormFloat64() * math.Pow(2, float64(r.Intn(30)-15)))
+		}
+		inputs = append(inputs, s)
+	}
+	return inputs
+}
+
+func TestSumSliceWithError(t *testing.T) {
+	for _, s := range errorBoundInputs() {
+		got, bound := SumSliceWithError(s)
+		checkBound(t, "SumSliceWithError", got, bound, exactSum(s))
+	}
+
+	// Below 2^29 in magnitude the float64 sum is exact, so the bound is the
+	// rounding error of the result itself
+	s := []Float16{FromFloat32(2048), One16, FromFloat32(0.25)}
+	got, bound := SumSliceWithError(s)
+	if got != FromFloat32(2050) || bound != 0.75 {
+		t.Errorf("SumSliceWithError(%v) = (%v, %g), want (2050, 0.75)", s, got, bound)
+	}
+	if _, bound := SumSliceWithError([]Float16{One16, Half16}); bound != 0 {
+		t.Errorf("exact sum has bound %g", bound)
+	}
+	if got, bound := SumSliceWithError([]Float16{MaxValue, MaxValue}); !got.IsInf(1) || !math.IsInf(bound, 1) {
+		t.Errorf("overflowing sum = (%v, %g), want +Inf with infinite bound", got, bound)
+	}
+	if got, bound := SumSliceWithError([]Float16{One16, QuietNaN}); !got.IsNaN() || !math.IsInf(bound, 1) {
+		t.Errorf("sum with NaN = (%v, %g), want NaN with infinite bound", got, bound)
+	}
+}
+
+func TestDotProductWithError(t *testing.T) {
+	inputs := errorBoundInputs()
+	for _, a := range inputs {
+		b := make([]Float16, len(a))
+		for i := range b {
+			// Mirror the signs so that products cancel as well
+			b[i] = inputs[len(inputs)-1][i%len(inputs[len(inputs)-1])].Abs()
+			if i%2 == 1 {
+				b[i] = b[i].Neg()
+			}
+		}
+		got, bound := DotProductWithError(a, b)
+		checkBound(t, "DotProductWithError", got, bound, new(big.Float).SetPrec(512).SetRat(exactDot(a, b)))
+	}
+
+	got, bound := DotProductWithError([]Float16{One16, Two16}, []Float16{Three16, Four16})
+	if got != FromFloat32(11) || bound != 0 {
+		t.Errorf("DotProductWithError exact = (%v, %g), want (11, 0)", got, bound)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("DotProductWithError with mismatched lengths did not panic")
+		}
+	}()
+	DotProductWithError([]Float16{One16}, nil)
+}
+
+func TestNorm2WithError(t *testing.T) {
+	for _, s := range errorBoundInputs() {
+		got, bound := Norm2WithError(s)
+		exact := new(big.Float).SetPrec(512).SetRat(exactDot(s, s))
+		exact.Sqrt(exact)
+		checkBound(t, "Norm2WithError", got, bound, exact)
+	}
+
+	got, bound := Norm2WithError([]Float16{Three16, Four16})
+	if got != Five16 || bound != 0 {
+		t.Errorf("Norm2WithError(3, 4) = (%v, %g), want (5, 0)", got, bound)
+	}
+	if got, bound := Norm2WithError(nil); got != PositiveZero || bound != 0 {
+		t.Errorf("Norm2WithError(nil) = (%v, %g), want (0, 0)", got, bound)
+	}
+	// √2 is irrational, so the bound must be positive
+	if _, bound := Norm2WithError([]Float16{One16, One16}); bound <= 0 {
+		t.Errorf("Norm2WithError(1, 1) bound = %g, want > 0", bound)
+	}
+}
+
+func BenchmarkSumSliceWithError(b *testing.B) {
+	s := GenerateBenchData(4096, ProfileUniformNormal)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SumSliceWithError(s)
+	}
+}
+
+func TestSumCondition(t *testing.T) {
+	tests := []struct {
+		name string
+		s    []Float16
+		want float64
+	}{
+		{"all positive", []Float16{One16, Two16, Three16, Half16}, 1},
+		{"all negative", []Float16{One16.Neg(), Ten16.Neg()}, 1},
+		{"near cancellation", []Float16{FromFloat32(1000), FromFloat32(-999), FromFloat32(0.5), FromFloat32(-1)}, 4001},
+		{"exact cancellation", []Float16{Three16, Three16.Neg()}, math.Inf(1)},
+		{"empty", nil, 1},
+		{"zeros", []Float16{PositiveZero, NegativeZero}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SumCondition(tt.s); got != tt.want {
+				t.Errorf("SumCondition(%v) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+	for _, s := range [][]Float16{{One16, QuietNaN}, {PositiveInfinity, NegativeInfinity}, {PositiveInfinity, One16}} {
+		if got := SumCondition(s); !math.IsNaN(got) {
+			t.Errorf("SumCondition(%v) = %v, want NaN", s, got)
+		}
+	}
+}
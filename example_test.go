@@ -0,0 +1,192 @@
+package float16_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/zerfoo/float16"
+)
+
+func ExampleToFloat16() {
+	for _, x := range []float64{1.5, 0.1, 70000, 1e-10} {
+		h := float16.ToFloat16(x)
+		fmt.Printf("%g -> %v (0x%04x)\n", x, h, h.Bits())
+	}
+	// Output:
+	// 1.5 -> 1.5 (0x3e00)
+	// 0.1 -> 0.0999756 (0x2e66)
+	// 70000 -> +Inf (0x7c00)
+	// 1e-10 -> 0 (0x0000)
+}
+
+func ExampleToFloat16WithMode_strict() {
+	for _, x := range []float64{0.5, 1e10, 1e-10} {
+		h, err := float16.ToFloat16WithMode(x, float16.ModeStrict)
+		var fe *float16.Float16Error
+		if errors.As(err, &fe) {
+			fmt.Printf("%g: error code %d\n", x, fe.Code)
+			continue
+		}
+		fmt.Printf("%g: %v\n", x, h)
+	}
+	// Output:
+	// 0.5: 0.5
+	// 1e+10: error code 3
+	// 1e-10: error code 4
+}
+
+func ExampleFromFloat32WithRounding() {
+	// 2049 lies halfway between the neighboring Float16 values 2048 and 2050
+	modes := []struct {
+		name string
+		mode float16.RoundingMode
+	}{
+		{"nearest even", float16.RoundNearestEven},
+		{"toward zero", float16.RoundTowardZero},
+		{"toward +Inf", float16.RoundTowardPositive},
+	}
+	for _, m := range modes {
+		fmt.Printf("%s: %v\n", m.name, float16.FromFloat32WithRounding(2049, m.mode))
+	}
+	// Output:
+	// nearest even: 2048
+	// toward zero: 2048
+	// toward +Inf: 2050
+}
+
+func ExampleFloat16_String() {
+	values := []float16.Float16{
+		float16.One16,
+		float16.FromFloat32(0.1),
+		float16.NegativeZero,
+		float16.MaxValue,
+		float16.SmallestSubnormal,
+		float16.NegativeInfinity,
+		float16.QuietNaN,
+	}
+	for _, v := range values {
+		fmt.Println(v)
+	}
+	// Output:
+	// 1
+	// 0.0999756
+	// -0
+	// 65504
+	// 5.96046e-08
+	// -Inf
+	// NaN
+}
+
+func ExampleFloat16_DebugString() {
+	// DebugString tells apart values that String prints the same way
+	for _, v := range []float16.Float16{float16.QuietNaN, float16.FromBits(0xfe01), float16.FromFloat32(0.1)} {
+		fmt.Printf("%v %s\n", v, v.DebugString())
+	}
+	// Output:
+	// NaN NaN(0x200)
+	// NaN -NaN(0x201)
+	// 0.0999756 0.1
+}
+
+func ExampleFormatFloat() {
+	h := float16.FromFloat32(0.1)
+	fmt.Println(float16.FormatFloat(h, 'g', -1))
+	fmt.Println(float16.FormatFloat(h, 'g', -2))
+	fmt.Println(float16.FormatFloat(h, 'e', 3))
+	// Output:
+	// 0.099975586
+	// 0.1
+	// 9.998e-02
+}
+
+func ExampleParse() {
+	for _, s := range []string{"0.1", "-0", "65520", "1e-10", "abc"} {
+		h, err := float16.Parse(s)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Printf("%s -> %v (0x%04x)\n", s, h, h.Bits())
+	}
+	// Output:
+	// 0.1 -> 0.0999756 (0x2e66)
+	// -0 -> -0 (0x8000)
+	// 65520 -> +Inf (0x7c00)
+	// 1e-10 -> 0 (0x0000)
+	// float16 Parse: invalid syntax "abc"
+}
+
+func ExampleAdd() {
+	a, b := float16.FromFloat32(1.5), float16.FromFloat32(2.25)
+	fmt.Println(float16.Add(a, b))
+	fmt.Println(float16.Add(float16.MaxValue, float16.MaxValue))
+	fmt.Println(float16.Add(float16.NegativeZero, float16.NegativeZero))
+	// Output:
+	// 3.75
+	// +Inf
+	// -0
+}
+
+func ExampleSqrt() {
+	fmt.Println(float16.Sqrt(float16.FromFloat32(2)))
+	fmt.Println(float16.Sqrt(float16.NegativeZero))
+	fmt.Println(float16.Sqrt(float16.FromFloat32(-1)))
+	// Output:
+	// 1.41406
+	// -0
+	// NaN
+}
+
+func ExampleDotProduct() {
+	a := float16.ToSlice16([]float32{1, 2, 3})
+	b := float16.ToSlice16([]float32{4, 5, 6})
+	fmt.Println(float16.DotProduct(a, b))
+	// Output:
+	// 32
+}
+
+func ExampleSliceStats() {
+	s := float16.ToSlice16([]float32{3, -1, 0.5, 2})
+	stats := float16.ComputeSliceStats(s)
+	fmt.Printf("min %v, max %v, sum %v, mean %v, n %d\n",
+		stats.Min, stats.Max, stats.Sum, stats.Mean, stats.Length)
+	// Output:
+	// min -1, max 3, sum 4.5, mean 1.125, n 4
+}
+
+func ExampleConfigure() {
+	saved := float16.GetConfig()
+	defer float16.Configure(saved)
+
+	cfg := float16.DefaultConfig()
+	cfg.DefaultNaN = float16.FromBits(0x7e2a) // a NaN whose payload marks our results
+	float16.Configure(cfg)
+
+	nan := float16.Sqrt(float16.FromFloat32(-1))
+	fmt.Println(nan.DebugString())
+	// Output:
+	// NaN(0x22a)
+}
+
+func ExampleBFloat16FromFloat32() {
+	// BFloat16 keeps the float32 exponent range but only 8 significant bits
+	b := float16.BFloat16FromFloat32(70000)
+	fmt.Println(b, b.ToFloat32())
+	// Output:
+	// 70144 70144
+}
+
+func ExampleOptionalFloat16() {
+	row := []float16.OptionalFloat16{
+		float16.NewOptionalFloat16(float16.One16),
+		{}, // null
+		float16.NewOptionalFloat16(float16.QuietNaN),
+	}
+	data, _ := json.Marshal(row)
+	fmt.Println(string(data))
+	fmt.Println(float16.MaskNulls(row))
+	// Output:
+	// [1,null,"NaN"]
+	// [false true false]
+}
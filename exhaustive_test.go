@@ -0,0 +1,27 @@
+//go:build exhaustive
+
+package float16
+
+import "testing"
+
+// Run with: go test -tags exhaustive -run Exhaustive -timeout 2h
+
+func TestExhaustiveBinaryArithmetic(t *testing.T) {
+	ops := []struct {
+		name string
+		op   func(a, b Float16) Float16
+		ref  func(a, b float64) float64
+	}{
+		{"Add", Add, func(a, b float64) float64 { return a + b }},
+		{"Sub", Sub, func(a, b float64) float64 { return a - b }},
+		{"Mul", Mul, func(a, b float64) float64 { return a * b }},
+		{"Div", Div, func(a, b float64) float64 { return a / b }},
+	}
+	for _, o := range ops {
+		t.Run(o.name, func(t *testing.T) {
+			if failures := ExhaustiveVerifyBinary(o.op, o.ref, 0, 1); len(failures) != 0 {
+				t.Errorf("%d failures, first %v", len(failures), failures[0])
+			}
+		})
+	}
+}
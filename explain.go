@@ -0,0 +1,156 @@
+package float16
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Conversion explanations
+
+// ConversionExplanation describes how a float64 maps to Float16 under round
+// to nearest even. It is meant for debugging and teaching, for example to
+// show why 0.1 prints as 0.0999756.
+type ConversionExplanation struct {
+	Input  float64
+	Result Float16
+	Event  ConversionEvent
+
+	// ExactValue is the exact decimal value of Result, with no rounding
+	ExactValue string
+
+	// AbsError is |Result - Input| and RelError is AbsError / |Input|. Both
+	// are zero for exact conversions and RelError is zero for a zero input.
+	AbsError float64
+	RelError float64
+
+	// Below and Above are the representable values that bracket Input, with
+	// Below <= Input <= Above. Past MaxValue, Above is the infinity of the
+	// same sign. For exact conversions both equal Result.
+	Below, Above Float16
+
+	// Tie reports that Input lay exactly halfway between Below and Above
+	Tie bool
+	// Direction is -1 if Result is below Input, +1 if it is above and 0 if
+	// the conversion was exact
+	Direction int
+}
+
+// ExplainConversion reports how f64 converts to Float16 with round to
+// nearest even: the result and its exact value, the error, the neighboring
+// representable values and whether the input was a tie. NaN and infinities
+// are explained as exact with Event ConversionSpecialValue.
+func ExplainConversion(f64 float64) ConversionExplanation {
+	result, event := FromFloat64Counted(f64, ModeIEEE, RoundNearestEven)
+	e := ConversionExplanation{
+		Input:      f64,
+		Result:     result,
+		Event:      event,
+		ExactValue: exactDecimal(result),
+		Below:      result,
+		Above:      result,
+	}
+	if event == ConversionSpecialValue || event == ConversionExact {
+		return e
+	}
+
+	var sign Float16
+	if f64 < 0 {
+		sign = SignMask
+	}
+	abs := math.Abs(f64)
+	down, lo, hi := bracketMagnitude(abs)
+	near, far := down|sign, (down+1)|sign // toward and away from zero
+	e.Below, e.Above = near, far
+	if sign != 0 {
+		e.Below, e.Above = far, near
+	}
+	e.Tie = abs-lo == hi-abs
+
+	if result.IsInf(0) {
+		e.AbsError = math.Inf(1)
+	} else {
+		diff := new(big.Rat).SetFloat64(result.ToFloat64())
+		diff.Sub(diff, new(big.Rat).SetFloat64(f64))
+		e.AbsError, _ = diff.Abs(diff).Float64()
+	}
+	e.RelError = e.AbsError / abs
+	e.Direction = 1
+	if result.ToFloat64() < f64 {
+		e.Direction = -1
+	}
+	return e
+}
+
+// exactDecimal returns the exact decimal expansion of f. Every finite Float16
+// is a multiple of 2^-24, so 24 fractional digits always suffice.
+func exactDecimal(f Float16) string {
+	if !f.IsFinite() {
+		return f.String()
+	}
+	s := new(big.Rat).SetFloat64(f.ToFloat64()).FloatString(24)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if f == NegativeZero {
+		return "-0"
+	}
+	return s
+}
+
+// String renders a one-line report, for example
+//
+//	0.1 -> 0x2e66 = 0.0999755859375 (Rounded down; abs error 2.44141e-05,
+//	rel error 0.000244141; between 0.0999755859375 and 0.10003662109375)
+//
+// on a single line.
+func (e ConversionExplanation) String() string {
+	head := fmt.Sprintf("%g -> 0x%04x = %s", e.Input, e.Result.Bits(), e.ExactValue)
+	if e.Direction == 0 {
+		return fmt.Sprintf("%s (%v)", head, e.Event)
+	}
+	how := "down"
+	if e.Direction > 0 {
+		how = "up"
+	}
+	if e.Tie {
+		how += " as a tie to even"
+	}
+	return fmt.Sprintf("%s (%v %s; abs error %.6g, rel error %.6g; between %s and %s)",
+		head, e.Event, how, e.AbsError, e.RelError, exactDecimal(e.Below), exactDecimal(e.Above))
+}
+
+// RoundingInterval returns the set of float64 values that convert to f with
+// round to nearest even, as endpoints lo <= hi and whether each endpoint is
+// included. Interior endpoints are the midpoints between f and its
+// neighbors; a midpoint belongs to f exactly when f has an even significand.
+//
+// The interval of MaxValue ends at 65520, exclusive, where values start
+// rounding to infinity, and the interval of +Inf is [65520, +Inf]. The zeros
+// share the midpoint 2^-25 with the smallest subnormals: +0 owns [0, 2^-25]
+// and -0 owns [-2^-25, -0], since a float64 zero converts to the zero of the
+// same sign. NaN has no interval and gives NaN endpoints.
+func RoundingInterval(f Float16) (lo, hi float64, loIncl, hiIncl bool) {
+	if f.IsNaN() {
+		return math.NaN(), math.NaN(), false, false
+	}
+	mag := f.Abs()
+	even := mag&1 == 0
+	switch {
+	case mag.IsInf(0):
+		lo, hi, loIncl, hiIncl = 65520, math.Inf(1), true, true
+	case mag == 0:
+		lo, hi, loIncl, hiIncl = 0, 0x1p-25, true, true
+	default:
+		below, above := (mag - 1).ToFloat64(), (mag + 1).ToFloat64()
+		if mag == MaxValue {
+			above = 65536 // where the next binade would start
+		}
+		v := mag.ToFloat64()
+		lo, hi = (below+v)/2, (v+above)/2
+		loIncl, hiIncl = even, even // MaxValue is odd, so 65520 is excluded
+	}
+	if f.Signbit() {
+		return -hi, -lo, hiIncl, loIncl
+	}
+	return lo, hi, loIncl, hiIncl
+}
@@ -0,0 +1,157 @@
+package float16
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExplainConversion(t *testing.T) {
+	tests := []struct {
+		name         string
+		in           float64
+		result       Float16
+		event        ConversionEvent
+		exact        string
+		below, above Float16
+		tie          bool
+		direction    int
+		text         string
+	}{
+		{
+			"tenth", 0.1, 0x2e66, ConversionRounded, "0.0999755859375", 0x2e66, 0x2e67, false, -1,
+			"0.1 -> 0x2e66 = 0.0999755859375 (Rounded down; abs error 2.44141e-05, rel error 0.000244141; between 0.0999755859375 and 0.10003662109375)",
+		},
+		{
+			"tie", 2049, 0x6800, ConversionRounded, "2048", 0x6800, 0x6801, true, -1,
+			"2049 -> 0x6800 = 2048 (Rounded down as a tie to even; abs error 1, rel error 0.000488043; between 2048 and 2050)",
+		},
+		{
+			"above max", 65505, MaxValue, ConversionRounded, "65504", MaxValue, PositiveInfinity, false, -1,
+			"65505 -> 0x7bff = 65504 (Rounded down; abs error 1, rel error 1.5266e-05; between 65504 and +Inf)",
+		},
+		{
+			"subnormal", math.Ldexp(3, -26), SmallestSubnormal, ConversionUnderflowed,
+			"0.000000059604644775390625", PositiveZero, SmallestSubnormal, false, 1,
+			"4.470348358154297e-08 -> 0x0001 = 0.000000059604644775390625 (Underflowed up; abs error 1.49012e-08, rel error 0.333333; between 0 and 0.000000059604644775390625)",
+		},
+		{
+			"negative", -0.1, 0xae66, ConversionRounded, "-0.0999755859375", 0xae67, 0xae66, false, 1,
+			"-0.1 -> 0xae66 = -0.0999755859375 (Rounded up; abs error 2.44141e-05, rel error 0.000244141; between -0.10003662109375 and -0.0999755859375)",
+		},
+		{
+			"exact", 0.375, 0x3600, ConversionExact, "0.375", 0x3600, 0x3600, false, 0,
+			"0.375 -> 0x3600 = 0.375 (Exact)",
+		},
+		{
+			"negative zero", math.Copysign(0, -1), NegativeZero, ConversionExact, "-0", NegativeZero, NegativeZero, false, 0,
+			"-0 -> 0x8000 = -0 (Exact)",
+		},
+		{
+			"infinity", math.Inf(1), PositiveInfinity, ConversionSpecialValue, "+Inf", PositiveInfinity, PositiveInfinity, false, 0,
+			"+Inf -> 0x7c00 = +Inf (SpecialValue)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := ExplainConversion(tt.in)
+			if e.Result != tt.result || e.Event != tt.event || e.ExactValue != tt.exact {
+				t.Errorf("result = (0x%04x, %v, %s), want (0x%04x, %v, %s)",
+					e.Result.Bits(), e.Event, e.ExactValue, tt.result.Bits(), tt.event, tt.exact)
+			}
+			if e.Below != tt.below || e.Above != tt.above || e.Tie != tt.tie || e.Direction != tt.direction {
+				t.Errorf("bracket = (%v, %v, tie %v, direction %d), want (%v, %v, tie %v, direction %d)",
+					e.Below, e.Above, e.Tie, e.Direction, tt.below, tt.above, tt.tie, tt.direction)
+			}
+			if got := e.String(); got != tt.text {
+				t.Errorf("String() =\n%s\nwant\n%s", got, tt.text)
+			}
+			if tt.direction == 0 && (e.AbsError != 0 || e.RelError != 0) {
+				t.Errorf("exact conversion has error (%g, %g)", e.AbsError, e.RelError)
+			}
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		e := ExplainConversion(0.1)
+		// The float64 nearest 0.1 is 0.1000000000000000055511151231257827...
+		if want := 2.4414062500005551e-05; e.AbsError != want {
+			t.Errorf("AbsError = %g, want %g", e.AbsError, want)
+		}
+		if e.RelError != e.AbsError/0.1 {
+			t.Errorf("RelError = %g, want %g", e.RelError, e.AbsError/0.1)
+		}
+		if e := ExplainConversion(1e10); !math.IsInf(e.AbsError, 1) || e.Event != ConversionOverflowed {
+			t.Errorf("overflow: AbsError = %g, Event = %v", e.AbsError, e.Event)
+		}
+	})
+}
+
+func TestRoundingInterval(t *testing.T) {
+	tests := []struct {
+		f              Float16
+		lo, hi         float64
+		loIncl, hiIncl bool
+	}{
+		{One16, 1 - 0x1p-12, 1 + 0x1p-11, true, true},
+		{FromBits(0x3c01), 1 + 0x1p-11, 1 + 3*0x1p-11, false, false},
+		{MaxValue, 65488, 65520, false, false},
+		{PositiveInfinity, 65520, math.Inf(1), true, true},
+		{NegativeInfinity, math.Inf(-1), -65520, true, true},
+		{PositiveZero, 0, 0x1p-25, true, true},
+		{SmallestSubnormal, 0x1p-25, 3 * 0x1p-25, false, false},
+		{LargestSubnormal.Neg(), -(0x1p-14 - 0x1p-25), -(0x1p-14 - 3*0x1p-25), false, false},
+	}
+	for _, tt := range tests {
+		lo, hi, loIncl, hiIncl := RoundingInterval(tt.f)
+		if lo != tt.lo || hi != tt.hi || loIncl != tt.loIncl || hiIncl != tt.hiIncl {
+			t.Errorf("RoundingInterval(%v) = (%v, %v, %v, %v), want (%v, %v, %v, %v)",
+				tt.f, lo, hi, loIncl, hiIncl, tt.lo, tt.hi, tt.loIncl, tt.hiIncl)
+		}
+	}
+	if lo, hi, _, _ := RoundingInterval(NegativeZero); lo != -0x1p-25 || hi != 0 || !math.Signbit(hi) {
+		t.Errorf("RoundingInterval(-0) = (%v, %v), want (-2^-25, -0)", lo, hi)
+	}
+	if lo, hi, _, _ := RoundingInterval(QuietNaN); !math.IsNaN(lo) || !math.IsNaN(hi) {
+		t.Errorf("RoundingInterval(NaN) = (%v, %v), want NaN endpoints", lo, hi)
+	}
+}
+
+func TestRoundingIntervalExhaustive(t *testing.T) {
+	intervals := 0
+	for b := 0; b < 1<<16; b++ {
+		f := Float16(b)
+		if f.IsNaN() {
+			continue
+		}
+		intervals++
+		lo, hi, loIncl, hiIncl := RoundingInterval(f)
+		// Each endpoint, the float64 values on either side of it and the
+		// midpoint of the interval; an infinite endpoint has no outside
+		type check struct {
+			x    float64
+			want bool
+		}
+		checks := []check{
+			{lo, loIncl},
+			{math.Nextafter(lo, math.Inf(1)), true},
+			{hi, hiIncl},
+			{math.Nextafter(hi, math.Inf(-1)), true},
+			{lo/2 + hi/2, true},
+		}
+		if !math.IsInf(lo, 0) {
+			checks = append(checks, check{math.Nextafter(lo, math.Inf(-1)), false})
+		}
+		if !math.IsInf(hi, 0) {
+			checks = append(checks, check{math.Nextafter(hi, math.Inf(1)), false})
+		}
+		for _, c := range checks {
+			if got := ToFloat16(c.x); (got == f) != c.want {
+				t.Fatalf("RoundingInterval(%v) = [%v, %v] (%v, %v), but ToFloat16(%v) = %v",
+					f, lo, hi, loIncl, hiIncl, c.x, got)
+			}
+		}
+	}
+	if intervals != 63490 {
+		t.Errorf("checked %d intervals, want 63490", intervals)
+	}
+}
@@ -0,0 +1,243 @@
+package float16
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// IEEE 754 status flags
+
+// Flags is a set of IEEE 754 exception flags raised by an operation
+type Flags uint8
+
+// The bit values are part of the wire format and never change.
+const (
+	// FlagInexact: the rounded result differs from the exact result
+	FlagInexact Flags = 1
+	// FlagOverflow: the result rounded with an unbounded exponent exceeds
+	// MaxValue
+	FlagOverflow Flags = 2
+	// FlagUnderflow: the result is tiny (below SmallestNormal) and inexact
+	FlagUnderflow Flags = 4
+	// FlagInvalid: the operation has no meaningful result, such as
+	// Inf + (-Inf), or an operand is a signaling NaN
+	FlagInvalid Flags = 8
+	// FlagDivByZero: a finite nonzero value was divided by zero
+	FlagDivByZero Flags = 16
+)
+
+// allFlags is the union of the defined flags
+const allFlags = FlagInexact | FlagOverflow | FlagUnderflow | FlagInvalid | FlagDivByZero
+
+// CombineFlags returns the union of flags, for accumulating the flags of a
+// sequence of operations the way IEEE 754 status flags stay raised until
+// cleared
+func CombineFlags(flags ...Flags) Flags {
+	var all Flags
+	for _, f := range flags {
+		all |= f
+	}
+	return all
+}
+
+// Has reports whether every flag in mask is set in f
+func (f Flags) Has(mask Flags) bool { return f&mask == mask }
+
+// Inexact reports whether FlagInexact is set
+func (f Flags) Inexact() bool { return f&FlagInexact != 0 }
+
+// Overflow reports whether FlagOverflow is set
+func (f Flags) Overflow() bool { return f&FlagOverflow != 0 }
+
+// Underflow reports whether FlagUnderflow is set
+func (f Flags) Underflow() bool { return f&FlagUnderflow != 0 }
+
+// Invalid reports whether FlagInvalid is set
+func (f Flags) Invalid() bool { return f&FlagInvalid != 0 }
+
+// DivByZero reports whether FlagDivByZero is set
+func (f Flags) DivByZero() bool { return f&FlagDivByZero != 0 }
+
+// String lists the set flags separated by "|", or returns "none"
+func (f Flags) String() string {
+	if f == 0 {
+		return "none"
+	}
+	var names []string
+	for _, flag := range []struct {
+		bit  Flags
+		name string
+	}{
+		{FlagInexact, "Inexact"},
+		{FlagOverflow, "Overflow"},
+		{FlagUnderflow, "Underflow"},
+		{FlagInvalid, "Invalid"},
+		{FlagDivByZero, "DivByZero"},
+	} {
+		if f&flag.bit != 0 {
+			names = append(names, flag.name)
+		}
+	}
+	if rest := f &^ allFlags; rest != 0 {
+		names = append(names, fmt.Sprintf("0x%02x", uint8(rest)))
+	}
+	return strings.Join(names, "|")
+}
+
+// AddWithFlags returns a + b correctly rounded in the given mode, together
+// with the IEEE 754 flags the addition raises. NaN operands give the default
+// NaN, raising Invalid only when one of them is signaling. An exact zero sum
+// of operands with opposite signs is +0, or -0 when rounding toward -Inf.
+// Sums of Float16 values are multiples of 2^-24, so a tiny sum is always an
+// exact subnormal and addition never raises Underflow.
+func AddWithFlags(a, b Float16, rounding RoundingMode) (Float16, Flags) {
+	if a.IsNaN() || b.IsNaN() {
+		return defaultNaN(), nanOperandFlags(a, b)
+	}
+	if a.IsInf(0) && b.IsInf(0) && a != b {
+		return defaultNaN(), FlagInvalid
+	}
+	if a.IsInf(0) || b.IsInf(0) {
+		if a.IsInf(0) {
+			return a, 0
+		}
+		return b, 0
+	}
+
+	// Float16 operands span 40 bits of exponent range, so the sum is exact
+	// in float64 and the conversion is the only rounding
+	sum := a.ToFloat64() + b.ToFloat64()
+	if sum == 0 {
+		if a.Signbit() == b.Signbit() {
+			return a, 0 // both zeros of the same sign
+		}
+		if rounding == RoundTowardNegative {
+			return NegativeZero, 0
+		}
+		return PositiveZero, 0
+	}
+	return roundWithFlags(sum, rounding)
+}
+
+// MulWithFlags returns a·b correctly rounded in the given mode, together with
+// the IEEE 754 flags the multiplication raises. 0·Inf raises Invalid; NaN
+// operands behave as in AddWithFlags.
+func MulWithFlags(a, b Float16, rounding RoundingMode) (Float16, Flags) {
+	if a.IsNaN() || b.IsNaN() {
+		return defaultNaN(), nanOperandFlags(a, b)
+	}
+	if (a.IsInf(0) && b.IsZero()) || (a.IsZero() && b.IsInf(0)) {
+		return defaultNaN(), FlagInvalid
+	}
+	// The product of two 11-bit significands is exact in float64, and so are
+	// infinite and signed zero products
+	return roundWithFlags(a.ToFloat64()*b.ToFloat64(), rounding)
+}
+
+// DivWithFlags returns a/b correctly rounded in the given mode, together with
+// the IEEE 754 flags the division raises. 0/0 and Inf/Inf raise Invalid, and
+// a finite nonzero value divided by zero gives a signed infinity and raises
+// DivByZero. Inf/0 is an exact infinity and raises nothing: as in IEEE 754,
+// DivByZero marks an infinite result created from finite operands. NaN
+// operands behave as in AddWithFlags.
+func DivWithFlags(a, b Float16, rounding RoundingMode) (Float16, Flags) {
+	if a.IsNaN() || b.IsNaN() {
+		return defaultNaN(), nanOperandFlags(a, b)
+	}
+	switch {
+	case (a.IsZero() && b.IsZero()) || (a.IsInf(0) && b.IsInf(0)):
+		return defaultNaN(), FlagInvalid
+	case a.IsInf(0) || b.IsInf(0) || a.IsZero():
+		return roundWithFlags(a.ToFloat64()/b.ToFloat64(), rounding) // exact
+	case b.IsZero():
+		return PositiveInfinity | (a^b)&SignMask, FlagDivByZero
+	}
+
+	x, y := a.ToFloat64(), b.ToFloat64()
+	q := x / y
+	// The remainder of a correctly rounded quotient is exact under FMA; its
+	// sign tells on which side of q the true quotient lies
+	r := math.FMA(-q, y, x)
+	return roundWithFlags(stickyRound(q, r != 0, (r > 0) == (y > 0)), rounding)
+}
+
+// SqrtWithFlags returns the square root of a correctly rounded in the given
+// mode, together with the IEEE 754 flags raised. The square root of a value
+// below zero raises Invalid; Sqrt(-0) is -0.
+func SqrtWithFlags(a Float16, rounding RoundingMode) (Float16, Flags) {
+	switch {
+	case a.IsNaN():
+		return defaultNaN(), nanOperandFlags(a, a)
+	case a.IsZero():
+		return a, 0
+	case a.Signbit():
+		return defaultNaN(), FlagInvalid
+	case a.IsInf(0):
+		return a, 0
+	}
+	x := a.ToFloat64()
+	s := math.Sqrt(x)
+	r := math.FMA(-s, s, x)
+	return roundWithFlags(stickyRound(s, r != 0, r > 0), rounding)
+}
+
+// ToFloat16WithFlags converts f32 to Float16 in the given rounding mode and
+// returns the IEEE 754 flags the conversion raises. Converting a signaling
+// NaN raises Invalid. As in FromFloat64Counted, mode only exists to match the
+// signature of the other conversions and the value is always the IEEE
+// result.
+func ToFloat16WithFlags(f32 float32, mode ConversionMode, rounding RoundingMode) (Float16, Flags) {
+	result, event := FromFloat64Counted(float64(f32), mode, rounding)
+	if f32 != f32 {
+		if math.Float32bits(f32)&0x400000 == 0 {
+			return result, FlagInvalid
+		}
+		return result, 0
+	}
+	return result, flagsForEvent(event)
+}
+
+// nanOperandFlags returns the flags raised by an operation with a NaN
+// operand: Invalid if either operand is a signaling NaN, otherwise none
+func nanOperandFlags(a, b Float16) Flags {
+	if a.Class() == ClassSignalingNaN || b.Class() == ClassSignalingNaN {
+		return FlagInvalid
+	}
+	return 0
+}
+
+// stickyRound turns a correctly rounded float64 approximation v into one
+// that rounds to the same Float16 as the exact value: if the exact value is
+// not v, an even v is moved one float64 ulp toward it (up when above is set).
+// An odd float64 cannot lie on a Float16 midpoint, so the following rounding
+// sees the exact value's side of every midpoint.
+func stickyRound(v float64, inexact, above bool) float64 {
+	if !inexact || math.Float64bits(v)&1 == 1 {
+		return v
+	}
+	if above {
+		return math.Nextafter(v, math.Inf(1))
+	}
+	return math.Nextafter(v, math.Inf(-1))
+}
+
+// roundWithFlags rounds v to Float16 in the given mode and returns the flags
+// the rounding raises
+func roundWithFlags(v float64, rounding RoundingMode) (Float16, Flags) {
+	result, event := FromFloat64Counted(v, ModeIEEE, rounding)
+	return result, flagsForEvent(event)
+}
+
+// flagsForEvent maps a conversion event to IEEE 754 flags
+func flagsForEvent(event ConversionEvent) Flags {
+	switch event {
+	case ConversionRounded:
+		return FlagInexact
+	case ConversionOverflowed:
+		return FlagOverflow | FlagInexact
+	case ConversionUnderflowed:
+		return FlagUnderflow | FlagInexact
+	}
+	return 0
+}
@@ -0,0 +1,291 @@
+package float16
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestAddWithFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     Float16
+		rounding RoundingMode
+		want     Float16
+		flags    Flags
+	}{
+		{"exact", One16, One16, RoundNearestEven, Two16, 0},
+		{"inexact", FromFloat32(2048), One16, RoundNearestEven, FromFloat32(2048), FlagInexact},
+		{"inexact upward", FromFloat32(2048), One16, RoundTowardPositive, FromFloat32(2050), FlagInexact},
+		{"overflow", MaxValue, MaxValue, RoundNearestEven, PositiveInfinity, FlagOverflow | FlagInexact},
+		{"overflow saturates", MaxValue, MaxValue, RoundTowardZero, MaxValue, FlagOverflow | FlagInexact},
+		{"negative overflow", MinValue, MinValue, RoundTowardPositive, MinValue, FlagOverflow | FlagInexact},
+		// 65504 + 8 rounds back to MaxValue: inexact but below the overflow threshold
+		{"near overflow", MaxValue, FromFloat32(8), RoundNearestEven, MaxValue, FlagInexact},
+		{"exact subnormal", SmallestSubnormal, SmallestSubnormal, RoundNearestEven, 0x0002, 0},
+		{"exact tiny difference", SmallestNormal, SmallestSubnormal.Neg(), RoundNearestEven, LargestSubnormal, 0},
+		{"exact cancellation", FromFloat32(-1), FromBits(0x3c01), RoundNearestEven, 0x1400, 0},
+		{"inf minus inf", PositiveInfinity, NegativeInfinity, RoundNearestEven, QuietNaN, FlagInvalid},
+		{"inf plus finite", NegativeInfinity, MaxValue, RoundNearestEven, NegativeInfinity, 0},
+		{"inf plus inf", PositiveInfinity, PositiveInfinity, RoundNearestEven, PositiveInfinity, 0},
+		{"quiet NaN", QuietNaN, One16, RoundNearestEven, QuietNaN, 0},
+		{"signaling NaN", One16, SignalingNaN, RoundNearestEven, QuietNaN, FlagInvalid},
+		{"cancellation", Three16, Three16.Neg(), RoundNearestEven, PositiveZero, 0},
+		{"cancellation toward -Inf", Three16, Three16.Neg(), RoundTowardNegative, NegativeZero, 0},
+		{"negative zeros", NegativeZero, NegativeZero, RoundNearestEven, NegativeZero, 0},
+		{"mixed zeros", NegativeZero, PositiveZero, RoundNearestEven, PositiveZero, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, flags := AddWithFlags(tt.a, tt.b, tt.rounding)
+			if got != tt.want || flags != tt.flags {
+				t.Errorf("AddWithFlags(%v, %v, %v) = (%v, %v), want (%v, %v)",
+					tt.a, tt.b, tt.rounding, got, flags, tt.want, tt.flags)
+			}
+		})
+	}
+
+	// Sampled agreement with the exact float64 sum. Every sum of Float16
+	// values is a multiple of 2^-24, so a tiny sum is an exact subnormal and
+	// addition never raises Underflow.
+	for a := 0; a < 0x7C00; a += 37 {
+		for b := 0; b < 0xFC00; b += 211 {
+			x, y := Float16(a), Float16(b)
+			if !y.IsFinite() {
+				continue
+			}
+			got, flags := AddWithFlags(x, y, RoundNearestEven)
+			exact := x.ToFloat64() + y.ToFloat64()
+			if flags.Inexact() != (got.ToFloat64() != exact) {
+				t.Fatalf("AddWithFlags(%v, %v) = (%v, %v), exact sum %v", x, y, got, flags, exact)
+			}
+			if flags.Overflow() != (math.Abs(exact) >= 65520) {
+				t.Fatalf("AddWithFlags(%v, %v) overflow flag %v, exact sum %v", x, y, flags.Overflow(), exact)
+			}
+			if flags.Underflow() {
+				t.Fatalf("AddWithFlags(%v, %v) raised Underflow", x, y)
+			}
+		}
+	}
+}
+
+func TestFlags(t *testing.T) {
+	f := FlagOverflow | FlagInexact
+	if !f.Overflow() || !f.Inexact() || f.Underflow() || f.Invalid() {
+		t.Errorf("predicates of %v are wrong", f)
+	}
+	if !f.Has(FlagOverflow) || !f.Has(f) || f.Has(FlagOverflow|FlagInvalid) {
+		t.Errorf("Has is wrong for %v", f)
+	}
+	tests := map[Flags]string{
+		0:                           "none",
+		FlagInvalid:                 "Invalid",
+		FlagOverflow | FlagInexact:  "Inexact|Overflow",
+		FlagUnderflow | FlagInexact: "Inexact|Underflow",
+		FlagDivByZero:               "DivByZero",
+		Flags(0x61):                 "Inexact|0x60",
+	}
+	for f, want := range tests {
+		if got := f.String(); got != want {
+			t.Errorf("Flags(%d).String() = %q, want %q", uint8(f), got, want)
+		}
+	}
+}
+
+func TestMulDivSqrtWithFlags(t *testing.T) {
+	tiny := FromFloat32(0x1p-13)
+	tests := []struct {
+		name  string
+		op    func() (Float16, Flags)
+		want  Float16
+		flags Flags
+	}{
+		{"mul exact", func() (Float16, Flags) { return MulWithFlags(Three16, Half16, RoundNearestEven) }, FromFloat32(1.5), 0},
+		{"mul inexact", func() (Float16, Flags) { return MulWithFlags(FromBits(0x3c01), FromBits(0x3c01), RoundNearestEven) }, FromBits(0x3c02), FlagInexact},
+		{"mul overflow", func() (Float16, Flags) { return MulWithFlags(MaxValue, Two16, RoundNearestEven) }, PositiveInfinity, FlagOverflow | FlagInexact},
+		{"mul overflow saturates", func() (Float16, Flags) { return MulWithFlags(MaxValue, Two16.Neg(), RoundTowardZero) }, MinValue, FlagOverflow | FlagInexact},
+		{"mul underflow", func() (Float16, Flags) { return MulWithFlags(tiny, tiny, RoundNearestEven) }, PositiveZero, FlagUnderflow | FlagInexact},
+		{"mul underflow upward", func() (Float16, Flags) { return MulWithFlags(tiny, tiny, RoundTowardPositive) }, SmallestSubnormal, FlagUnderflow | FlagInexact},
+		{"mul exact subnormal", func() (Float16, Flags) { return MulWithFlags(SmallestNormal, Half16, RoundNearestEven) }, FromBits(0x0200), 0},
+		{"mul zero sign", func() (Float16, Flags) { return MulWithFlags(NegativeZero, Three16, RoundNearestEven) }, NegativeZero, 0},
+		{"mul zero times inf", func() (Float16, Flags) { return MulWithFlags(PositiveZero, NegativeInfinity, RoundNearestEven) }, QuietNaN, FlagInvalid},
+		{"mul inf", func() (Float16, Flags) { return MulWithFlags(NegativeInfinity, Two16.Neg(), RoundNearestEven) }, PositiveInfinity, 0},
+		{"mul signaling NaN", func() (Float16, Flags) { return MulWithFlags(SignalingNaN, One16, RoundNearestEven) }, QuietNaN, FlagInvalid},
+		{"div exact", func() (Float16, Flags) { return DivWithFlags(Three16, Two16, RoundNearestEven) }, FromFloat32(1.5), 0},
+		{"div inexact", func() (Float16, Flags) { return DivWithFlags(One16, Three16, RoundNearestEven) }, FromFloat32(1.0 / 3), FlagInexact},
+		{"div inexact upward", func() (Float16, Flags) { return DivWithFlags(One16, Three16, RoundTowardPositive) }, FromFloat32(1.0/3) + 1, FlagInexact},
+		{"div zero by zero", func() (Float16, Flags) { return DivWithFlags(PositiveZero, NegativeZero, RoundNearestEven) }, QuietNaN, FlagInvalid},
+		{"div inf by inf", func() (Float16, Flags) { return DivWithFlags(PositiveInfinity, NegativeInfinity, RoundNearestEven) }, QuietNaN, FlagInvalid},
+		{"div by zero", func() (Float16, Flags) { return DivWithFlags(One16, PositiveZero, RoundNearestEven) }, PositiveInfinity, FlagDivByZero},
+		{"div by negative zero", func() (Float16, Flags) { return DivWithFlags(One16, NegativeZero, RoundNearestEven) }, NegativeInfinity, FlagDivByZero},
+		{"div inf by zero", func() (Float16, Flags) { return DivWithFlags(NegativeInfinity, PositiveZero, RoundNearestEven) }, NegativeInfinity, 0},
+		{"div by inf", func() (Float16, Flags) { return DivWithFlags(Three16, NegativeInfinity, RoundNearestEven) }, NegativeZero, 0},
+		{"div overflow", func() (Float16, Flags) { return DivWithFlags(MaxValue, Half16, RoundNearestEven) }, PositiveInfinity, FlagOverflow | FlagInexact},
+		{"div underflow", func() (Float16, Flags) { return DivWithFlags(SmallestSubnormal, Three16, RoundNearestEven) }, PositiveZero, FlagUnderflow | FlagInexact},
+		{"div quiet NaN", func() (Float16, Flags) { return DivWithFlags(QuietNaN, PositiveZero, RoundNearestEven) }, QuietNaN, 0},
+		{"sqrt exact", func() (Float16, Flags) { return SqrtWithFlags(FromFloat32(6.25), RoundNearestEven) }, FromFloat32(2.5), 0},
+		{"sqrt inexact", func() (Float16, Flags) { return SqrtWithFlags(Two16, RoundNearestEven) }, FromFloat32(math.Sqrt2), FlagInexact},
+		{"sqrt inexact downward", func() (Float16, Flags) { return SqrtWithFlags(Two16, RoundTowardZero) }, FromFloat32(math.Sqrt2), FlagInexact},
+		{"sqrt negative", func() (Float16, Flags) { return SqrtWithFlags(One16.Neg(), RoundNearestEven) }, QuietNaN, FlagInvalid},
+		{"sqrt negative inf", func() (Float16, Flags) { return SqrtWithFlags(NegativeInfinity, RoundNearestEven) }, QuietNaN, FlagInvalid},
+		{"sqrt negative zero", func() (Float16, Flags) { return SqrtWithFlags(NegativeZero, RoundNearestEven) }, NegativeZero, 0},
+		{"sqrt inf", func() (Float16, Flags) { return SqrtWithFlags(PositiveInfinity, RoundNearestEven) }, PositiveInfinity, 0},
+		{"sqrt subnormal", func() (Float16, Flags) { return SqrtWithFlags(SmallestSubnormal, RoundNearestEven) }, FromFloat32(0x1p-12), 0},
+		{"sqrt signaling NaN", func() (Float16, Flags) { return SqrtWithFlags(SignalingNaN, RoundNearestEven) }, QuietNaN, FlagInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, flags := tt.op()
+			if got != tt.want || flags != tt.flags {
+				t.Errorf("got (%v, %v), want (%v, %v)", got, flags, tt.want, tt.flags)
+			}
+		})
+	}
+
+	// Sampled agreement with the exactly rounded quotient and square root in
+	// every rounding mode
+	modes := []RoundingMode{RoundNearestEven, RoundTowardZero, RoundTowardPositive, RoundTowardNegative, RoundNearestAway}
+	for a := 1; a < 0x7C00; a += 97 {
+		x := Float16(a)
+		for b := 1; b < 0xFC00; b += 389 {
+			y := Float16(b)
+			if !y.IsFinite() {
+				continue
+			}
+			exact := new(big.Rat).Quo(ratOf(x), ratOf(y))
+			for _, mode := range modes {
+				got, flags := DivWithFlags(x, y, mode)
+				checkRounded(t, "DivWithFlags", x, y, mode, exact, got, flags)
+			}
+		}
+		for _, mode := range modes {
+			got, flags := SqrtWithFlags(x, mode)
+			// got is correct iff the exact root lies in the rounding interval,
+			// so compare squares
+			square := new(big.Rat).Mul(ratOf(got), ratOf(got))
+			if flags.Inexact() != (square.Cmp(ratOf(x)) != 0) {
+				t.Fatalf("SqrtWithFlags(%v, %v) = (%v, %v)", x, mode, got, flags)
+			}
+			if ref := fromFloat64Rounded(math.Sqrt(x.ToFloat64()), mode); mode == RoundNearestEven && got != ref {
+				t.Fatalf("SqrtWithFlags(%v) = %v, want %v", x, got, ref)
+			}
+		}
+	}
+}
+
+// checkRounded verifies that got is exact correctly rounded in the given
+// mode and that flags reports whether it is inexact
+func checkRounded(t *testing.T, op string, x, y Float16, mode RoundingMode, exact *big.Rat, got Float16, flags Flags) {
+	t.Helper()
+	if got.IsInf(0) || got.Abs() == MaxValue {
+		return // saturation is covered by the table
+	}
+	g := ratOf(got)
+	cmp := g.Cmp(exact)
+	awayFromZero := new(big.Rat).Abs(g).Cmp(new(big.Rat).Abs(exact)) > 0
+	if flags.Inexact() != (cmp != 0) {
+		t.Fatalf("%s(%v, %v, %v) = (%v, %v), exact %v", op, x, y, mode, got, flags, exact.FloatString(12))
+	}
+	if cmp == 0 {
+		return
+	}
+	below, above := got, NextAfter(got, PositiveInfinity)
+	if cmp > 0 {
+		below, above = NextAfter(got, NegativeInfinity), got
+	}
+	if ratOf(below).Cmp(exact) >= 0 || ratOf(above).Cmp(exact) <= 0 {
+		t.Fatalf("%s(%v, %v, %v) = %v does not neighbor exact %v", op, x, y, mode, got, exact.FloatString(12))
+	}
+	var ok bool
+	switch mode {
+	case RoundTowardPositive:
+		ok = cmp > 0
+	case RoundTowardNegative:
+		ok = cmp < 0
+	case RoundTowardZero:
+		ok = !awayFromZero
+	default:
+		mid := new(big.Rat).Add(ratOf(below), ratOf(above))
+		mid.Quo(mid, big.NewRat(2, 1))
+		d := new(big.Rat).Sub(exact, mid).Sign()
+		if d == 0 {
+			ok = mode == RoundNearestAway && awayFromZero || mode == RoundNearestEven && got&1 == 0
+		} else {
+			ok = (d > 0) == (cmp > 0)
+		}
+	}
+	if !ok {
+		t.Fatalf("%s(%v, %v, %v) = %v, exact %v", op, x, y, mode, got, exact.FloatString(12))
+	}
+}
+
+func TestToFloat16WithFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       float32
+		rounding RoundingMode
+		want     Float16
+		flags    Flags
+	}{
+		{"exact", 1.5, RoundNearestEven, FromFloat32(1.5), 0},
+		{"inexact", 0.1, RoundNearestEven, FromFloat32(0.1), FlagInexact},
+		{"overflow", 1e6, RoundNearestEven, PositiveInfinity, FlagOverflow | FlagInexact},
+		{"overflow saturates", -1e6, RoundTowardZero, MinValue, FlagOverflow | FlagInexact},
+		{"underflow", 1e-10, RoundNearestEven, PositiveZero, FlagUnderflow | FlagInexact},
+		{"underflow upward", 1e-10, RoundTowardPositive, SmallestSubnormal, FlagUnderflow | FlagInexact},
+		{"exact subnormal", 0x1p-24, RoundNearestEven, SmallestSubnormal, 0},
+		{"inf", float32(math.Inf(-1)), RoundNearestEven, NegativeInfinity, 0},
+		{"quiet NaN", float32(math.NaN()), RoundNearestEven, QuietNaN, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, flags := ToFloat16WithFlags(tt.in, ModeIEEE, tt.rounding)
+			if (got != tt.want && !(got.IsNaN() && tt.want.IsNaN())) || flags != tt.flags {
+				t.Errorf("ToFloat16WithFlags(%g, %v) = (%v, %v), want (%v, %v)", tt.in, tt.rounding, got, flags, tt.want, tt.flags)
+			}
+		})
+	}
+	if got, flags := ToFloat16WithFlags(math.Float32frombits(0x7f800001), ModeIEEE, RoundNearestEven); !got.IsNaN() || flags != FlagInvalid {
+		t.Errorf("ToFloat16WithFlags(sNaN) = (%v, %v), want NaN and Invalid", got, flags)
+	}
+}
+
+func TestCombineFlags(t *testing.T) {
+	_, f1 := DivWithFlags(One16, Three16, RoundNearestEven)
+	_, f2 := DivWithFlags(One16, PositiveZero, RoundNearestEven)
+	_, f3 := SqrtWithFlags(Four16, RoundNearestEven)
+	if got := CombineFlags(f1, f2, f3); got != FlagInexact|FlagDivByZero {
+		t.Errorf("CombineFlags = %v, want Inexact|DivByZero", got)
+	}
+	if got := CombineFlags(); got != 0 {
+		t.Errorf("CombineFlags() = %v, want none", got)
+	}
+}
+
+func TestDivWithFlagsDivByZero(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  Float16
+		want  Float16
+		flags Flags
+	}{
+		{"one by zero", One16, PositiveZero, PositiveInfinity, FlagDivByZero},
+		{"negative by zero", Two16.Neg(), PositiveZero, NegativeInfinity, FlagDivByZero},
+		{"subnormal by negative zero", SmallestSubnormal, NegativeZero, NegativeInfinity, FlagDivByZero},
+		{"zero by zero", PositiveZero, PositiveZero, QuietNaN, FlagInvalid},
+		// The infinity is exact, so IEEE 754 raises no exception
+		{"inf by zero", PositiveInfinity, NegativeZero, NegativeInfinity, 0},
+		{"normal division", FromFloat32(6), Two16, Three16, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, flags := DivWithFlags(tt.a, tt.b, RoundNearestEven)
+			if (got != tt.want && !(got.IsNaN() && tt.want.IsNaN())) || flags != tt.flags {
+				t.Errorf("DivWithFlags(%v, %v) = (%v, %v), want (%v, %v)", tt.a, tt.b, got, flags, tt.want, tt.flags)
+			}
+			if flags.DivByZero() && flags.Invalid() {
+				t.Errorf("DivWithFlags(%v, %v) raised both DivByZero and Invalid", tt.a, tt.b)
+			}
+		})
+	}
+}
@@ -42,8 +42,11 @@
 package float16
 
 import (
+	"errors"
 	"math"
+	"runtime"
 	"sync"
+	"sync/atomic"
 )
 
 // Package version information
@@ -102,6 +105,31 @@ type Config struct {
 	//   - Inexact: When rounding occurs (in strict mode)
 	//
 	// See: http://en.wikipedia.org/wiki/Half-precision_floating-point_format
+
+	// Metrics receives conversion event counts; nil disables reporting
+	Metrics MetricsHook
+
+	// OnInexact, when non-nil, is called with the original value whenever
+	// FromFloat64WithMode or ToSlice16WithMode rounds. Leaving it nil keeps
+	// those conversions free of the extra exactness check.
+	OnInexact func(value float64)
+
+	// DebugChecks validates the fields of every result assembled by the
+	// conversion and arithmetic kernels. Invalid fields are reported by the
+	// error-returning APIs and produce NaN elsewhere.
+	DebugChecks bool
+
+	// DefaultNaN is the NaN produced by arithmetic, math functions and
+	// conversions. A value that is not a NaN selects QuietNaN.
+	DefaultNaN Float16
+
+	// SubnormalMode describes the emulated target, not this package: it
+	// records how the hardware being modelled treats subnormals, for callers
+	// to pass to ToFloat32WithMode. The package's own conversions and
+	// arithmetic keep subnormals whatever it says. FlushToZeroActive,
+	// SubnormalsSupported and the "supports_subnormals" key of DebugInfo
+	// report it.
+	SubnormalMode SubnormalMode
 }
 
 // DefaultConfig returns the default package configuration
@@ -111,23 +139,50 @@ func DefaultConfig() *Config {
 		DefaultRoundingMode:   DefaultRoundingMode,
 		DefaultArithmeticMode: ModeIEEEArithmetic,
 		EnableFastMath:        false,
+		DefaultNaN:            QuietNaN,
 	}
 }
 
 var (
 	configMutex sync.RWMutex
 	config      = DefaultConfig()
+
+	// defaultNaNBits holds the configured NaN; zero selects QuietNaN
+	defaultNaNBits atomic.Uint32
 )
 
-// Configure applies the given configuration to the package
+// defaultNaN returns the NaN that operations produce
+func defaultNaN() Float16 {
+	if b := defaultNaNBits.Load(); b != 0 {
+		return Float16(b)
+	}
+	return QuietNaN
+}
+
+// Configure applies the given configuration to the package.
+// It installs a copy, in which a DefaultNaN that is not a NaN is replaced by
+// QuietNaN; cfg itself is not modified.
 func Configure(cfg *Config) {
 	configMutex.Lock()
 	defer configMutex.Unlock()
+	applyConfig(cfg)
+}
 
-	config = cfg
-	DefaultConversionMode = cfg.DefaultConversionMode
-	DefaultRoundingMode = cfg.DefaultRoundingMode
-	DefaultArithmeticMode = cfg.DefaultArithmeticMode
+// applyConfig installs a copy of cfg, leaving the caller's struct as it was;
+// configMutex must be held for writing
+func applyConfig(cfg *Config) {
+	c := *cfg
+	if !c.DefaultNaN.IsNaN() {
+		c.DefaultNaN = QuietNaN
+	}
+	config = &c
+	setMetricsHook(c.Metrics)
+	setInexactHook(c.OnInexact)
+	debugChecks.Store(c.DebugChecks)
+	defaultNaNBits.Store(uint32(c.DefaultNaN))
+	DefaultConversionMode = c.DefaultConversionMode
+	DefaultRoundingMode = c.DefaultRoundingMode
+	DefaultArithmeticMode = c.DefaultArithmeticMode
 }
 
 // GetConfig returns the current package configuration
@@ -136,12 +191,151 @@ func GetConfig() *Config {
 	defer configMutex.RUnlock()
 
 	// Return a copy to prevent external modification
-	return &Config{
-		DefaultConversionMode: config.DefaultConversionMode,
-		DefaultRoundingMode:   config.DefaultRoundingMode,
-		DefaultArithmeticMode: config.DefaultArithmeticMode,
-		EnableFastMath:        config.EnableFastMath,
+	cfg := *config
+	return &cfg
+}
+
+// ConfigureChecked is Configure for untrusted input: it rejects a nil cfg
+// and unknown conversion, rounding and arithmetic modes with an
+// ErrInvalidOperation error, leaving the configuration unchanged. On success
+// it returns a copy of the configuration it replaced, for passing back to
+// Configure.
+func ConfigureChecked(cfg *Config) (previous *Config, err error) {
+	if cfg == nil {
+		return nil, &Float16Error{Op: "ConfigureChecked", Msg: "nil config", Code: ErrInvalidOperation}
 	}
+	if err := validateConfig("ConfigureChecked", cfg); err != nil {
+		return nil, err
+	}
+	configMutex.Lock()
+	defer configMutex.Unlock()
+	prev := *config
+	applyConfig(cfg)
+	return &prev, nil
+}
+
+// validateConfig reports the first mode in cfg that is not a known value
+func validateConfig(op string, cfg *Config) error {
+	if _, err := ConversionModeFromInt(int(cfg.DefaultConversionMode)); err != nil {
+		return configError(op, err)
+	}
+	if _, err := RoundingModeFromInt(int(cfg.DefaultRoundingMode)); err != nil {
+		return configError(op, err)
+	}
+	if _, err := ArithmeticModeFromInt(int(cfg.DefaultArithmeticMode)); err != nil {
+		return configError(op, err)
+	}
+	if _, err := SubnormalModeFromInt(int(cfg.SubnormalMode)); err != nil {
+		return configError(op, err)
+	}
+	return nil
+}
+
+// configError relabels an enum decoding error with the operation that
+// rejected the value
+func configError(op string, err error) error {
+	var fe *Float16Error
+	if !errors.As(err, &fe) {
+		return err
+	}
+	return &Float16Error{Op: op, Msg: fe.Msg, Code: fe.Code}
+}
+
+// updateConfig applies edit to a copy of the current configuration and
+// installs the copy, all under one lock, so concurrent updates are not lost
+// and readers see either the old or the new configuration
+func updateConfig(edit func(*Config)) {
+	configMutex.Lock()
+	defer configMutex.Unlock()
+	cfg := *config
+	edit(&cfg)
+	applyConfig(&cfg)
+}
+
+// SetDefaultConversionMode sets Config.DefaultConversionMode and returns the
+// previous value. An unknown mode is an ErrInvalidOperation error and changes
+// nothing.
+func SetDefaultConversionMode(mode ConversionMode) (previous ConversionMode, err error) {
+	if _, err := ConversionModeFromInt(int(mode)); err != nil {
+		return 0, configError("SetDefaultConversionMode", err)
+	}
+	updateConfig(func(c *Config) {
+		previous, c.DefaultConversionMode = c.DefaultConversionMode, mode
+	})
+	return previous, nil
+}
+
+// SetDefaultRounding sets Config.DefaultRoundingMode and returns the
+// previous value. An unknown mode is an ErrInvalidOperation error and changes
+// nothing.
+func SetDefaultRounding(mode RoundingMode) (previous RoundingMode, err error) {
+	if _, err := RoundingModeFromInt(int(mode)); err != nil {
+		return 0, configError("SetDefaultRounding", err)
+	}
+	updateConfig(func(c *Config) {
+		previous, c.DefaultRoundingMode = c.DefaultRoundingMode, mode
+	})
+	return previous, nil
+}
+
+// SetDefaultArithmeticMode sets Config.DefaultArithmeticMode and returns the
+// previous value. An unknown mode is an ErrInvalidOperation error and changes
+// nothing.
+func SetDefaultArithmeticMode(mode ArithmeticMode) (previous ArithmeticMode, err error) {
+	if _, err := ArithmeticModeFromInt(int(mode)); err != nil {
+		return 0, configError("SetDefaultArithmeticMode", err)
+	}
+	updateConfig(func(c *Config) {
+		previous, c.DefaultArithmeticMode = c.DefaultArithmeticMode, mode
+	})
+	return previous, nil
+}
+
+// SetSubnormalMode sets Config.SubnormalMode and returns the previous value.
+// An unknown mode is an ErrInvalidOperation error and changes nothing.
+func SetSubnormalMode(mode SubnormalMode) (previous SubnormalMode, err error) {
+	if _, err := SubnormalModeFromInt(int(mode)); err != nil {
+		return 0, configError("SetSubnormalMode", err)
+	}
+	updateConfig(func(c *Config) {
+		previous, c.SubnormalMode = c.SubnormalMode, mode
+	})
+	return previous, nil
+}
+
+// FlushToZeroActive reports whether Config.SubnormalMode is
+// SubnormalFlushToZero, that is whether the emulated target flushes
+// subnormals. The package itself never does.
+func FlushToZeroActive() bool {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return config.SubnormalMode == SubnormalFlushToZero
+}
+
+// SubnormalsSupported reports whether the emulated target described by
+// Config.SubnormalMode keeps subnormal values, that is whether
+// FlushToZeroActive is false
+func SubnormalsSupported() bool {
+	return !FlushToZeroActive()
+}
+
+// SetDebugChecks sets Config.DebugChecks and returns the previous value
+func SetDebugChecks(enabled bool) (previous bool) {
+	updateConfig(func(c *Config) {
+		previous, c.DebugChecks = c.DebugChecks, enabled
+	})
+	return previous
+}
+
+// WithTemporaryConfig applies cfg with Configure, runs fn, and restores the
+// previous configuration, also when fn panics. It does not serialize with
+// other configuration changes made while fn runs; the restore overwrites
+// them.
+func WithTemporaryConfig(cfg *Config, fn func()) {
+	previous := GetConfig()
+	Configure(cfg)
+	defer Configure(previous)
+	fn()
 }
 
 // GetVersion returns the package version string
@@ -158,12 +352,13 @@ func Zero() Float16 {
 
 // One returns a Float16 value representing 1.0
 func One() Float16 {
-	return FromFloat32(1.0)
+	return One16
 }
 
-// NaN returns a Float16 quiet NaN value
+// NaN returns the NaN value produced by operations, QuietNaN unless
+// Config.DefaultNaN selects another
 func NaN() Float16 {
-	return QuietNaN
+	return defaultNaN()
 }
 
 // Inf returns a Float16 infinity value
@@ -199,7 +394,7 @@ func Signbit(f Float16) bool {
 // NextAfter returns the next representable Float16 value after f in the direction of g
 func NextAfter(f, g Float16) Float16 {
 	if f.IsNaN() || g.IsNaN() {
-		return QuietNaN
+		return defaultNaN()
 	}
 
 	if Equal(f, g) {
@@ -223,6 +418,29 @@ func NextAfter(f, g Float16) Float16 {
 	return FromBits(bits)
 }
 
+// DistinguishableDelta returns the smallest positive Float16 d such that
+// Add(f, d) != f under the current arithmetic settings. Because the sum is
+// rounded, d is about half the spacing of Float16 values at f rather than the
+// full spacing, and near MaxValue it is the amount needed to round up to
+// infinity. It returns NaN for NaN and infinite f, which no finite d changes.
+func DistinguishableDelta(f Float16) Float16 {
+	if f.IsNaN() || f.IsInf(0) {
+		return defaultNaN()
+	}
+
+	// Add(f, d) != f is monotone in d for d > 0; +Inf always differs.
+	lo, hi := uint16(SmallestSubnormal), uint16(PositiveInfinity)
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if Add(f, Float16(mid)) != f {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return Float16(lo)
+}
+
 // Frexp breaks f into a normalized fraction and an integral power of two
 // It returns frac and exp satisfying f == frac × 2^exp, with the absolute
 // value of frac in the interval [0.5, 1) or zero
@@ -276,6 +494,16 @@ func IsSubnormal(f Float16) bool {
 	return f.IsSubnormal()
 }
 
+// IsNegativeZero reports whether f is negative zero, as opposed to +0
+func IsNegativeZero(f Float16) bool {
+	return f.IsNegativeZero()
+}
+
+// IsPositiveZero reports whether f is positive zero, as opposed to -0
+func IsPositiveZero(f Float16) bool {
+	return f.IsPositiveZero()
+}
+
 // FpClassify returns the IEEE 754 class of f
 func FpClassify(f Float16) FloatClass {
 	return f.Class()
@@ -300,12 +528,23 @@ func DebugInfo() map[string]interface{} {
 		"default_rounding_mode":   cfg.DefaultRoundingMode,
 		"default_arithmetic_mode": cfg.DefaultArithmeticMode,
 		"fast_math_enabled":       cfg.EnableFastMath,
+		"metrics_enabled":         cfg.Metrics != nil,
+		"default_nan":             cfg.DefaultNaN,
 		"ieee754_compliant":       true,
-		"supports_subnormals":     true,
+		"supports_subnormals":     cfg.SubnormalMode != SubnormalFlushToZero,
 		"lookup_tables":           false,
+		"cpu_arch":                runtime.GOARCH,
+		"cpu_count":               runtime.NumCPU(),
+		"cpu_features":            append([]string(nil), cpuFeatures...),
+		"self_test":               lastSelfTestStatus(),
 	}
 }
 
+// cpuFeatures lists the detected CPU extensions relevant to future SIMD
+// paths, such as "f16c" or "avx512fp16", so that performance reports say
+// what hardware they ran on. Detection runs once at startup.
+var cpuFeatures = detectCPUFeatures()
+
 // Benchmark helpers for performance testing
 
 // BenchmarkOperation represents a benchmarkable operation
@@ -322,20 +561,20 @@ func GetBenchmarkOperations() map[string]BenchmarkOperation {
 }
 
 // Constants for common values
-var (
+const (
 	// Common integer values
-	Zero16  = PositiveZero
-	One16   = FromFloat32(1.0)
-	Two16   = FromFloat32(2.0)
-	Three16 = FromFloat32(3.0)
-	Four16  = FromFloat32(4.0)
-	Five16  = FromFloat32(5.0)
-	Ten16   = FromFloat32(10.0)
+	Zero16          = PositiveZero
+	One16   Float16 = 0x3C00
+	Two16   Float16 = 0x4000
+	Three16 Float16 = 0x4200
+	Four16  Float16 = 0x4400
+	Five16  Float16 = 0x4500
+	Ten16   Float16 = 0x4900
 
 	// Common fractional values
-	Half16    = FromFloat32(0.5)
-	Quarter16 = FromFloat32(0.25)
-	Third16   = FromFloat32(1.0 / 3.0)
+	Half16    Float16 = 0x3800
+	Quarter16 Float16 = 0x3400
+	Third16   Float16 = 0x3555 // nearest to 1/3
 
 	// Special mathematical values
 	NaN16  = QuietNaN
@@ -343,8 +582,8 @@ var (
 	NegInf = NegativeInfinity
 
 	// Commonly used constants
-	Deg2Rad = FromFloat32(float32(math.Pi / 180.0)) // Degrees to radians
-	Rad2Deg = FromFloat32(float32(180.0 / math.Pi)) // Radians to degrees
+	Deg2Rad Float16 = 0x2478 // Degrees to radians, nearest to π/180
+	Rad2Deg Float16 = 0x5329 // Radians to degrees, nearest to 180/π
 )
 
 // Helper functions for slice operations with error handling
@@ -1,6 +1,9 @@
 package float16
 
 import (
+	"errors"
+	"math"
+	"sync"
 	"testing"
 )
 
@@ -161,6 +164,36 @@ func TestIsSubnormal(t *testing.T) {
 	}
 }
 
+func TestSignedZeroPredicates(t *testing.T) {
+	tests := []struct {
+		f                Float16
+		negZero, posZero bool
+	}{
+		{PositiveZero, false, true},
+		{NegativeZero, true, false},
+		{One16, false, false},
+		{One16.Neg(), false, false},
+		{SmallestSubnormal, false, false},
+		{SmallestSubnormal.Neg(), false, false},
+		{NegativeInfinity, false, false},
+		{NegativeQNaN, false, false},
+	}
+	for _, tt := range tests {
+		if got := tt.f.IsNegativeZero(); got != tt.negZero {
+			t.Errorf("%#04x.IsNegativeZero() = %v, want %v", tt.f.Bits(), got, tt.negZero)
+		}
+		if got := IsNegativeZero(tt.f); got != tt.negZero {
+			t.Errorf("IsNegativeZero(%#04x) = %v, want %v", tt.f.Bits(), got, tt.negZero)
+		}
+		if got := tt.f.IsPositiveZero(); got != tt.posZero {
+			t.Errorf("%#04x.IsPositiveZero() = %v, want %v", tt.f.Bits(), got, tt.posZero)
+		}
+		if got := IsPositiveZero(tt.f); got != tt.posZero {
+			t.Errorf("IsPositiveZero(%#04x) = %v, want %v", tt.f.Bits(), got, tt.posZero)
+		}
+	}
+}
+
 func TestFpClassify(t *testing.T) {
 	if FpClassify(One()) != ClassPositiveNormal {
 		t.Error("FpClassify(One()) should be ClassPositiveNormal")
@@ -281,3 +314,321 @@ func TestToSlice32Empty(t *testing.T) {
 		t.Errorf("Expected empty slice, got %v", result)
 	}
 }
+
+func TestDistinguishableDelta(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Float16
+		want Float16
+	}{
+		// Half an ULP (2^-11) ties to even at 1.0, so the next value up is needed
+		{"one", One(), NextAfter(FromFloat32(1.0/2048), PositiveInfinity)},
+		{"max value", MaxValue, FromFloat32(16)},
+		{"zero", PositiveZero, SmallestSubnormal},
+		{"negative max", MinValue, FromFloat32(16)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DistinguishableDelta(tt.f)
+			if got != tt.want {
+				t.Errorf("DistinguishableDelta(%v) = %v, want %v", tt.f, got, tt.want)
+			}
+		})
+	}
+
+	for _, f := range []Float16{One(), MaxValue, FromFloat32(1000), FromFloat32(-3), SmallestNormal, LargestSubnormal, NegativeZero} {
+		d := DistinguishableDelta(f)
+		if Add(f, d) == f {
+			t.Errorf("Add(%v, %v) did not change the value", f, d)
+		}
+		if prev := NextAfter(d, PositiveZero); !prev.IsZero() && Add(f, prev) != f {
+			t.Errorf("DistinguishableDelta(%v) = %v is not minimal", f, d)
+		}
+	}
+
+	if d := DistinguishableDelta(MaxValue); !Greater(d, One()) {
+		t.Errorf("delta near MaxValue = %v, expected a large step", d)
+	}
+	for _, f := range []Float16{QuietNaN, PositiveInfinity, NegativeInfinity} {
+		if !DistinguishableDelta(f).IsNaN() {
+			t.Errorf("DistinguishableDelta(%v) should be NaN", f)
+		}
+	}
+}
+
+func TestConfigDefaultNaN(t *testing.T) {
+	original := GetConfig()
+	defer Configure(original)
+
+	custom := FromBits(0x7C01) // signaling NaN with a payload
+	lowPayloadNaN32 := math.Float32frombits(0x7f800001)
+	cfg := GetConfig()
+	cfg.DefaultNaN = custom
+	Configure(cfg)
+
+	produced := map[string]Float16{
+		"Add(+Inf, -Inf)": Add(PositiveInfinity, NegativeInfinity),
+		"Add(NaN, 1)":     Add(QuietNaN, One()),
+		"Mul(0, Inf)":     Mul(PositiveZero, PositiveInfinity),
+		"Div(0, 0)":       Div(PositiveZero, NegativeZero),
+		"Sqrt(-1)":        Sqrt(FromInt(-1)),
+		"Log(-1)":         Log(FromInt(-1)),
+		"Mod(1, 0)":       Mod(One(), PositiveZero),
+		"NextAfter(NaN)":  NextAfter(QuietNaN, One()),
+		"NaN()":           NaN(),
+		// Conversions keep payloads, falling back to the default NaN only
+		// when the high payload bits are all zero
+		"FromFloat32(low payload NaN)":             FromFloat32(lowPayloadNaN32),
+		"FromFloat32WithRounding(low payload NaN)": FromFloat32WithRounding(lowPayloadNaN32, RoundTowardZero),
+	}
+	for name, got := range produced {
+		if got != custom {
+			t.Errorf("%s = %#04x, want %#04x", name, got.Bits(), custom.Bits())
+		}
+	}
+	if got := FromFloat64(math.Float64frombits(0xfff0000000000001)); got != custom|SignMask {
+		t.Errorf("negative NaN conversion = %#04x, want %#04x", got.Bits(), (custom | SignMask).Bits())
+	}
+
+	// A non-NaN value falls back to QuietNaN
+	cfg.DefaultNaN = One()
+	Configure(cfg)
+	if GetConfig().DefaultNaN != QuietNaN || Div(PositiveZero, PositiveZero) != QuietNaN {
+		t.Error("non-NaN DefaultNaN should select QuietNaN")
+	}
+	if cfg.DefaultNaN != One() {
+		t.Errorf("Configure rewrote the caller's DefaultNaN to %#04x", cfg.DefaultNaN.Bits())
+	}
+
+	Configure(original)
+	if Sqrt(FromInt(-4)) != QuietNaN {
+		t.Error("default NaN not restored")
+	}
+}
+
+// These declarations only compile if the values are constants, so no
+// package can reassign them
+const _, _, _, _, _, _, _, _, _, _, _ = E, Pi, Phi, Sqrt2, SqrtE, SqrtPi, SqrtPhi, Ln2, Log2E, Ln10, Log10E
+const _, _, _, _, _, _, _ = Zero16, One16, Two16, Three16, Four16, Five16, Ten16
+const _, _, _, _, _, _, _, _ = Half16, Quarter16, Third16, NaN16, PosInf, NegInf, Deg2Rad, Rad2Deg
+const _, _, _, _, _, _, _ = BFloat16Zero, BFloat16One, BFloat16Two, BFloat16Half, BFloat16E, BFloat16Pi, BFloat16Sqrt2
+
+func TestConstantValues(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Float16
+		want float64
+	}{
+		{"E", E, math.E}, {"Pi", Pi, math.Pi}, {"Phi", Phi, math.Phi},
+		{"Sqrt2", Sqrt2, math.Sqrt2}, {"SqrtE", SqrtE, math.SqrtE}, {"SqrtPi", SqrtPi, math.SqrtPi},
+		{"SqrtPhi", SqrtPhi, math.SqrtPhi}, {"Ln2", Ln2, math.Ln2}, {"Log2E", Log2E, math.Log2E},
+		{"Ln10", Ln10, math.Ln10}, {"Log10E", Log10E, math.Log10E},
+		{"One16", One16, 1}, {"Two16", Two16, 2}, {"Three16", Three16, 3}, {"Four16", Four16, 4},
+		{"Five16", Five16, 5}, {"Ten16", Ten16, 10}, {"Half16", Half16, 0.5},
+		{"Quarter16", Quarter16, 0.25}, {"Third16", Third16, 1.0 / 3},
+		{"Deg2Rad", Deg2Rad, math.Pi / 180}, {"Rad2Deg", Rad2Deg, 180 / math.Pi},
+	}
+	for _, tt := range tests {
+		if want := FromFloat64(tt.want); tt.got != want {
+			t.Errorf("%s = %#04x, want %#04x", tt.name, tt.got.Bits(), want.Bits())
+		}
+	}
+
+	bf := []struct {
+		name string
+		got  BFloat16
+		want float32
+	}{
+		{"BFloat16One", BFloat16One, 1}, {"BFloat16Two", BFloat16Two, 2}, {"BFloat16Half", BFloat16Half, 0.5},
+		{"BFloat16E", BFloat16E, math.E}, {"BFloat16Pi", BFloat16Pi, math.Pi}, {"BFloat16Sqrt2", BFloat16Sqrt2, math.Sqrt2},
+	}
+	for _, tt := range bf {
+		if want := BFloat16FromFloat32(tt.want); tt.got != want {
+			t.Errorf("%s = %#04x, want %#04x", tt.name, uint16(tt.got), uint16(want))
+		}
+	}
+}
+
+// sameSettings compares the value fields of two configurations
+func sameSettings(a, b *Config) bool {
+	return a.DefaultConversionMode == b.DefaultConversionMode && a.DefaultRoundingMode == b.DefaultRoundingMode &&
+		a.DefaultArithmeticMode == b.DefaultArithmeticMode && a.EnableFastMath == b.EnableFastMath &&
+		a.DebugChecks == b.DebugChecks && a.DefaultNaN == b.DefaultNaN && a.SubnormalMode == b.SubnormalMode
+}
+
+// baseConfig is DefaultConfig with the modes reset to their initial values,
+// which DefaultConfig itself takes from the current package defaults
+func baseConfig() *Config {
+	cfg := DefaultConfig()
+	cfg.DefaultConversionMode, cfg.DefaultRoundingMode, cfg.DefaultArithmeticMode = ModeIEEE, RoundNearestEven, ModeIEEEArithmetic
+	return cfg
+}
+
+func TestConfigSetters(t *testing.T) {
+	original := GetConfig()
+	defer Configure(original)
+	Configure(baseConfig())
+
+	if prev, err := SetDefaultRounding(RoundTowardZero); err != nil || prev != RoundNearestEven {
+		t.Errorf("SetDefaultRounding = (%v, %v), want previous RoundNearestEven", prev, err)
+	}
+	if prev, err := SetDefaultConversionMode(ModeStrict); err != nil || prev != ModeIEEE {
+		t.Errorf("SetDefaultConversionMode = (%v, %v), want previous ModeIEEE", prev, err)
+	}
+	if prev, err := SetDefaultArithmeticMode(ModeFastArithmetic); err != nil || prev != ModeIEEEArithmetic {
+		t.Errorf("SetDefaultArithmeticMode = (%v, %v), want previous ModeIEEEArithmetic", prev, err)
+	}
+	if prev := SetDebugChecks(true); prev {
+		t.Error("SetDebugChecks returned previous true")
+	}
+	cfg := GetConfig()
+	if cfg.DefaultRoundingMode != RoundTowardZero || cfg.DefaultConversionMode != ModeStrict ||
+		cfg.DefaultArithmeticMode != ModeFastArithmetic || !cfg.DebugChecks {
+		t.Errorf("setters not applied: %+v", cfg)
+	}
+	if DefaultRoundingMode != RoundTowardZero || DefaultConversionMode != ModeStrict || DefaultArithmeticMode != ModeFastArithmetic {
+		t.Error("setters did not update the package defaults")
+	}
+
+	// Invalid values fail and change nothing
+	before := GetConfig()
+	var fe *Float16Error
+	if _, err := SetDefaultRounding(RoundingMode(99)); !errors.As(err, &fe) || fe.Code != ErrInvalidOperation || fe.Op != "SetDefaultRounding" {
+		t.Errorf("SetDefaultRounding(99) err = %v", err)
+	}
+	if _, err := SetDefaultConversionMode(-1); err == nil {
+		t.Error("SetDefaultConversionMode(-1) succeeded")
+	}
+	if _, err := SetDefaultArithmeticMode(3); err == nil {
+		t.Error("SetDefaultArithmeticMode(3) succeeded")
+	}
+	bad := GetConfig()
+	bad.DefaultRoundingMode = 7
+	bad.DebugChecks = false
+	if _, err := ConfigureChecked(bad); !errors.As(err, &fe) || fe.Op != "ConfigureChecked" {
+		t.Errorf("ConfigureChecked(invalid) err = %v", err)
+	}
+	if _, err := ConfigureChecked(nil); err == nil {
+		t.Error("ConfigureChecked(nil) succeeded")
+	}
+	if after := GetConfig(); !sameSettings(after, before) {
+		t.Errorf("failed updates changed the config: %+v, want %+v", after, before)
+	}
+
+	// ConfigureChecked returns what it replaced, ready for rollback
+	next := GetConfig()
+	next.DefaultRoundingMode = RoundTowardNegative
+	prev, err := ConfigureChecked(next)
+	if err != nil || !sameSettings(prev, before) {
+		t.Errorf("ConfigureChecked previous = (%+v, %v), want %+v", prev, err, before)
+	}
+	if GetConfig().DefaultRoundingMode != RoundTowardNegative {
+		t.Error("ConfigureChecked did not apply the config")
+	}
+}
+
+func TestWithTemporaryConfig(t *testing.T) {
+	original := GetConfig()
+	defer Configure(original)
+	Configure(baseConfig())
+
+	temp := baseConfig()
+	temp.DefaultRoundingMode = RoundTowardPositive
+	ran := false
+	WithTemporaryConfig(temp, func() {
+		ran = GetConfig().DefaultRoundingMode == RoundTowardPositive
+	})
+	if !ran {
+		t.Error("fn did not see the temporary config")
+	}
+	if GetConfig().DefaultRoundingMode != RoundNearestEven {
+		t.Error("config not restored after fn returned")
+	}
+
+	panicked := panics(func() {
+		WithTemporaryConfig(temp, func() { panic("boom") })
+	})
+	if !panicked {
+		t.Error("the panic in fn was swallowed")
+	}
+	if GetConfig().DefaultRoundingMode != RoundNearestEven {
+		t.Error("config not restored after fn panicked")
+	}
+}
+
+func TestConfigConcurrentReaders(t *testing.T) {
+	original := GetConfig()
+	defer Configure(original)
+
+	a, b := baseConfig(), baseConfig()
+	b.DefaultConversionMode, b.DefaultRoundingMode, b.DefaultArithmeticMode = ModeStrict, RoundTowardZero, ModeExactArithmetic
+	Configure(a)
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if c := GetConfig(); !sameSettings(c, a) && !sameSettings(c, b) {
+					t.Errorf("observed a half-applied config: %+v", c)
+					return
+				}
+			}
+		}()
+	}
+	for i := range 2000 {
+		next := a
+		if i%2 == 0 {
+			next = b
+		}
+		if _, err := ConfigureChecked(next); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
+
+func TestSubnormalModeConfig(t *testing.T) {
+	original := GetConfig()
+	defer Configure(original)
+	Configure(baseConfig())
+
+	if FlushToZeroActive() || !SubnormalsSupported() || DebugInfo()["supports_subnormals"] != true {
+		t.Fatal("default config should keep subnormals")
+	}
+
+	if prev, err := SetSubnormalMode(SubnormalFlushToZero); err != nil || prev != SubnormalExact {
+		t.Errorf("SetSubnormalMode = (%v, %v), want previous Exact", prev, err)
+	}
+	if !FlushToZeroActive() || SubnormalsSupported() || DebugInfo()["supports_subnormals"] != false {
+		t.Error("predicates did not follow SetSubnormalMode(FlushToZero)")
+	}
+	// The setting describes the target: the package still keeps subnormals
+	if SmallestSubnormal.ToFloat32() == 0 {
+		t.Error("FlushToZero config changed ToFloat32")
+	}
+
+	if _, err := SetSubnormalMode(2); err == nil || !FlushToZeroActive() {
+		t.Errorf("SetSubnormalMode(2) err = %v, or changed the mode", err)
+	}
+	bad := GetConfig()
+	bad.SubnormalMode = -1
+	if _, err := ConfigureChecked(bad); err == nil {
+		t.Error("ConfigureChecked accepted SubnormalMode -1")
+	}
+
+	cfg := GetConfig()
+	cfg.SubnormalMode = SubnormalExact
+	Configure(cfg)
+	if FlushToZeroActive() || !SubnormalsSupported() || DebugInfo()["supports_subnormals"] != true {
+		t.Error("predicates did not follow Configure back to Exact")
+	}
+}
@@ -655,10 +655,7 @@ func BenchmarkMul(b *testing.B) {
 }
 
 func BenchmarkToSlice32(b *testing.B) {
-	input := make([]Float16, 1000)
-	for i := range input {
-		input[i] = FromFloat32(float32(i) * 0.1)
-	}
+	input := GenerateBenchData(1000, ProfileMixedSpecial)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -667,13 +664,9 @@ func BenchmarkToSlice32(b *testing.B) {
 }
 
 func BenchmarkDotProduct(b *testing.B) {
-	size := 1000
-	a := make([]Float16, size)
-	c := make([]Float16, size)
-	for i := range a {
-		a[i] = FromFloat32(float32(i) * 0.1)
-		c[i] = FromFloat32(float32(i) * 0.2)
-	}
+	const size = 1000
+	data := GenerateBenchData(2*size, ProfileIntegerHeavy)
+	a, c := data[:size], data[size:]
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -848,3 +841,11 @@ func TestComputeSliceStats(t *testing.T) {
 		}
 	})
 }
+
+func BenchmarkDiv(b *testing.B) {
+	a := FromFloat32(1.5)
+	c := FromFloat32(2.5)
+	for i := 0; i < b.N; i++ {
+		_ = Div(a, c)
+	}
+}
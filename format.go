@@ -0,0 +1,357 @@
+package float16
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Decimal formatting
+
+// maxRoundTripDigits is the number of significant decimal digits that always
+// suffices to recover a Float16 from its decimal form: ceil(1 + 11·log10(2)).
+const maxRoundTripDigits = 5
+
+// MaxRoundTripDigits returns the number of significant decimal digits that is
+// sufficient for every finite Float16 to survive a format/parse round trip.
+func MaxRoundTripDigits() int {
+	return maxRoundTripDigits
+}
+
+// DecimalDigitsNeeded returns the smallest number of significant decimal
+// digits d such that formatting f with d digits and parsing the result with
+// correct rounding yields f again. The result is between 1 and
+// MaxRoundTripDigits for finite values; it is 0 for NaN and infinities, whose
+// text forms are not numeric.
+func DecimalDigitsNeeded(f Float16) int {
+	if !f.IsFinite() {
+		return 0
+	}
+	if f.IsZero() {
+		return 1
+	}
+	v := f.ToFloat64()
+	for d := 1; d < maxRoundTripDigits; d++ {
+		if parseDecimalExact(strconv.FormatFloat(v, 'e', d-1, 64)) == f {
+			return d
+		}
+	}
+	return maxRoundTripDigits
+}
+
+// FormatFloat converts f to a string in the style of strconv.FormatFloat,
+// using the format byte fmt ('e', 'E', 'f', 'g', 'G', ...) and precision prec.
+// A prec of -1 gives the shortest representation of f's exact float32 value,
+// as strconv does for float32. A prec of -2 gives the shortest decimal that
+// parses back to f itself, using DecimalDigitsNeeded significant digits; this
+// is often much shorter, for example "0.1" rather than "0.099975586".
+func FormatFloat(f Float16, fmt byte, prec int) string {
+	if prec != -2 || !f.IsFinite() {
+		return strconv.FormatFloat(f.ToFloat64(), fmt, prec, 32)
+	}
+
+	// The shortest float64 form of a value with at most five significant
+	// digits reproduces exactly those digits in any format.
+	short := strconv.FormatFloat(f.ToFloat64(), 'e', DecimalDigitsNeeded(f)-1, 64)
+	v, _ := strconv.ParseFloat(short, 64)
+	return strconv.FormatFloat(v, fmt, -1, 64)
+}
+
+// parseDecimalExact converts a decimal string to the nearest Float16 with a
+// single rounding. The string must be a valid finite decimal.
+func parseDecimalExact(s string) Float16 {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return defaultNaN()
+	}
+	result := fromRatRoundToOdd(r)
+	if len(s) > 0 && s[0] == '-' && result.IsZero() {
+		result = NegativeZero
+	}
+	return result
+}
+
+// DebugString returns a lossless text form of f for logs and test failures.
+// Unlike String it distinguishes every bit pattern: NaNs print their sign and
+// 10-bit payload as "NaN(0x200)" or "-NaN(0x001)", infinities print as "+Inf"
+// and "-Inf", negative zero prints as "-0", and finite values print as the
+// shortest decimal that round-trips. ParseDebugString reverses it.
+func (f Float16) DebugString() string {
+	switch {
+	case f.IsNaN():
+		sign := ""
+		if f.Signbit() {
+			sign = "-"
+		}
+		return fmt.Sprintf("%sNaN(0x%03x)", sign, uint16(f&MantissaMask))
+	case f.IsInf(0):
+		return f.String()
+	}
+	return FormatFloat(f, 'g', -2)
+}
+
+// DyadicString returns the exact value of f as a fraction in lowest terms
+// whose denominator is a power of two, such as "3/2" or "-1/1024". Integers
+// print without a denominator, and powers of two add their exponent, as in
+// "1 (=2^0)" and "1024 (=2^10)". Zeros print as "0" and "-0", and NaN and
+// infinities print as String does.
+func (f Float16) DyadicString() string {
+	if !f.IsFinite() {
+		return f.String()
+	}
+	sign := ""
+	if f.Signbit() {
+		sign = "-"
+	}
+	if f.IsZero() {
+		return sign + "0"
+	}
+
+	// f = m · 2^e with an integer significand m
+	m, e := uint32(f&MantissaMask), int(f&ExponentMask>>MantissaLen)
+	if e == 0 {
+		e = 1
+	} else {
+		m |= 1 << MantissaLen
+	}
+	e -= ExponentBias + MantissaLen
+	tz := bits.TrailingZeros32(m)
+	m >>= tz
+	e += tz
+
+	switch {
+	case e < 0:
+		return fmt.Sprintf("%s%d/%d", sign, m, uint32(1)<<-e)
+	case m == 1:
+		return fmt.Sprintf("%s%d (=2^%d)", sign, m<<e, e)
+	}
+	return fmt.Sprintf("%s%d", sign, m<<e)
+}
+
+// ParseDebugString parses the output of Float16.DebugString, restoring NaN
+// payloads and signed zeros exactly. Plain decimal input is also accepted and
+// rounded to nearest.
+func ParseDebugString(s string) (Float16, error) {
+	body, sign := s, Float16(0)
+	if strings.HasPrefix(body, "-") {
+		body, sign = body[1:], SignMask
+	} else if strings.HasPrefix(body, "+") {
+		body = body[1:]
+	}
+
+	switch {
+	case body == "Inf":
+		return PositiveInfinity | sign, nil
+	case strings.HasPrefix(body, "NaN(0x") && strings.HasSuffix(body, ")"):
+		payload, err := strconv.ParseUint(body[len("NaN(0x"):len(body)-1], 16, 16)
+		if err != nil || payload == 0 || payload > uint64(MantissaMask) {
+			return 0, &Float16Error{
+				Op:   "ParseDebugString",
+				Msg:  fmt.Sprintf("invalid NaN payload in %q", s),
+				Code: ErrInvalidOperation,
+			}
+		}
+		return PositiveInfinity | Float16(payload) | sign, nil
+	}
+
+	if _, err := strconv.ParseFloat(body, 64); err != nil || !isDecimal(body) {
+		return 0, &Float16Error{
+			Op:   "ParseDebugString",
+			Msg:  fmt.Sprintf("invalid syntax %q", s),
+			Code: ErrInvalidOperation,
+		}
+	}
+	return parseDecimalExact(body) | sign, nil
+}
+
+// isDecimal reports whether s is an unsigned decimal literal, ruling out the
+// "inf", "nan", hexadecimal and underscore spellings that strconv accepts
+func isDecimal(s string) bool {
+	if s == "" || (s[0] != '.' && (s[0] < '0' || s[0] > '9')) {
+		return false
+	}
+	return !strings.ContainsFunc(s, func(r rune) bool {
+		return !strings.ContainsRune("0123456789.eE+-", r)
+	})
+}
+
+// Text streams
+
+// ReadAllText reads whitespace-separated numbers from r and parses each token
+// with Parse, so NaN, Inf, +Inf and -Inf are accepted alongside decimals.
+// There is no comment syntax: every token must be a number. It returns all
+// values, or nil and the first error, which names the 1-based token index and
+// line of an unparsable token. ReadSlice is the binary counterpart.
+func ReadAllText(r io.Reader) ([]Float16, error) {
+	br := bufio.NewReader(r)
+	var values []Float16
+	var token strings.Builder
+	line, tokenLine := 1, 1
+
+	flush := func() error {
+		if token.Len() == 0 {
+			return nil
+		}
+		v, err := Parse(token.String())
+		if err != nil {
+			return &Float16Error{
+				Op:   "ReadAllText",
+				Msg:  fmt.Sprintf("token %d (line %d): invalid syntax %q", len(values)+1, tokenLine, token.String()),
+				Code: ErrInvalidOperation,
+			}
+		}
+		values = append(values, v)
+		token.Reset()
+		return nil
+	}
+
+	for {
+		c, _, err := br.ReadRune()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("float16 ReadAllText: %w", err)
+		}
+		if !unicode.IsSpace(c) {
+			if token.Len() == 0 {
+				tokenLine = line
+			}
+			token.WriteRune(c)
+			continue
+		}
+		if err := flush(); err != nil {
+			return nil, err
+		}
+		if c == '\n' {
+			line++
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// Units
+
+// unitExponents maps the suffixes accepted by ParseWithUnits to the power of
+// ten they scale by
+var unitExponents = map[string]int{
+	"%": -2,
+	"n": -9,
+	"µ": -6, // micro sign
+	"μ": -6, // Greek small letter mu
+	"u": -6,
+	"m": -3,
+	"k": 3,
+	"M": 6,
+}
+
+// ParseWithUnits parses a decimal number with an optional unit suffix: "%"
+// or one of the SI prefixes n, µ (or u), m, k and M, as in "12.5%" or
+// "1.5k". The scaling by a power of ten is exact and the scaled value is
+// rounded once to nearest even, so "50%" is exactly 0.5. Values past the
+// Float16 range become infinite as in Parse; use ParseWithUnitsMode for
+// ModeStrict. NaN and the infinities are accepted without a suffix. Syntax
+// errors give the byte offset of the first character that could not be
+// parsed.
+func ParseWithUnits(s string) (Float16, error) {
+	return ParseWithUnitsMode(s, ModeIEEE)
+}
+
+// ParseWithUnitsMode is ParseWithUnits with a conversion mode. In ModeStrict
+// it returns an error with code ErrOverflow, ErrUnderflow, ErrNaN or
+// ErrInfinity where FromFloat64WithMode would.
+func ParseWithUnitsMode(s string, mode ConversionMode) (Float16, error) {
+	strictError := func(msg string, code ErrorCode) error {
+		return &Float16Error{Op: "ParseWithUnits", Msg: msg, Code: code}
+	}
+	if f, err := Parse(s); err == nil && !f.IsFinite() {
+		switch {
+		case mode == ModeStrict && f.IsNaN():
+			return 0, strictError("NaN in strict mode", ErrNaN)
+		case mode == ModeStrict:
+			return 0, strictError("infinity in strict mode", ErrInfinity)
+		}
+		return f, nil
+	}
+
+	n := strings.IndexFunc(s, func(r rune) bool { return !strings.ContainsRune("0123456789.eE+-", r) })
+	if n < 0 {
+		n = len(s)
+	}
+	number, suffix := s[:n], s[n:]
+	exp, known := unitExponents[suffix]
+	body, negative := strings.TrimPrefix(number, "+"), strings.HasPrefix(number, "-")
+	if negative {
+		body = number[1:]
+	}
+	r, ok := new(big.Rat).SetString(body)
+	if !isDecimal(body) || !ok || (suffix != "" && !known) {
+		// Point at the number if it is malformed, otherwise just past the
+		// longest known unit that starts the suffix
+		offset := 0
+		if isDecimal(body) && ok {
+			offset = n
+			for unit := range unitExponents {
+				if strings.HasPrefix(suffix, unit) && n+len(unit) > offset {
+					offset = n + len(unit)
+				}
+			}
+		}
+		return 0, &Float16Error{
+			Op:   "ParseWithUnits",
+			Msg:  fmt.Sprintf("invalid syntax at offset %d in %q", offset, s),
+			Code: ErrInvalidOperation,
+		}
+	}
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(max(exp, -exp))), nil)
+	if exp >= 0 {
+		r.Mul(r, new(big.Rat).SetInt(pow))
+	} else {
+		r.Quo(r, new(big.Rat).SetInt(pow))
+	}
+
+	var sign Float16
+	if negative {
+		sign = SignMask
+	}
+	switch {
+	case r.Sign() == 0:
+		return sign, nil
+	case mode == ModeStrict && r.Cmp(big.NewRat(65504, 1)) > 0:
+		return 0, strictError("overflow", ErrOverflow)
+	case r.Cmp(big.NewRat(65520, 1)) >= 0:
+		return PositiveInfinity | sign, nil
+	}
+	result := fromRatRoundToOdd(r) | sign
+	if mode == ModeStrict && (result.IsZero() || result.IsSubnormal()) {
+		return 0, strictError("underflow", ErrUnderflow)
+	}
+	return result, nil
+}
+
+// FormatPercent formats f as a percentage with prec digits after the decimal
+// point, as in "12.50%". A negative prec gives the fewest digits that parse
+// back to f exactly with ParseWithUnits. NaN and the infinities format as
+// "NaN%", "+Inf%" and "-Inf%".
+func FormatPercent(f Float16, prec int) string {
+	if prec >= 0 || !f.IsFinite() {
+		// f·100 is exact in float64
+		return strconv.FormatFloat(f.ToFloat64()*100, 'f', prec, 64) + "%"
+	}
+
+	// The shortest digits of f, as FormatFloat with prec -2 picks them, with
+	// the decimal exponent moved by two rather than multiplied in binary
+	short := strconv.FormatFloat(f.ToFloat64(), 'e', DecimalDigitsNeeded(f)-1, 64)
+	mantissa, exp, _ := strings.Cut(short, "e")
+	e, _ := strconv.Atoi(exp)
+	v, _ := strconv.ParseFloat(mantissa+"e"+strconv.Itoa(e+2), 64)
+	return strconv.FormatFloat(v, 'f', -1, 64) + "%"
+}
@@ -0,0 +1,324 @@
+package float16
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestDecimalDigitsNeeded(t *testing.T) {
+	counts := make(map[int]int)
+	for i := 0; i < 1<<16; i++ {
+		f := Float16(i)
+		if !f.IsFinite() {
+			if d := DecimalDigitsNeeded(f); d != 0 {
+				t.Fatalf("DecimalDigitsNeeded(%v) = %d, want 0", f, d)
+			}
+			continue
+		}
+		d := DecimalDigitsNeeded(f)
+		if d < 1 || d > MaxRoundTripDigits() {
+			t.Fatalf("DecimalDigitsNeeded(%#04x) = %d out of range", i, d)
+		}
+		counts[d]++
+
+		v := f.ToFloat64()
+		if got := parseDecimalExact(strconv.FormatFloat(v, 'e', d-1, 64)); got != f {
+			t.Fatalf("%#04x: %d digits did not round-trip (got %#04x)", i, d, got.Bits())
+		}
+		if d > 1 {
+			if got := parseDecimalExact(strconv.FormatFloat(v, 'e', d-2, 64)); got == f {
+				t.Fatalf("%#04x: %d digits are not minimal", i, d)
+			}
+		}
+	}
+	if counts[MaxRoundTripDigits()] == 0 {
+		t.Error("expected some values to need the maximum number of digits")
+	}
+	if MaxRoundTripDigits() != 5 {
+		t.Errorf("MaxRoundTripDigits() = %d, want 5", MaxRoundTripDigits())
+	}
+}
+
+func TestFormatFloat(t *testing.T) {
+	tests := []struct {
+		f    Float16
+		fmt  byte
+		prec int
+		want string
+	}{
+		{FromFloat32(0.1), 'g', -2, "0.1"},
+		{FromFloat32(0.1), 'g', -1, "0.099975586"},
+		{FromFloat32(0.1), 'e', -2, "1e-01"},
+		{FromFloat32(-1234.5), 'f', -2, "-1234"},
+		{MaxValue, 'g', -2, "65500"},
+		{SmallestSubnormal, 'g', -2, "6e-08"},
+		{FromFloat32(1.5), 'f', 3, "1.500"},
+		{NegativeZero, 'g', -2, "-0"},
+		{PositiveInfinity, 'g', -2, "+Inf"},
+		{QuietNaN, 'f', -2, "NaN"},
+	}
+	for _, tt := range tests {
+		if got := FormatFloat(tt.f, tt.fmt, tt.prec); got != tt.want {
+			t.Errorf("FormatFloat(%#04x, %q, %d) = %q, want %q", tt.f.Bits(), tt.fmt, tt.prec, got, tt.want)
+		}
+	}
+
+	// Minimal output always parses back to the original value
+	for i := 0; i < 1<<16; i += 3 {
+		f := Float16(i)
+		if !f.IsFinite() {
+			continue
+		}
+		if got := parseDecimalExact(FormatFloat(f, 'g', -2)); got != f {
+			t.Fatalf("FormatFloat(%#04x, 'g', -2) = %q does not round-trip", i, FormatFloat(f, 'g', -2))
+		}
+	}
+}
+
+func TestDebugStringRoundTrip(t *testing.T) {
+	for i := 0; i < 1<<16; i++ {
+		f := Float16(i)
+		s := f.DebugString()
+		got, err := ParseDebugString(s)
+		if err != nil {
+			t.Fatalf("ParseDebugString(%q) error: %v", s, err)
+		}
+		if got != f {
+			t.Fatalf("ParseDebugString(%q) = %#04x, want %#04x", s, got.Bits(), i)
+		}
+	}
+}
+
+func TestDyadicString(t *testing.T) {
+	tests := []struct {
+		in   Float16
+		want string
+	}{
+		{Half16, "1/2"},
+		{FromFloat32(1.5), "3/2"},
+		{SmallestSubnormal, "1/16777216"},
+		{FromFloat32(-0.0009765625), "-1/1024"},
+		{One16, "1 (=2^0)"},
+		{FromFloat32(1024), "1024 (=2^10)"},
+		{Three16, "3"},
+		{MaxValue, "65504"},
+		{PositiveZero, "0"},
+		{NegativeZero, "-0"},
+		{PositiveInfinity, "+Inf"},
+		{QuietNaN, "NaN"},
+	}
+	for _, tt := range tests {
+		if got := tt.in.DyadicString(); got != tt.want {
+			t.Errorf("%v.DyadicString() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	// Every finite value prints its exact value
+	for i := 0; i < 1<<16; i++ {
+		f := Float16(i)
+		if !f.IsFinite() {
+			continue
+		}
+		s, _, _ := strings.Cut(f.DyadicString(), " ")
+		r, ok := new(big.Rat).SetString(s)
+		if !ok {
+			t.Fatalf("%#04x.DyadicString() = %q does not parse", i, s)
+		}
+		if v, _ := r.Float64(); v != f.ToFloat64() {
+			t.Fatalf("%#04x.DyadicString() = %q, want value %v", i, s, f.ToFloat64())
+		}
+	}
+}
+
+func TestDebugString(t *testing.T) {
+	tests := []struct {
+		in   Float16
+		want string
+	}{
+		{QuietNaN, "NaN(0x200)"},
+		{FromBits(0x7C01), "NaN(0x001)"},
+		{FromBits(0xFE5A), "-NaN(0x25a)"},
+		{PositiveInfinity, "+Inf"},
+		{NegativeInfinity, "-Inf"},
+		{NegativeZero, "-0"},
+		{PositiveZero, "0"},
+		{FromFloat32(0.1), "0.1"},
+		{MaxValue, "65500"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.in.DebugString(); got != tt.want {
+				t.Errorf("DebugString(%#04x) = %q, want %q", tt.in.Bits(), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDebugStringErrors(t *testing.T) {
+	for _, s := range []string{"", "NaN", "NaN(0x000)", "NaN(0x400)", "NaN(0xzz)", "inf", "nan", "0x1p-3", "--1", "1_0", "abc"} {
+		if _, err := ParseDebugString(s); err == nil {
+			t.Errorf("ParseDebugString(%q) succeeded, want error", s)
+		}
+	}
+	if got, err := ParseDebugString("+1.5"); err != nil || got != FromFloat32(1.5) {
+		t.Errorf("ParseDebugString(\"+1.5\") = %v, %v", got, err)
+	}
+}
+
+func TestReadAllText(t *testing.T) {
+	input := "1 2.5\t-0.1\n\n  NaN Inf -Inf\r\n+Inf 65504 1e-8\n-0\n"
+	got, err := ReadAllText(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Float16{One16, FromFloat32(2.5), FromFloat32(-0.1), QuietNaN, PositiveInfinity, NegativeInfinity,
+		PositiveInfinity, MaxValue, PositiveZero, NegativeZero}
+	if len(got) != len(want) {
+		t.Fatalf("ReadAllText returned %d values %v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] && !(got[i].IsNaN() && want[i].IsNaN()) {
+			t.Errorf("value %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if got, err := ReadAllText(strings.NewReader(" \n\t")); err != nil || len(got) != 0 {
+		t.Errorf("ReadAllText(blank) = (%v, %v), want no values", got, err)
+	}
+
+	_, err = ReadAllText(strings.NewReader("1 2\n3 # comment\n"))
+	var fe *Float16Error
+	if !errors.As(err, &fe) || fe.Code != ErrInvalidOperation || !strings.Contains(fe.Msg, "token 4 (line 2)") {
+		t.Errorf("ReadAllText with a bad token: err = %v, want token 4 on line 2", err)
+	}
+
+	failing := io.MultiReader(strings.NewReader("1 2 "), iotest.ErrReader(errors.New("disk on fire")))
+	if _, err := ReadAllText(failing); err == nil || !strings.Contains(err.Error(), "disk on fire") {
+		t.Errorf("ReadAllText with a failing reader: err = %v", err)
+	}
+}
+
+func TestParseWithUnits(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Float16
+	}{
+		{"50%", Half16},
+		{"12.5%", FromFloat32(0.125)},
+		{"-250%", FromFloat32(-2.5)},
+		{"1.5k", FromFloat32(1500)},
+		{"65.5k", MaxValue},
+		{"70k", PositiveInfinity},
+		{"-0.07M", NegativeInfinity},
+		{"1m", FromFloat64(0.001)},
+		{"1µ", FromBits(0x0011)}, // 1e-6 = 16.78 · 2^-24
+		{"1μ", FromBits(0x0011)},
+		{"1u", FromBits(0x0011)},
+		{"20n", PositiveZero},
+		{"30n", SmallestSubnormal},
+		{"-0k", NegativeZero},
+		{"3", Three16},
+		{"1e-1%", FromFloat64(0.001)},
+		{"Inf", PositiveInfinity},
+		{"-Inf", NegativeInfinity},
+	}
+	for _, tt := range tests {
+		got, err := ParseWithUnits(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseWithUnits(%q) = (%v, %v), want %v", tt.in, got, err, tt.want)
+		}
+	}
+	if got, err := ParseWithUnits("NaN"); err != nil || !got.IsNaN() {
+		t.Errorf("ParseWithUnits(NaN) = (%v, %v)", got, err)
+	}
+
+	// Scaling is exact: a suffix gives the same result as writing the
+	// exponent out
+	for _, in := range []string{"0.1%", "33.3%", "4.0961k", "0.3m"} {
+		got, _ := ParseWithUnits(in)
+		n := strings.IndexAny(in, "%km")
+		exp := map[byte]string{'%': "e-2", 'k': "e3", 'm': "e-3"}[in[n]]
+		if want, _ := Parse(in[:n] + exp); got != want {
+			t.Errorf("ParseWithUnits(%q) = %v, want %v", in, got, want)
+		}
+	}
+
+	strict := []struct {
+		in   string
+		code ErrorCode
+	}{
+		{"65.5k", -1},
+		{"70k", ErrOverflow},
+		{"1µ", ErrUnderflow},
+		{"Inf", ErrInfinity},
+		{"NaN", ErrNaN},
+	}
+	for _, tt := range strict {
+		_, err := ParseWithUnitsMode(tt.in, ModeStrict)
+		var fe *Float16Error
+		if tt.code < 0 {
+			if err != nil {
+				t.Errorf("ParseWithUnitsMode(%q, strict): %v", tt.in, err)
+			}
+		} else if !errors.As(err, &fe) || fe.Code != tt.code {
+			t.Errorf("ParseWithUnitsMode(%q, strict): err = %v, want code %v", tt.in, err, tt.code)
+		}
+	}
+
+	syntax := []struct {
+		in     string
+		offset int
+	}{
+		{"1.5kk", 4},
+		{"1.5x", 3},
+		{"12 %", 2},
+		{"k", 0},
+		{"1.2.3k", 0},
+		{"%", 0},
+		{"", 0},
+		{"1e%", 0},
+	}
+	for _, tt := range syntax {
+		_, err := ParseWithUnits(tt.in)
+		var fe *Float16Error
+		want := fmt.Sprintf("offset %d", tt.offset)
+		if !errors.As(err, &fe) || fe.Code != ErrInvalidOperation || !strings.Contains(fe.Msg, want) {
+			t.Errorf("ParseWithUnits(%q): err = %v, want syntax error at %s", tt.in, err, want)
+		}
+	}
+}
+
+func TestFormatPercent(t *testing.T) {
+	tests := []struct {
+		f    Float16
+		prec int
+		want string
+	}{
+		{Half16, 1, "50.0%"},
+		{FromFloat32(0.125), 2, "12.50%"},
+		{FromFloat32(0.125), -1, "12.5%"},
+		{FromFloat64(0.1), -1, "10%"}, // 0.0999755859375 exactly
+		{FromFloat64(0.333), -1, "33.3%"},
+		{SmallestSubnormal, -1, "0.000006%"},
+		{NegativeZero, -1, "-0%"},
+		{One16.Neg(), 0, "-100%"},
+		{PositiveInfinity, 1, "+Inf%"},
+	}
+	for _, tt := range tests {
+		if got := FormatPercent(tt.f, tt.prec); got != tt.want {
+			t.Errorf("FormatPercent(%v, %d) = %q, want %q", tt.f, tt.prec, got, tt.want)
+		}
+	}
+	for b := 0; b < 0x7c00; b++ {
+		f := Float16(b)
+		if got, err := ParseWithUnits(FormatPercent(f, -1)); err != nil || got != f {
+			t.Fatalf("ParseWithUnits(FormatPercent(%v, -1) = %q) = (%v, %v)", f, FormatPercent(f, -1), got, err)
+		}
+	}
+}
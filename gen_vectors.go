@@ -0,0 +1,112 @@
+//go:build ignore
+
+// gen_vectors writes vectors/f16c_float32.bin, the float32 to Float16
+// conformance vectors checked by VerifyHardwareCompatibility. Run it with
+// go generate. The expected results come from the integer reference model
+// below, which follows the x86 F16C VCVTPS2PH instruction with
+// round-to-nearest-even, and not from this package's conversion code.
+//
+// Each vector is 6 bytes: the float32 input bits as a little-endian uint32,
+// then the expected Float16 bits as a little-endian uint16.
+package main
+
+import (
+	"encoding/binary"
+	"log"
+	"math/bits"
+	"math/rand"
+	"os"
+)
+
+// f16c converts float32 bits to Float16 bits as VCVTPS2PH does with
+// round-to-nearest-even. NaNs keep their sign and top payload bits and are
+// quieted.
+func f16c(in uint32) uint16 {
+	sign := uint16(in>>16) & 0x8000
+	exp := int(in>>23) & 0xff
+	mant := in & 0x7fffff
+	if exp == 0xff {
+		if mant == 0 {
+			return sign | 0x7c00
+		}
+		return sign | 0x7e00 | uint16(mant>>13)
+	}
+
+	// in = sig·2^e exactly
+	sig, e := uint64(mant), -149
+	if exp != 0 {
+		sig, e = sig|1<<23, exp-150
+	}
+	if sig == 0 {
+		return sign
+	}
+
+	// Round to a multiple of 2^k: 11 significant bits, but never finer
+	// than the subnormal quantum 2^-24
+	k := max(-24, bits.Len64(sig)-1+e-10)
+	var q uint64
+	if e >= k {
+		q = sig << (e - k)
+	} else if s := k - e; s > 40 {
+		q = 0 // far below half a quantum
+	} else {
+		q = sig >> s
+		rem, half := sig&(1<<s-1), uint64(1)<<(s-1)
+		if rem > half || (rem == half && q&1 == 1) {
+			q++
+		}
+	}
+	if q == 0 {
+		return sign
+	}
+	if q == 2048 {
+		q, k = 1024, k+1
+	}
+	if k+25 >= 31 {
+		return sign | 0x7c00
+	}
+	// A subnormal has k = -24 and q < 1024, which this also encodes
+	return sign | uint16((k+25)<<10+int(q)-1024)
+}
+
+func main() {
+	rng := rand.New(rand.NewSource(1))
+	fixed := []uint32{
+		0, 1, 2, 3, 0x0fff, 0x1000, 0x1001, 0x1fff, 0x2000, 0x2001, 0x3000,
+		0x5000, 0x400000, 0x400001, 0x401000, 0x7fe000, 0x7fefff, 0x7ff000,
+		0x7ff001, 0x7fffff,
+	}
+
+	var inputs []uint32
+	for exp := uint32(0); exp <= 0xff; exp++ {
+		mants := append([]uint32(nil), fixed...)
+		for len(mants) < 32 {
+			mants = append(mants, uint32(rng.Intn(1<<23)))
+		}
+		// Midpoints and their neighbors around the Float16 subnormal range,
+		// where the rounding position moves with the exponent
+		if exp >= 100 && exp <= 113 {
+			for t := 0; t < 23; t++ {
+				mants = append(mants, 1<<t-1, 1<<t, 1<<t+1)
+			}
+		}
+		for _, m := range mants {
+			if exp == 0xff && m != 0 {
+				m |= 0x400000 // quiet NaNs only; see VerifyHardwareCompatibility
+			}
+			for _, sign := range []uint32{0, 1 << 31} {
+				inputs = append(inputs, sign|exp<<23|m&0x7fffff)
+			}
+		}
+	}
+
+	out := make([]byte, 0, 6*len(inputs))
+	for _, in := range inputs {
+		out = binary.LittleEndian.AppendUint32(out, in)
+		out = binary.LittleEndian.AppendUint16(out, f16c(in))
+	}
+	if err := os.WriteFile("vectors/f16c_float32.bin", out, 0o644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("wrote %d vectors", len(inputs))
+}
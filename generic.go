@@ -0,0 +1,70 @@
+package float16
+
+// Generic numeric adapters
+//
+// Float16 is a uint16 underneath, so it cannot satisfy constraints such as
+// ~float32 | ~float64. The helpers below convert slices to and from any
+// float-based type, and F wraps a Float16 in a value type with arithmetic
+// methods so that generic code written against a small method set can be
+// instantiated with Float16.
+
+// Float is the constraint satisfied by the native floating-point types
+type Float interface {
+	~float32 | ~float64
+}
+
+// Map converts each element of s to the floating-point type T.
+func Map[T Float](s []Float16) []T {
+	result := make([]T, len(s))
+	for i, v := range s {
+		result[i] = T(v.ToFloat64())
+	}
+	return result
+}
+
+// FromSliceOf converts each element of s to Float16 with the default rounding.
+func FromSliceOf[T Float](s []T) []Float16 {
+	result := make([]Float16, len(s))
+	for i, v := range s {
+		result[i] = FromFloat64(float64(v))
+	}
+	return result
+}
+
+// F is an arithmetic view of a Float16 whose methods return F, allowing
+// method chaining and use with generic algorithms that require a method set
+// such as Add, Mul, Less and FromFloat64.
+type F struct {
+	v Float16
+}
+
+// NewF wraps f in an F.
+func NewF(f Float16) F { return F{v: f} }
+
+// Value returns the wrapped Float16.
+func (x F) Value() Float16 { return x.v }
+
+// Float64 returns the wrapped value as a float64.
+func (x F) Float64() float64 { return x.v.ToFloat64() }
+
+// FromFloat64 returns f converted to an F. The receiver is ignored, which
+// lets generic code construct values from a zero T.
+func (F) FromFloat64(f float64) F { return F{v: FromFloat64(f)} }
+
+// Add returns x + y.
+func (x F) Add(y F) F { return F{v: Add(x.v, y.v)} }
+
+// Sub returns x - y.
+func (x F) Sub(y F) F { return F{v: Sub(x.v, y.v)} }
+
+// Mul returns x * y.
+func (x F) Mul(y F) F { return F{v: Mul(x.v, y.v)} }
+
+// Div returns x / y.
+func (x F) Div(y F) F { return F{v: Div(x.v, y.v)} }
+
+// Less reports whether x < y.
+func (x F) Less(y F) bool { return Less(x.v, y.v) }
+
+// String returns the string form of the wrapped value.
+func (x F) String() string { return x.v.String() }
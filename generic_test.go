@@ -0,0 +1,122 @@
+package float16
+
+import (
+	"math"
+	"testing"
+)
+
+// number is the minimal method set a generic algorithm needs
+type number[T any] interface {
+	Add(T) T
+	Sub(T) T
+	Mul(T) T
+	Div(T) T
+	Less(T) bool
+	FromFloat64(float64) T
+	Float64() float64
+}
+
+// f32 and f64 adapt the native types to the number interface
+type f32 float32
+
+func (x f32) Add(y f32) f32           { return x + y }
+func (x f32) Sub(y f32) f32           { return x - y }
+func (x f32) Mul(y f32) f32           { return x * y }
+func (x f32) Div(y f32) f32           { return x / y }
+func (x f32) Less(y f32) bool         { return x < y }
+func (f32) FromFloat64(f float64) f32 { return f32(f) }
+func (x f32) Float64() float64        { return float64(x) }
+
+type f64 float64
+
+func (x f64) Add(y f64) f64           { return x + y }
+func (x f64) Sub(y f64) f64           { return x - y }
+func (x f64) Mul(y f64) f64           { return x * y }
+func (x f64) Div(y f64) f64           { return x / y }
+func (x f64) Less(y f64) bool         { return x < y }
+func (f64) FromFloat64(f float64) f64 { return f64(f) }
+func (x f64) Float64() float64        { return float64(x) }
+
+// meanVariance is an example generic algorithm over the number interface
+func meanVariance[T number[T]](xs []float64) (mean, variance T) {
+	var zero T
+	sum := zero.FromFloat64(0)
+	for _, x := range xs {
+		sum = sum.Add(zero.FromFloat64(x))
+	}
+	n := zero.FromFloat64(float64(len(xs)))
+	mean = sum.Div(n)
+
+	sq := zero.FromFloat64(0)
+	for _, x := range xs {
+		d := zero.FromFloat64(x).Sub(mean)
+		sq = sq.Add(d.Mul(d))
+	}
+	return mean, sq.Div(n)
+}
+
+func TestGenericMeanVariance(t *testing.T) {
+	xs := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	m32, v32 := meanVariance[f32](xs)
+	m64, v64 := meanVariance[f64](xs)
+	m16, v16 := meanVariance[F](xs)
+
+	for _, c := range []struct {
+		name       string
+		mean, vari float64
+	}{
+		{"float32", m32.Float64(), v32.Float64()},
+		{"float64", m64.Float64(), v64.Float64()},
+		{"Float16", m16.Float64(), v16.Float64()},
+	} {
+		if c.mean != 4.5 || c.vari != 5.25 {
+			t.Errorf("%s: mean, variance = %v, %v, want 4.5, 5.25", c.name, c.mean, c.vari)
+		}
+	}
+}
+
+func TestFMethods(t *testing.T) {
+	x := NewF(FromInt(3))
+	y := NewF(FromInt(4))
+	if got := x.Mul(x).Add(y.Mul(y)).Value(); got != FromInt(25) {
+		t.Errorf("3*3 + 4*4 = %v, want 25", got)
+	}
+	if got := y.Sub(x).Div(y).Value(); got != FromFloat32(0.25) {
+		t.Errorf("(4-3)/4 = %v, want 0.25", got)
+	}
+	if !x.Less(y) || y.Less(x) {
+		t.Error("Less is inconsistent")
+	}
+	if s := x.String(); s != "3" {
+		t.Errorf("String() = %q, want \"3\"", s)
+	}
+}
+
+func TestMapAndFromSliceOf(t *testing.T) {
+	s := []Float16{One(), FromFloat32(-2.5), PositiveInfinity, QuietNaN}
+
+	got32 := Map[float32](s)
+	got64 := Map[float64](s)
+	for i, v := range s {
+		want := v.ToFloat64()
+		if math.IsNaN(want) {
+			if !math.IsNaN(float64(got32[i])) || !math.IsNaN(got64[i]) {
+				t.Errorf("element %d: expected NaN", i)
+			}
+			continue
+		}
+		if float64(got32[i]) != want || got64[i] != want {
+			t.Errorf("element %d: got %v / %v, want %v", i, got32[i], got64[i], want)
+		}
+	}
+
+	type celsius float32
+	back := FromSliceOf([]celsius{1, -2.5, 0.1})
+	want := []Float16{One(), FromFloat32(-2.5), FromFloat32(0.1)}
+	for i := range want {
+		if back[i] != want[i] {
+			t.Errorf("FromSliceOf()[%d] = %v, want %v", i, back[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package float16
+
+import (
+	_ "embed"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Hardware conformance
+
+//go:generate go run gen_vectors.go
+
+// hardwareVectors holds the float32 to Float16 conformance vectors: a
+// little-endian uint32 input followed by the expected uint16 result, 6 bytes
+// per vector. gen_vectors.go derives them from a reference model of x86 F16C
+// VCVTPS2PH with round to nearest even, covering every float32 exponent.
+//
+//go:embed vectors/f16c_float32.bin
+var hardwareVectors []byte
+
+// hardwareVectorSize is the length of one encoded vector
+const hardwareVectorSize = 6
+
+// VerifyHardwareCompatibility checks FromFloat32 and ToFloat16 against the
+// embedded conformance vectors, which reproduce the results of x86 F16C
+// (VCVTPS2PH) with round to nearest even; NVIDIA's __float2half_rn rounds
+// every non-NaN input the same way. It is cheap enough for a startup
+// self-check and returns an error describing the first mismatch and the
+// total count.
+//
+// The vectors contain quiet NaNs only: this package keeps a signaling NaN
+// signaling when narrowing it, where F16C quiets it.
+func VerifyHardwareCompatibility() error {
+	var mismatches int
+	var first string
+	for i := 0; i+hardwareVectorSize <= len(hardwareVectors); i += hardwareVectorSize {
+		in := binary.LittleEndian.Uint32(hardwareVectors[i:])
+		want := Float16(binary.LittleEndian.Uint16(hardwareVectors[i+4:]))
+		f32 := math.Float32frombits(in)
+		for _, got := range [...]Float16{FromFloat32(f32), ToFloat16(float64(f32))} {
+			if got != want {
+				if mismatches == 0 {
+					first = fmt.Sprintf("float32 bits 0x%08x gave 0x%04x, want 0x%04x", in, uint16(got), uint16(want))
+				}
+				mismatches++
+			}
+		}
+	}
+	if mismatches > 0 {
+		return &Float16Error{
+			Op:   "VerifyHardwareCompatibility",
+			Msg:  fmt.Sprintf("%d mismatches; first: %s", mismatches, first),
+			Code: ErrInvalidOperation,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package float16
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestHardwareVectors(t *testing.T) {
+	n := len(hardwareVectors) / hardwareVectorSize
+	if n < 10000 || len(hardwareVectors)%hardwareVectorSize != 0 {
+		t.Fatalf("%d bytes of vectors, want at least 10000 whole vectors", len(hardwareVectors))
+	}
+
+	var exponents [256]int
+	failures := 0
+	for i := 0; i < n; i++ {
+		in := binary.LittleEndian.Uint32(hardwareVectors[i*hardwareVectorSize:])
+		want := Float16(binary.LittleEndian.Uint16(hardwareVectors[i*hardwareVectorSize+4:]))
+		exponents[in>>23&0xff]++
+
+		f32 := math.Float32frombits(in)
+		if got := FromFloat32(f32); got != want {
+			failures++
+			if failures <= 10 {
+				t.Errorf("FromFloat32(bits 0x%08x = %g) = 0x%04x, want 0x%04x", in, f32, got.Bits(), want.Bits())
+			}
+		}
+		if got := ToFloat16(float64(f32)); got != want {
+			failures++
+			if failures <= 10 {
+				t.Errorf("ToFloat16(%g) = 0x%04x, want 0x%04x", f32, got.Bits(), want.Bits())
+			}
+		}
+		if f32 == f32 {
+			if got := ToSlice16Mode([]float32{f32}, false)[0]; got != want {
+				failures++
+				if failures <= 10 {
+					t.Errorf("ToSlice16Mode(%g, false) = 0x%04x, want 0x%04x", f32, got.Bits(), want.Bits())
+				}
+			}
+		}
+	}
+	if failures > 10 {
+		t.Errorf("%d mismatches in total", failures)
+	}
+	for exp, count := range exponents {
+		if count == 0 {
+			t.Errorf("no vectors with float32 exponent %d", exp)
+		}
+	}
+
+	if err := VerifyHardwareCompatibility(); err != nil {
+		t.Errorf("VerifyHardwareCompatibility: %v", err)
+	}
+}
+
+func TestVerifyHardwareCompatibilityReportsMismatch(t *testing.T) {
+	saved := hardwareVectors
+	defer func() { hardwareVectors = saved }()
+
+	// 1.0 claimed to convert to 2.0
+	bad := binary.LittleEndian.AppendUint32(nil, math.Float32bits(1))
+	hardwareVectors = binary.LittleEndian.AppendUint16(bad, uint16(Two16))
+	var fe *Float16Error
+	err := VerifyHardwareCompatibility()
+	if err == nil {
+		t.Fatal("VerifyHardwareCompatibility accepted a wrong vector")
+	}
+	if !errors.As(err, &fe) || fe.Code != ErrInvalidOperation {
+		t.Errorf("err = %v, want ErrInvalidOperation", err)
+	}
+}
@@ -0,0 +1,130 @@
+package float16
+
+import "math/bits"
+
+// Slice hashing for tensor fingerprinting
+//
+// HashSlice and HashSliceBits compute the 64-bit XXH64 digest of the
+// little-endian byte encoding of a Float16 slice. The byte stream is assembled
+// arithmetically from the 16-bit patterns, so the digest does not depend on the
+// host byte order. The algorithm, the canonicalization rules and the
+// little-endian encoding are part of the package API: a given (slice, seed)
+// pair hashes to the same value across releases and platforms.
+
+// XXH64 primes
+const (
+	xxhPrime1 uint64 = 11400714785074694791
+	xxhPrime2 uint64 = 14029467366897019727
+	xxhPrime3 uint64 = 1609587929392839161
+	xxhPrime4 uint64 = 9650029242287828579
+	xxhPrime5 uint64 = 2870177450012600261
+)
+
+// HashSlice returns a digest of s that treats numerically equal slices equally.
+// Before hashing, negative zero is mapped to positive zero and every NaN
+// (regardless of sign or payload) is mapped to QuietNaN.
+func HashSlice(s []Float16, seed uint64) uint64 {
+	return xxh64Float16(s, seed, canonicalHashBits)
+}
+
+// HashSliceBits returns a digest of the raw bit patterns of s. Slices that
+// differ only in the sign of zero or in NaN payloads hash differently.
+func HashSliceBits(s []Float16, seed uint64) uint64 {
+	return xxh64Float16(s, seed, rawHashBits)
+}
+
+// canonicalHashBits maps values that compare numerically equal to one bit pattern
+func canonicalHashBits(f Float16) uint16 {
+	if f.IsNaN() {
+		return uint16(QuietNaN)
+	}
+	if f.IsZero() {
+		return uint16(PositiveZero)
+	}
+	return uint16(f)
+}
+
+// rawHashBits returns the bit pattern unchanged
+func rawHashBits(f Float16) uint16 {
+	return uint16(f)
+}
+
+// load64 assembles 4 consecutive values into a little-endian 64-bit word
+func load64(s []Float16, key func(Float16) uint16) uint64 {
+	return uint64(key(s[0])) | uint64(key(s[1]))<<16 | uint64(key(s[2]))<<32 | uint64(key(s[3]))<<48
+}
+
+// xxh64Float16 runs XXH64 over the little-endian encoding of s
+func xxh64Float16(s []Float16, seed uint64, key func(Float16) uint16) uint64 {
+	n := len(s)
+	var h uint64
+
+	// 32-byte stripes hold 16 values
+	if n >= 16 {
+		v1 := seed + xxhPrime1 + xxhPrime2
+		v2 := seed + xxhPrime2
+		v3 := seed
+		v4 := seed - xxhPrime1
+		for len(s) >= 16 {
+			v1 = xxhRound(v1, load64(s[0:4], key))
+			v2 = xxhRound(v2, load64(s[4:8], key))
+			v3 = xxhRound(v3, load64(s[8:12], key))
+			v4 = xxhRound(v4, load64(s[12:16], key))
+			s = s[16:]
+		}
+		h = bits.RotateLeft64(v1, 1) + bits.RotateLeft64(v2, 7) +
+			bits.RotateLeft64(v3, 12) + bits.RotateLeft64(v4, 18)
+		h = xxhMergeRound(h, v1)
+		h = xxhMergeRound(h, v2)
+		h = xxhMergeRound(h, v3)
+		h = xxhMergeRound(h, v4)
+	} else {
+		h = seed + xxhPrime5
+	}
+
+	h += uint64(n) * 2
+
+	// 8-byte words hold 4 values
+	for len(s) >= 4 {
+		h ^= xxhRound(0, load64(s, key))
+		h = bits.RotateLeft64(h, 27)*xxhPrime1 + xxhPrime4
+		s = s[4:]
+	}
+
+	// A 4-byte word holds 2 values
+	if len(s) >= 2 {
+		w := uint64(key(s[0])) | uint64(key(s[1]))<<16
+		h ^= w * xxhPrime1
+		h = bits.RotateLeft64(h, 23)*xxhPrime2 + xxhPrime3
+		s = s[2:]
+	}
+
+	// Trailing bytes of a single value, low byte first
+	if len(s) == 1 {
+		v := key(s[0])
+		for _, b := range [2]byte{byte(v), byte(v >> 8)} {
+			h ^= uint64(b) * xxhPrime5
+			h = bits.RotateLeft64(h, 11) * xxhPrime1
+		}
+	}
+
+	h ^= h >> 33
+	h *= xxhPrime2
+	h ^= h >> 29
+	h *= xxhPrime3
+	h ^= h >> 32
+	return h
+}
+
+// xxhRound mixes one 64-bit input lane into an accumulator
+func xxhRound(acc, input uint64) uint64 {
+	acc += input * xxhPrime2
+	acc = bits.RotateLeft64(acc, 31)
+	return acc * xxhPrime1
+}
+
+// xxhMergeRound folds an accumulator into the final hash
+func xxhMergeRound(h, v uint64) uint64 {
+	h ^= xxhRound(0, v)
+	return h*xxhPrime1 + xxhPrime4
+}
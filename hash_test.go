@@ -0,0 +1,137 @@
+package float16
+
+import (
+	"math/bits"
+	"math/rand"
+	"testing"
+)
+
+// xxh64Bytes is a straightforward byte-oriented XXH64 used as a reference
+func xxh64Bytes(b []byte, seed uint64) uint64 {
+	le64 := func(p []byte) uint64 {
+		var v uint64
+		for i := 7; i >= 0; i-- {
+			v = v<<8 | uint64(p[i])
+		}
+		return v
+	}
+	n := len(b)
+	var h uint64
+	if n >= 32 {
+		v1 := seed + xxhPrime1 + xxhPrime2
+		v2 := seed + xxhPrime2
+		v3 := seed
+		v4 := seed - xxhPrime1
+		for len(b) >= 32 {
+			v1 = xxhRound(v1, le64(b[0:]))
+			v2 = xxhRound(v2, le64(b[8:]))
+			v3 = xxhRound(v3, le64(b[16:]))
+			v4 = xxhRound(v4, le64(b[24:]))
+			b = b[32:]
+		}
+		h = bits.RotateLeft64(v1, 1) + bits.RotateLeft64(v2, 7) + bits.RotateLeft64(v3, 12) + bits.RotateLeft64(v4, 18)
+		h = xxhMergeRound(h, v1)
+		h = xxhMergeRound(h, v2)
+		h = xxhMergeRound(h, v3)
+		h = xxhMergeRound(h, v4)
+	} else {
+		h = seed + xxhPrime5
+	}
+	h += uint64(n)
+	for len(b) >= 8 {
+		h ^= xxhRound(0, le64(b))
+		h = bits.RotateLeft64(h, 27)*xxhPrime1 + xxhPrime4
+		b = b[8:]
+	}
+	if len(b) >= 4 {
+		w := uint64(b[0]) | uint64(b[1])<<8 | uint64(b[2])<<16 | uint64(b[3])<<24
+		h ^= w * xxhPrime1
+		h = bits.RotateLeft64(h, 23)*xxhPrime2 + xxhPrime3
+		b = b[4:]
+	}
+	for _, c := range b {
+		h ^= uint64(c) * xxhPrime5
+		h = bits.RotateLeft64(h, 11) * xxhPrime1
+	}
+	h ^= h >> 33
+	h *= xxhPrime2
+	h ^= h >> 29
+	h *= xxhPrime3
+	h ^= h >> 32
+	return h
+}
+
+func TestHashSliceKnownVector(t *testing.T) {
+	// XXH64 of the empty input with seed 0
+	if got := HashSliceBits(nil, 0); got != 0xEF46DB3751D8E999 {
+		t.Errorf("HashSliceBits(nil, 0) = %#x, want 0xef46db3751d8e999", got)
+	}
+	if HashSlice(nil, 0) != HashSliceBits([]Float16{}, 0) {
+		t.Error("empty slices should hash identically")
+	}
+}
+
+func TestHashSliceMatchesByteStream(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for n := 0; n < 70; n++ {
+		s := make([]Float16, n)
+		buf := make([]byte, 2*n)
+		for i := range s {
+			s[i] = Float16(rng.Uint32())
+			buf[2*i] = byte(s[i])
+			buf[2*i+1] = byte(s[i] >> 8)
+		}
+		seed := rng.Uint64()
+		if got, want := HashSliceBits(s, seed), xxh64Bytes(buf, seed); got != want {
+			t.Fatalf("len %d: HashSliceBits = %#x, want %#x", n, got, want)
+		}
+	}
+}
+
+func TestHashSliceCanonicalization(t *testing.T) {
+	a := []Float16{FromFloat32(1.5), PositiveZero, QuietNaN, FromFloat32(-2)}
+	b := []Float16{FromFloat32(1.5), NegativeZero, NegativeQNaN | 0x0055, FromFloat32(-2)}
+
+	if HashSlice(a, 7) != HashSlice(b, 7) {
+		t.Error("HashSlice should treat ±0 and NaN payloads as equal")
+	}
+	if HashSliceBits(a, 7) == HashSliceBits(b, 7) {
+		t.Error("HashSliceBits should distinguish ±0 and NaN payloads")
+	}
+
+	single := []Float16{NegativeZero}
+	if HashSlice(single, 0) != HashSlice([]Float16{PositiveZero}, 0) {
+		t.Error("single-element -0 and +0 should hash equally")
+	}
+	if HashSliceBits(single, 0) == HashSliceBits([]Float16{PositiveZero}, 0) {
+		t.Error("single-element -0 and +0 should have different raw hashes")
+	}
+	if HashSlice(a, 1) == HashSlice(a, 2) {
+		t.Error("different seeds should produce different hashes")
+	}
+}
+
+func TestHashSliceCollisions(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	seen := make(map[uint64]int)
+	const corpus = 20000
+	for i := 0; i < corpus; i++ {
+		s := make([]Float16, 1+rng.Intn(40))
+		for j := range s {
+			s[j] = Float16(rng.Uint32())
+		}
+		seen[HashSliceBits(s, 0)]++
+	}
+	// Duplicate inputs are possible for very short slices, but only rarely.
+	if len(seen) < corpus-5 {
+		t.Errorf("too many collisions: %d distinct hashes for %d inputs", len(seen), corpus)
+	}
+}
+
+func BenchmarkHashSlice(b *testing.B) {
+	s := GenerateBenchData(4096, ProfileMixedSpecial)
+	b.SetBytes(int64(2 * len(s)))
+	for i := 0; i < b.N; i++ {
+		HashSlice(s, 0)
+	}
+}
@@ -0,0 +1,50 @@
+package float16
+
+// Tolerance comparisons
+//
+// IsClose and AllClose follow numpy.isclose and numpy.allclose, so test
+// suites written against numpy port directly. The tolerance test is
+// asymmetric, like numpy's: rtol scales |b|, the reference value, only.
+
+// IsClose reports whether |a - b| <= atol + rtol·|b|, evaluated in float32.
+// NaNs are never close, not even to each other. An infinity is close only to
+// the same infinity.
+func IsClose(a, b, rtol, atol Float16) bool {
+	return IsCloseWithNaN(a, b, rtol, atol, false)
+}
+
+// IsCloseWithNaN is IsClose with numpy's equal_nan option: when equalNaN is
+// true, two NaNs are close to each other.
+func IsCloseWithNaN(a, b, rtol, atol Float16, equalNaN bool) bool {
+	switch {
+	case a.IsNaN() || b.IsNaN():
+		return equalNaN && a.IsNaN() && b.IsNaN()
+	case a.IsInf(0) || b.IsInf(0):
+		return a == b
+	}
+	x, y := a.ToFloat32(), b.ToFloat32()
+	diff := x - y
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= atol.ToFloat32()+rtol.ToFloat32()*b.Abs().ToFloat32()
+}
+
+// AllClose reports whether IsClose holds for every pair a[i], b[i]. It
+// panics if the lengths differ.
+func AllClose(a, b []Float16, rtol, atol Float16) bool {
+	return AllCloseWithNaN(a, b, rtol, atol, false)
+}
+
+// AllCloseWithNaN is AllClose with numpy's equal_nan option
+func AllCloseWithNaN(a, b []Float16, rtol, atol Float16, equalNaN bool) bool {
+	if len(a) != len(b) {
+		panic("float16: slice length mismatch")
+	}
+	for i := range a {
+		if !IsCloseWithNaN(a[i], b[i], rtol, atol, equalNaN) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package float16
+
+import "testing"
+
+func TestIsClose(t *testing.T) {
+	// numpy's defaults, rounded to Float16: atol 1e-8 becomes 0
+	rtol, atol := FromFloat64(1e-5), FromFloat64(1e-8)
+	h := FromFloat64
+
+	tests := []struct {
+		name       string
+		a, b       Float16
+		rtol, atol Float16
+		want       bool
+	}{
+		{"equal", One16, One16, rtol, atol, true},
+		{"one ulp apart at default rtol", One16, NextAfter(One16, Two16), rtol, atol, false},
+		{"within rtol", h(1000), h(1000.5), h(1e-3), 0, true},
+		{"outside rtol", h(1000), h(1002), h(1e-3), 0, false},
+		// rtol scales |b| only, so swapping the arguments can change the answer
+		{"asymmetric, small b", One16, h(0.75), h(0.25), 0, false},
+		{"asymmetric, large b", h(0.75), One16, h(0.25), 0, true},
+		// numpy.isclose([1e-100, 1e-7], [0.0, 0.0], atol=0.0) is all False
+		{"tiny against zero without atol", h(1e-4), 0, rtol, 0, false},
+		// numpy.isclose([1e-10, 1e-10], [1e-20, 0.0]) is all True with atol
+		{"tiny against zero with atol", h(1e-7), 0, rtol, h(1e-6), true},
+		{"signed zeros", NegativeZero, PositiveZero, 0, 0, true},
+		{"same infinity", PositiveInfinity, PositiveInfinity, 0, 0, true},
+		{"opposite infinities", PositiveInfinity, NegativeInfinity, 0, 0, false},
+		{"infinity and MaxValue", PositiveInfinity, MaxValue, MaxValue, MaxValue, false},
+		{"NaN and one", QuietNaN, One16, MaxValue, MaxValue, false},
+		{"NaN and NaN", QuietNaN, QuietNaN, rtol, atol, false},
+	}
+	for _, tt := range tests {
+		if got := IsClose(tt.a, tt.b, tt.rtol, tt.atol); got != tt.want {
+			t.Errorf("%s: IsClose(%v, %v, %v, %v) = %v, want %v", tt.name, tt.a, tt.b, tt.rtol, tt.atol, got, tt.want)
+		}
+	}
+
+	// numpy.isclose([1.0, nan], [1.0, nan], equal_nan=True) is all True
+	if !IsCloseWithNaN(QuietNaN, QuietNaN, rtol, atol, true) {
+		t.Error("IsCloseWithNaN(NaN, NaN, equalNaN) = false")
+	}
+	if IsCloseWithNaN(QuietNaN, One16, rtol, atol, true) {
+		t.Error("IsCloseWithNaN(NaN, 1, equalNaN) = true")
+	}
+}
+
+func TestAllClose(t *testing.T) {
+	rtol, atol := FromFloat64(1e-3), PositiveZero
+	a := []Float16{One16, FromFloat64(1000), QuietNaN}
+	b := []Float16{One16, FromFloat64(1000.5), QuietNaN}
+
+	if AllClose(a, b, rtol, atol) {
+		t.Error("AllClose treated NaNs as close")
+	}
+	if !AllCloseWithNaN(a, b, rtol, atol, true) {
+		t.Error("AllCloseWithNaN(equalNaN) = false")
+	}
+	if !AllClose(a[:2], b[:2], rtol, atol) {
+		t.Error("AllClose of close finite slices = false")
+	}
+	if !AllClose(nil, nil, rtol, atol) {
+		t.Error("AllClose(nil, nil) = false")
+	}
+	if !panics(func() { AllClose(a, b[:1], rtol, atol) }) {
+		t.Error("AllClose did not panic on length mismatch")
+	}
+}
@@ -0,0 +1,112 @@
+package float16
+
+import "fmt"
+
+// Data movement
+//
+// These functions only move values; no element is converted or rounded.
+
+// Reverse reverses s in place.
+func Reverse(s []Float16) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// Reversed returns a reversed copy of s.
+func Reversed(s []Float16) []Float16 {
+	result := make([]Float16, len(s))
+	for i, v := range s {
+		result[len(s)-1-i] = v
+	}
+	return result
+}
+
+// Rotate cyclically rotates s in place to the left by k positions, so that
+// the element at index k moves to index 0. A negative k rotates to the
+// right, and k is taken modulo len(s).
+func Rotate(s []Float16, k int) {
+	n := len(s)
+	if n == 0 {
+		return
+	}
+	k %= n
+	if k < 0 {
+		k += n
+	}
+	if k == 0 {
+		return
+	}
+	Reverse(s[:k])
+	Reverse(s[k:])
+	Reverse(s)
+}
+
+// StridedView returns a copy of the count elements of s starting at offset
+// and stride apart: s[offset], s[offset+stride], ... It is the gather step
+// for viewing one axis of a row-major tensor. stride must be positive, and
+// every gathered index must lie inside s; otherwise StridedView returns an
+// ErrInvalidOperation error describing the offending index.
+func StridedView(s []Float16, offset, stride, count int) ([]Float16, error) {
+	if count < 0 {
+		return nil, &Float16Error{
+			Op:   "StridedView",
+			Msg:  fmt.Sprintf("negative count %d", count),
+			Code: ErrInvalidOperation,
+		}
+	}
+	if err := stridedBounds("StridedView", len(s), offset, stride, count); err != nil {
+		return nil, err
+	}
+	dst := make([]Float16, count)
+	for i := range dst {
+		dst[i] = s[offset+i*stride]
+	}
+	return dst, nil
+}
+
+// GatherInto is StridedView writing into dst, gathering len(dst) elements:
+// dst[i] = s[offset+i·stride]. It returns an error, leaving dst untouched, if
+// the stride or any index is invalid.
+func GatherInto(dst, s []Float16, offset, stride int) error {
+	if err := stridedBounds("GatherInto", len(s), offset, stride, len(dst)); err != nil {
+		return err
+	}
+	for i := range dst {
+		dst[i] = s[offset+i*stride]
+	}
+	return nil
+}
+
+// ScatterInto is the inverse of GatherInto: it writes src[i] to
+// dst[offset+i·stride] for every i, leaving the other elements of dst
+// unchanged. It returns an error, writing nothing, if the stride or any index
+// is invalid.
+func ScatterInto(dst, src []Float16, offset, stride int) error {
+	if err := stridedBounds("ScatterInto", len(dst), offset, stride, len(src)); err != nil {
+		return err
+	}
+	for i, v := range src {
+		dst[offset+i*stride] = v
+	}
+	return nil
+}
+
+// stridedBounds checks that offset+i·stride lies in [0, n) for every
+// i < count, without overflowing
+func stridedBounds(op string, n, offset, stride, count int) error {
+	var msg string
+	switch {
+	case stride <= 0:
+		msg = fmt.Sprintf("stride %d is not positive", stride)
+	case count == 0:
+		return nil
+	case offset < 0 || offset >= n:
+		msg = fmt.Sprintf("offset %d outside [0, %d)", offset, n)
+	case count-1 > (n-1-offset)/stride:
+		msg = fmt.Sprintf("last index %d + (%d-1)·%d is past length %d", offset, count, stride, n)
+	default:
+		return nil
+	}
+	return &Float16Error{Op: op, Msg: msg, Code: ErrInvalidOperation}
+}
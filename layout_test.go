@@ -0,0 +1,162 @@
+package float16
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func seq(n int) []Float16 {
+	s := make([]Float16, n)
+	for i := range s {
+		s[i] = FromInt(i)
+	}
+	return s
+}
+
+func TestReverse(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []Float16
+		want []Float16
+	}{
+		{"empty", []Float16{}, []Float16{}},
+		{"single", []Float16{QuietNaN}, []Float16{QuietNaN}},
+		{"even", seq(4), []Float16{FromInt(3), FromInt(2), One(), PositiveZero}},
+		{"odd with specials", []Float16{NegativeZero, PositiveInfinity, FromBits(0x7C01)}, []Float16{FromBits(0x7C01), PositiveInfinity, NegativeZero}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := slices.Clone(tt.in)
+			if got := Reversed(tt.in); !slices.Equal(got, tt.want) {
+				t.Errorf("Reversed = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(tt.in, orig) {
+				t.Error("Reversed modified its input")
+			}
+			Reverse(tt.in)
+			if !slices.Equal(tt.in, tt.want) {
+				t.Errorf("Reverse = %v, want %v", tt.in, tt.want)
+			}
+		})
+	}
+}
+
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		name  string
+		n, k  int
+		first int
+	}{
+		{"by zero", 5, 0, 0},
+		{"left by two", 5, 2, 2},
+		{"right by one", 5, -1, 4},
+		{"full turn", 5, 5, 0},
+		{"large k", 5, 13, 3},
+		{"large negative k", 5, -13, 2},
+		{"single", 1, 7, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := seq(tt.n)
+			Rotate(s, tt.k)
+			for i, v := range s {
+				if want := FromInt((tt.first + i) % tt.n); v != want {
+					t.Fatalf("Rotate(seq(%d), %d) = %v, want element %d to be %v", tt.n, tt.k, s, i, want)
+				}
+			}
+		})
+	}
+	Rotate(nil, 3) // must not panic
+}
+
+func TestStridedView(t *testing.T) {
+	s := seq(10)
+	tests := []struct {
+		name                  string
+		offset, stride, count int
+		want                  []int // element values, or nil for an error
+		errPart               string
+	}{
+		{"every other", 1, 2, 5, []int{1, 3, 5, 7, 9}, ""},
+		{"column", 2, 4, 2, []int{2, 6}, ""},
+		{"single", 9, 3, 1, []int{9}, ""},
+		{"empty", 0, 1, 0, []int{}, ""},
+		{"zero stride", 0, 0, 3, nil, "stride 0"},
+		{"negative stride", 9, -1, 3, nil, "stride -1"},
+		{"past the end", 1, 3, 4, nil, "last index 1 + (4-1)·3 is past length 10"},
+		{"offset past the end", 10, 1, 1, nil, "offset 10"},
+		{"negative offset", -1, 1, 1, nil, "offset -1"},
+		{"negative count", 0, 1, -1, nil, "negative count"},
+		{"huge count", 0, 1 << 40, 1 << 40, nil, "past length"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := StridedView(s, tt.offset, tt.stride, tt.count)
+			if tt.want == nil {
+				var fe *Float16Error
+				if !errors.As(err, &fe) || fe.Code != ErrInvalidOperation || !strings.Contains(fe.Msg, tt.errPart) {
+					t.Errorf("StridedView(%d, %d, %d) error = %v, want one mentioning %q", tt.offset, tt.stride, tt.count, err, tt.errPart)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := make([]Float16, len(tt.want))
+			for i, v := range tt.want {
+				want[i] = FromInt(v)
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("StridedView = %v, want %v", got, want)
+			}
+		})
+	}
+
+	// On error the buffers are left alone
+	dst := seq(3)
+	if err := GatherInto(dst, s, 5, 3); err == nil || !slices.Equal(dst, seq(3)) {
+		t.Errorf("GatherInto past the end: err = %v, dst = %v", err, dst)
+	}
+	big := seq(10)
+	if err := ScatterInto(big, seq(5), 2, 2); err == nil || !slices.Equal(big, seq(10)) {
+		t.Errorf("ScatterInto past the end: err = %v, dst = %v", err, big)
+	}
+}
+
+func FuzzGatherScatter(f *testing.F) {
+	f.Add(10, 0, 1, 10)
+	f.Add(10, 1, 3, 3)
+	f.Add(7, 6, 5, 1)
+	f.Add(5, 0, 0, 2)
+	f.Fuzz(func(t *testing.T, n, offset, stride, count int) {
+		if n < 0 || n > 1<<12 || count < 0 || count > 1<<12 {
+			t.Skip()
+		}
+		s := seq(n)
+		view := make([]Float16, count)
+		err := GatherInto(view, s, offset, stride)
+		if err != nil {
+			if ScatterInto(make([]Float16, n), view, offset, stride) == nil {
+				t.Fatalf("GatherInto failed (%v) but ScatterInto accepted the same layout", err)
+			}
+			return
+		}
+		// Scattering the view back into a blank buffer and gathering again
+		// gives the view, and the scattered positions hold the originals
+		blank := make([]Float16, n)
+		if err := ScatterInto(blank, view, offset, stride); err != nil {
+			t.Fatal(err)
+		}
+		again := make([]Float16, count)
+		if err := GatherInto(again, blank, offset, stride); err != nil || !slices.Equal(again, view) {
+			t.Fatalf("round trip = (%v, %v), want %v", again, err, view)
+		}
+		for i := range view {
+			if j := offset + i*stride; blank[j] != s[j] {
+				t.Fatalf("blank[%d] = %v, want %v", j, blank[j], s[j])
+			}
+		}
+	})
+}
@@ -1,10 +1,28 @@
 package float16
 
 import (
+	"errors"
+	"fmt"
 	"math"
 )
 
 // Mathematical functions for Float16
+//
+// Unless documented otherwise, the unary functions evaluate the float64
+// function from package math at the exact input and round the result once to
+// Float16. Rounding through float32 first would round twice and can miss the
+// nearest Float16; math_accuracy_test.go checks every input against that
+// reference.
+
+// fromMath64 rounds a result computed by package math to Float16. A NaN
+// there was created from non-NaN operands, so it becomes the configured
+// default NaN rather than keeping the sign and payload package math gave it.
+func fromMath64(v float64) Float16 {
+	if v != v {
+		return defaultNaN()
+	}
+	return FromFloat64(v)
+}
 
 // Sqrt returns the square root of the Float16 value
 func Sqrt(f Float16) Float16 {
@@ -20,28 +38,14 @@ func Sqrt(f Float16) Float16 {
 	}
 	if f.Signbit() {
 		// Square root of negative number
-		return QuietNaN
+		return defaultNaN()
 	}
 
-	// Use float32 for computation and convert back
-	f32 := f.ToFloat32()
-	result := float32(math.Sqrt(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Sqrt(f.ToFloat64()))
 }
 
 // Cbrt returns the cube root of the Float16 value
 func Cbrt(f Float16) Float16 {
-	switch f {
-	case 0x3C00: // 1.0
-		return 0x3C00 // 1.0
-	case 0x4800: // 8.0
-		return 0x4000 // 2.0
-	case 0x51C0: // 27.0
-		return 0x4240 // 3.0
-	case 0x5800: // 64.0
-		return 0x4400 // 4.0
-	}
-
 	if f.IsZero() || f.IsNaN() {
 		return f
 	}
@@ -49,43 +53,132 @@ func Cbrt(f Float16) Float16 {
 		return f
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Cbrt(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Cbrt(f.ToFloat64()))
+}
+
+// Rsqrt returns the reciprocal square root 1/√f. Rsqrt(±0) is ±Inf,
+// Rsqrt(+Inf) is +0, and negative inputs give NaN.
+func Rsqrt(f Float16) Float16 {
+	if f.IsNaN() {
+		return f
+	}
+	if f.Signbit() && !f.IsZero() {
+		return defaultNaN()
+	}
+	return fromMath64(1 / math.Sqrt(f.ToFloat64()))
+}
+
+// rsqrtMagic is the constant of the float32 fast inverse square root
+const rsqrtMagic = 0x5F3759DF
+
+// RsqrtApprox returns an approximation of 1/√f for studying accuracy against
+// cost. It forms the classic bit-level estimate in float32, subtracting half
+// the bit pattern from 0x5F3759DF, and refines it with the given number of
+// Newton-Raphson steps y·(1.5 - 0.5·x·y²) before rounding to Float16. Over
+// all positive finite inputs the relative error before rounding is at most
+// 3.44% with no steps, 0.176% after one and 4.7·10^-6 after two. After
+// rounding that is up to 69 ULP, 3 ULP and 1 ULP from Rsqrt, and three steps
+// match Rsqrt for every input. Negative iterations count as 0. The special
+// cases are those of Rsqrt.
+func RsqrtApprox(f Float16, iterations int) Float16 {
+	switch {
+	case f.IsNaN() || f.IsZero() || f.IsInf(1):
+		return Rsqrt(f)
+	case f.Signbit():
+		return defaultNaN()
+	}
+	x := f.ToFloat32()
+	y := math.Float32frombits(rsqrtMagic - math.Float32bits(x)>>1)
+	for range iterations {
+		y *= 1.5 - 0.5*x*y*y
+	}
+	return FromFloat32(y)
+}
+
+// rsqrtSliceSteps is the number of Newton steps RsqrtSlice takes, the
+// fewest for which RsqrtApprox matches Rsqrt on every input
+const rsqrtSliceSteps = 3
+
+// RsqrtSlice returns 1/sqrt of each element of s. It refines the float32
+// reciprocal square root estimate of RsqrtApprox with Newton steps instead
+// of dividing by a float64 square root, and the results equal Rsqrt's.
+func RsqrtSlice(s []Float16) []Float16 {
+	dst := make([]Float16, len(s))
+	RsqrtSliceInto(dst, s)
+	return dst
+}
+
+// RsqrtSliceInto writes 1/sqrt of each element of s into dst, which must
+// have the same length as s. dst may alias s.
+func RsqrtSliceInto(dst, s []Float16) {
+	if len(dst) != len(s) {
+		panic("float16: slice length mismatch")
+	}
+	for i, v := range s {
+		dst[i] = RsqrtApprox(v, rsqrtSliceSteps)
+	}
 }
 
-// Pow returns f raised to the power of exp
+// Pow returns f raised to the power of exp, evaluated in float64 and rounded
+// once, so the result overflows to ±Inf exactly when the true power rounds
+// past MaxValue, that is when its magnitude reaches 65520
 func Pow(f, exp Float16) Float16 {
 	// Handle special cases according to IEEE 754
 	if exp.IsZero() {
-		return FromFloat32(1)
-	}
-	if f.IsZero() {
-		if exp.Signbit() {
-			return PositiveInfinity // 0^(-y) = +∞
-		}
-		return PositiveZero // 0^y = 0 for positive y
+		return One16
 	}
 	if f.IsNaN() || exp.IsNaN() {
-		return QuietNaN
+		return defaultNaN()
 	}
-	if f.IsInf(0) {
-		if exp.Signbit() {
-			return PositiveZero // ∞^(-y) = 0
-		}
-		return PositiveInfinity // ∞^y = ∞
+
+	// math.Pow applies the IEEE 754 rules for zero and infinite bases, where
+	// odd integer exponents keep the sign: Pow(-0, 3) = -0, Pow(-0, -1) = -Inf
+	return fromMath64(math.Pow(f.ToFloat64(), exp.ToFloat64()))
+}
+
+// PowSlice returns each element of s raised to the scalar power exp, with
+// the special-case behaviour of Pow: a negative base with a non-integer
+// exponent gives NaN.
+func PowSlice(s []Float16, exp Float16) []Float16 {
+	dst := make([]Float16, len(s))
+	PowSliceInto(dst, s, exp)
+	return dst
+}
+
+// PowSliceInto writes each element of s raised to exp into dst, which must
+// have the same length as s. dst may alias s.
+func PowSliceInto(dst, s []Float16, exp Float16) {
+	if len(dst) != len(s) {
+		panic("float16: slice length mismatch")
+	}
+	for i, v := range s {
+		dst[i] = Pow(v, exp)
 	}
+}
 
-	f32 := f.ToFloat32()
-	exp32 := exp.ToFloat32()
-	result := float32(math.Pow(float64(f32), float64(exp32)))
-	return FromFloat32(result)
+// PowSliceElementwise returns base[i] raised to exp[i] for each i.
+func PowSliceElementwise(base, exp []Float16) []Float16 {
+	dst := make([]Float16, len(base))
+	PowSliceElementwiseInto(dst, base, exp)
+	return dst
 }
 
-// Exp returns e^f
+// PowSliceElementwiseInto writes base[i] raised to exp[i] into dst[i]. All
+// three slices must have the same length; dst may alias either input.
+func PowSliceElementwiseInto(dst, base, exp []Float16) {
+	if len(base) != len(exp) || len(dst) != len(base) {
+		panic("float16: slice length mismatch")
+	}
+	for i := range base {
+		dst[i] = Pow(base[i], exp[i])
+	}
+}
+
+// Exp returns e^f. The result saturates to +Inf from f = 11.09375 on, the
+// first Float16 above ln(65520); Exp(11.0859375) is 65248.
 func Exp(f Float16) Float16 {
 	if f.IsZero() {
-		return FromFloat32(1)
+		return One16
 	}
 	if f.IsNaN() {
 		return f
@@ -97,15 +190,14 @@ func Exp(f Float16) Float16 {
 		return PositiveZero
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Exp(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Exp(f.ToFloat64()))
 }
 
-// Exp2 returns 2^f
+// Exp2 returns 2^f. The result saturates to +Inf from f = 16 on; the largest
+// finite result is Exp2(15.9921875) = 65184.
 func Exp2(f Float16) Float16 {
 	if f.IsZero() {
-		return FromFloat32(1)
+		return One16
 	}
 	if f.IsNaN() {
 		return f
@@ -117,14 +209,25 @@ func Exp2(f Float16) Float16 {
 		return PositiveZero
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Exp2(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Exp2(f.ToFloat64()))
 }
 
 // Exp10 returns 10^f
 func Exp10(f Float16) Float16 {
-	return FromFloat32(10)
+	if f.IsZero() {
+		return One16
+	}
+	if f.IsNaN() {
+		return f
+	}
+	if f.IsInf(1) {
+		return PositiveInfinity
+	}
+	if f.IsInf(-1) {
+		return PositiveZero
+	}
+
+	return fromMath64(math.Pow(10, f.ToFloat64()))
 }
 
 // Log returns the natural logarithm of f
@@ -139,12 +242,10 @@ func Log(f Float16) Float16 {
 		return PositiveInfinity
 	}
 	if f.Signbit() {
-		return QuietNaN // log of negative number
+		return defaultNaN() // log of negative number
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Log(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Log(f.ToFloat64()))
 }
 
 // Log2 returns the base-2 logarithm of f
@@ -159,12 +260,10 @@ func Log2(f Float16) Float16 {
 		return PositiveInfinity
 	}
 	if f.Signbit() {
-		return QuietNaN
+		return defaultNaN()
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Log2(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Log2(f.ToFloat64()))
 }
 
 // Log10 returns the base-10 logarithm of f
@@ -179,12 +278,10 @@ func Log10(f Float16) Float16 {
 		return PositiveInfinity
 	}
 	if f.Signbit() {
-		return QuietNaN
+		return defaultNaN()
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Log10(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Log10(f.ToFloat64()))
 }
 
 // Trigonometric functions
@@ -195,75 +292,113 @@ func Sin(f Float16) Float16 {
 		return f // Preserve sign of zero
 	}
 	if f.IsNaN() || f.IsInf(0) {
-		return QuietNaN
+		return defaultNaN()
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Sin(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Sin(f.ToFloat64()))
 }
 
 // Cos returns the cosine of f (in radians)
 func Cos(f Float16) Float16 {
 	if f.IsZero() {
-		return FromFloat32(1)
+		return One16
 	}
 	if f.IsNaN() || f.IsInf(0) {
-		return QuietNaN
+		return defaultNaN()
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Cos(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Cos(f.ToFloat64()))
 }
 
-// Tan returns the tangent of f (in radians)
+// Tan returns the tangent of f (in radians): the float64 tangent of the
+// exact input rounded once to Float16. No Float16 equals an odd multiple of
+// π/2, so Tan has no poles, although a few inputs such as -177.5 lie close
+// enough to one that the result overflows to ±Inf. Use Tanpi for exact poles.
 func Tan(f Float16) Float16 {
 	if f.IsZero() {
 		return f // Preserve sign of zero
 	}
 	if f.IsNaN() || f.IsInf(0) {
-		return QuietNaN
+		return defaultNaN()
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Tan(float64(f32)))
-	return FromFloat32(result)
+	return FromFloat32(roundToOdd64(math.Tan(f.ToFloat64()), 0))
 }
 
-// Asin returns the arcsine of f
-func Asin(f Float16) Float16 {
-	if f.IsZero() {
-		return f
-	}
-	if f.IsNaN() {
-		return f
+// Tanpi returns tan(π·f), with the period reduced exactly so that large
+// arguments, where the period is below one ULP, follow the representable
+// input rather than a rounded multiple of π. Following IEEE 754, integers
+// give +0 for positive even and negative odd n and -0 otherwise, and n+½
+// gives +Inf for even n and -Inf for odd n. NaN and infinities give NaN.
+func Tanpi(f Float16) Float16 {
+	if f.IsNaN() || f.IsInf(0) {
+		return defaultNaN()
+	}
+	x := f.ToFloat64()
+	r := math.Mod(x, 1) // exact
+	switch math.Abs(r) {
+	case 0:
+		odd := math.Mod(x, 2) != 0
+		if f.Signbit() != odd {
+			return NegativeZero
+		}
+		return PositiveZero
+	case 0.5:
+		if math.Mod(math.Floor(x), 2) != 0 {
+			return NegativeInfinity
+		}
+		return PositiveInfinity
 	}
 
-	// Check domain: [-1, 1]
-	if f.Abs().ToFloat32() > 1.0 {
-		return QuietNaN
+	// Reduce to (-½, ½), then use tan(πr) = 1/tan(π(½-r)) near the poles so
+	// the argument of math.Tan stays small; all the subtractions are exact
+	if r > 0.5 {
+		r--
+	} else if r < -0.5 {
+		r++
 	}
-
-	f32 := f.ToFloat32()
-	result := float32(math.Asin(float64(f32)))
-	return FromFloat32(result)
+	var t float64
+	if math.Abs(r) <= 0.25 {
+		t = math.Tan(math.Pi * r)
+	} else {
+		t = math.Copysign(1/math.Tan(math.Pi*(0.5-math.Abs(r))), r)
+	}
+	return FromFloat32(roundToOdd64(t, 0))
 }
 
-// Acos returns the arccosine of f
-func Acos(f Float16) Float16 {
-	if f.IsNaN() {
+// Asin returns the arcsine of f, or NaN if |f| > 1. The endpoints are
+// exact constants: Asin(±1) is ±HalfPi, the correctly rounded π/2.
+func Asin(f Float16) Float16 {
+	switch {
+	case f.IsZero() || f.IsNaN():
 		return f
+	case f.Abs() > One16: // also ±Inf
+		return defaultNaN()
+	case f == One16:
+		return HalfPi
+	case f == negOne16:
+		return HalfPi.Neg()
 	}
+	return fromMath64(math.Asin(f.ToFloat64()))
+}
 
-	// Check domain: [-1, 1]
-	if f.Abs().ToFloat32() > 1.0 {
-		return QuietNaN
+// Acos returns the arccosine of f, or NaN if |f| > 1. The endpoints and the
+// midpoint are exact constants: Acos(1) is +0, Acos(-1) is Pi and Acos(±0) is
+// HalfPi, each the correctly rounded value.
+func Acos(f Float16) Float16 {
+	switch {
+	case f.IsNaN():
+		return f
+	case f.Abs() > One16: // also ±Inf
+		return defaultNaN()
+	case f == One16:
+		return PositiveZero
+	case f == negOne16:
+		return Pi
+	case f.IsZero():
+		return HalfPi
 	}
-
-	f32 := f.ToFloat32()
-	result := float32(math.Acos(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Acos(f.ToFloat64()))
 }
 
 // Atan returns the arctangent of f
@@ -275,21 +410,19 @@ func Atan(f Float16) Float16 {
 		return f
 	}
 	if f.IsInf(1) {
-		return Div(Pi, FromFloat32(2))
+		return HalfPi
 	}
 	if f.IsInf(-1) {
-		return Div(Pi, FromFloat32(2)).Neg()
+		return HalfPi.Neg()
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Atan(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Atan(f.ToFloat64()))
 }
 
 // Atan2 returns the arctangent of y/x
 func Atan2(y, x Float16) Float16 {
 	if y.IsNaN() || x.IsNaN() {
-		return QuietNaN
+		return defaultNaN()
 	}
 
 	y32 := y.ToFloat32()
@@ -300,7 +433,9 @@ func Atan2(y, x Float16) Float16 {
 
 // Hyperbolic functions
 
-// Sinh returns the hyperbolic sine of f
+// Sinh returns the hyperbolic sine of f. The result saturates to ±Inf for
+// |f| >= 11.7890625, the first Float16 above ln(2·65520); Sinh(11.78125) is
+// 65376.
 func Sinh(f Float16) Float16 {
 	if f.IsZero() {
 		return f
@@ -312,15 +447,14 @@ func Sinh(f Float16) Float16 {
 		return f
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Sinh(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Sinh(f.ToFloat64()))
 }
 
-// Cosh returns the hyperbolic cosine of f
+// Cosh returns the hyperbolic cosine of f. The result saturates to +Inf for
+// |f| >= 11.7890625, as Sinh does.
 func Cosh(f Float16) Float16 {
 	if f.IsZero() {
-		return FromFloat32(1)
+		return One16
 	}
 	if f.IsNaN() {
 		return f
@@ -329,9 +463,7 @@ func Cosh(f Float16) Float16 {
 		return PositiveInfinity
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Cosh(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Cosh(f.ToFloat64()))
 }
 
 // Tanh returns the hyperbolic tangent of f
@@ -343,15 +475,13 @@ func Tanh(f Float16) Float16 {
 		return f
 	}
 	if f.IsInf(1) {
-		return FromFloat32(1)
+		return One16
 	}
 	if f.IsInf(-1) {
-		return FromFloat32(-1)
+		return negOne16
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Tanh(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Tanh(f.ToFloat64()))
 }
 
 // Rounding and truncation functions
@@ -362,9 +492,7 @@ func Floor(f Float16) Float16 {
 		return f
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Floor(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Floor(f.ToFloat64()))
 }
 
 // Ceil returns the smallest integer value greater than or equal to f
@@ -373,9 +501,7 @@ func Ceil(f Float16) Float16 {
 		return f
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Ceil(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Ceil(f.ToFloat64()))
 }
 
 // Round returns the nearest integer value to f
@@ -384,9 +510,7 @@ func Round(f Float16) Float16 {
 		return f
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Round(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Round(f.ToFloat64()))
 }
 
 // RoundToEven returns the nearest integer value to f, rounding ties to even
@@ -395,9 +519,7 @@ func RoundToEven(f Float16) Float16 {
 		return f
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.RoundToEven(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.RoundToEven(f.ToFloat64()))
 }
 
 // Trunc returns the integer part of f (truncated towards zero)
@@ -406,69 +528,68 @@ func Trunc(f Float16) Float16 {
 		return f
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Trunc(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Trunc(f.ToFloat64()))
 }
 
-// Mod returns the floating-point remainder of f/divisor
+// Mod returns the floating-point remainder of f/divisor.
+// The result has the sign of f and magnitude less than |divisor|, matching
+// math.Mod: Mod(±0, y) = ±0 and Mod(x, ±Inf) = x for finite x. The remainder
+// of two Float16 values is always exactly representable, so the result is
+// computed exactly in float64 and narrowed without rounding.
 func Mod(f, divisor Float16) Float16 {
-	if divisor.IsZero() {
-		return QuietNaN
-	}
-	if f.IsZero() {
-		return f
-	}
 	if f.IsNaN() || divisor.IsNaN() {
-		return QuietNaN
+		return defaultNaN()
 	}
-	if f.IsInf(0) || divisor.IsInf(0) {
-		return QuietNaN
+	if divisor.IsZero() || f.IsInf(0) {
+		return defaultNaN()
+	}
+	if f.IsZero() || divisor.IsInf(0) {
+		return f
 	}
 
-	f32 := f.ToFloat32()
-	div32 := divisor.ToFloat32()
-	result := float32(math.Mod(float64(f32), float64(div32)))
-	return FromFloat32(result)
+	result := math.Mod(f.ToFloat64(), divisor.ToFloat64())
+	return fromMath64(result)
 }
 
-// Remainder returns the IEEE 754 floating-point remainder of f/divisor
+// Remainder returns the IEEE 754 floating-point remainder of f/divisor.
+// The result is f - n*divisor where n is the integer nearest f/divisor, with
+// ties broken toward the even n, so it lies in [-|divisor|/2, |divisor|/2].
+// A zero result has the sign of f. As with Mod, the result is exact.
 func Remainder(f, divisor Float16) Float16 {
-	if divisor.IsZero() {
-		return QuietNaN
-	}
-	if f.IsZero() {
-		return f
-	}
 	if f.IsNaN() || divisor.IsNaN() {
-		return QuietNaN
+		return defaultNaN()
 	}
-	if f.IsInf(0) {
-		return QuietNaN
+	if divisor.IsZero() || f.IsInf(0) {
+		return defaultNaN()
 	}
-	if divisor.IsInf(0) {
+	if f.IsZero() || divisor.IsInf(0) {
 		return f
 	}
 
-	f32 := f.ToFloat32()
-	div32 := divisor.ToFloat32()
-	result := float32(math.Remainder(float64(f32), float64(div32)))
-	return FromFloat32(result)
+	result := math.Remainder(f.ToFloat64(), divisor.ToFloat64())
+	return fromMath64(result)
 }
 
-// Mathematical constants as Float16 values
-var (
-	E       = FromFloat32(float32(math.E))       // Euler's number
-	Pi      = FromFloat32(float32(math.Pi))      // Pi
-	Phi     = FromFloat32(float32(math.Phi))     // Golden ratio
-	Sqrt2   = FromFloat32(float32(math.Sqrt2))   // Square root of 2
-	SqrtE   = FromFloat32(float32(math.SqrtE))   // Square root of E
-	SqrtPi  = FromFloat32(float32(math.SqrtPi))  // Square root of Pi
-	SqrtPhi = FromFloat32(float32(math.SqrtPhi)) // Square root of Phi
-	Ln2     = FromFloat32(float32(math.Ln2))     // Natural logarithm of 2
-	Log2E   = FromFloat32(float32(math.Log2E))   // Base-2 logarithm of E
-	Ln10    = FromFloat32(float32(math.Ln10))    // Natural logarithm of 10
-	Log10E  = FromFloat32(float32(math.Log10E))  // Base-10 logarithm of E
+// Mathematical constants as Float16 values, each the nearest Float16 to the
+// corresponding math constant
+const (
+	E       Float16 = 0x4170 // Euler's number
+	Pi      Float16 = 0x4248 // Pi
+	HalfPi  Float16 = 0x3E48 // Pi/2
+	Tau     Float16 = 0x4648 // 2·Pi
+	Phi     Float16 = 0x3E79 // Golden ratio
+	Sqrt2   Float16 = 0x3DA8 // Square root of 2
+	SqrtE   Float16 = 0x3E98 // Square root of E
+	SqrtPi  Float16 = 0x3F17 // Square root of Pi
+	SqrtPhi Float16 = 0x3D17 // Square root of Phi
+	Ln2     Float16 = 0x398C // Natural logarithm of 2
+	Log2E   Float16 = 0x3DC5 // Base-2 logarithm of E
+	Ln10    Float16 = 0x409B // Natural logarithm of 10
+	Log10E  Float16 = 0x36F3 // Base-10 logarithm of E
+
+	// negOne16 is -1, for the special cases below; One16, Two16 and Ten16
+	// cover the positive values
+	negOne16 Float16 = 0xBC00
 )
 
 // Utility functions
@@ -498,7 +619,7 @@ func Lerp(a, b, t Float16) Float16 {
 	if t.IsZero() {
 		return a
 	}
-	if Equal(t, FromFloat32(1)) {
+	if Equal(t, One16) {
 		return b
 	}
 
@@ -507,18 +628,16 @@ func Lerp(a, b, t Float16) Float16 {
 	return Add(a, scaled)
 }
 
-// Sign returns -1, 0, or 1 depending on the sign of f
+// Sign returns -1, 0, or 1 depending on the sign of f. Zeros keep their
+// sign, so Sign(-0) is -0.
 func Sign(f Float16) Float16 {
-	if f.IsNaN() {
+	if f.IsNaN() || f.IsZero() {
 		return f
 	}
-	if f.IsZero() {
-		return PositiveZero
-	}
 	if f.Signbit() {
-		return FromFloat32(-1)
+		return negOne16
 	}
-	return FromFloat32(1)
+	return One16
 }
 
 // CopySign returns a Float16 with the magnitude of f and the sign of sign
@@ -529,8 +648,8 @@ func CopySign(f, sign Float16) Float16 {
 // Dim returns the positive difference between f and g: max(f-g, 0)
 func Dim(f, g Float16) Float16 {
 	diff := Sub(f, g)
-	if Less(diff, PositiveZero) {
-		return PositiveZero
+	if diff.Signbit() && !diff.IsNaN() {
+		return PositiveZero // also for -0, as max(-0, +0) is +0
 	}
 	return diff
 }
@@ -541,7 +660,7 @@ func Hypot(f, g Float16) Float16 {
 		return PositiveInfinity
 	}
 	if f.IsNaN() || g.IsNaN() {
-		return QuietNaN
+		return defaultNaN()
 	}
 
 	f32 := f.ToFloat32()
@@ -556,15 +675,13 @@ func Gamma(f Float16) Float16 {
 		return f
 	}
 	if f.IsInf(-1) {
-		return QuietNaN
+		return defaultNaN()
 	}
 	if f.IsInf(1) {
 		return PositiveInfinity
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Gamma(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Gamma(f.ToFloat64()))
 }
 
 // Lgamma returns the natural logarithm and sign of Gamma(f)
@@ -573,6 +690,10 @@ func Lgamma(f Float16) (Float16, int) {
 		return f, 1
 	}
 
+	if f == NegativeZero {
+		return PositiveInfinity, -1 // Gamma(-0) is -Inf
+	}
+
 	f32 := f.ToFloat32()
 	lgamma, sign := math.Lgamma(float64(f32))
 	return FromFloat32(float32(lgamma)), sign
@@ -587,9 +708,7 @@ func J0(f Float16) Float16 {
 		return PositiveZero
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.J0(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.J0(f.ToFloat64()))
 }
 
 // J1 returns the order-one Bessel function of the first kind
@@ -600,44 +719,41 @@ func J1(f Float16) Float16 {
 	if f.IsInf(0) {
 		return PositiveZero
 	}
+	if f.IsZero() {
+		return f // J1 is odd, so J1(-0) is -0
+	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.J1(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.J1(f.ToFloat64()))
 }
 
 // Y0 returns the order-zero Bessel function of the second kind
 func Y0(f Float16) Float16 {
-	if f.IsNaN() || f.Signbit() {
-		return QuietNaN
-	}
 	if f.IsZero() {
-		return NegativeInfinity
+		return NegativeInfinity // also for -0, which is not below zero
+	}
+	if f.IsNaN() || f.Signbit() {
+		return defaultNaN()
 	}
 	if f.IsInf(1) {
 		return PositiveZero
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Y0(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Y0(f.ToFloat64()))
 }
 
 // Y1 returns the order-one Bessel function of the second kind
 func Y1(f Float16) Float16 {
-	if f.IsNaN() || f.Signbit() {
-		return QuietNaN
-	}
 	if f.IsZero() {
-		return NegativeInfinity
+		return NegativeInfinity // also for -0, which is not below zero
+	}
+	if f.IsNaN() || f.Signbit() {
+		return defaultNaN()
 	}
 	if f.IsInf(1) {
 		return PositiveZero
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Y1(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Y1(f.ToFloat64()))
 }
 
 // Erf returns the error function of f
@@ -649,15 +765,13 @@ func Erf(f Float16) Float16 {
 		return f
 	}
 	if f.IsInf(1) {
-		return FromFloat32(1)
+		return One16
 	}
 	if f.IsInf(-1) {
-		return FromFloat32(-1)
+		return negOne16
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Erf(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Erf(f.ToFloat64()))
 }
 
 // Erfc returns the complementary error function of f
@@ -669,10 +783,136 @@ func Erfc(f Float16) Float16 {
 		return PositiveZero
 	}
 	if f.IsInf(-1) {
-		return FromFloat32(2)
+		return Two16
 	}
 
-	f32 := f.ToFloat32()
-	result := float32(math.Erfc(float64(f32)))
-	return FromFloat32(result)
+	return fromMath64(math.Erfc(f.ToFloat64()))
+}
+
+// Strict variants of the overflow-prone functions
+//
+// In ModeIEEE these return exactly what the plain functions return. In
+// ModeStrict a finite input whose true result does not fit a normal Float16
+// is reported the same way FromFloat64WithMode reports it: ErrOverflow above
+// MaxValue, ErrUnderflow for nonzero results that are subnormal or zero,
+// ErrInfinity for poles and ErrNaN for domain errors. Non-finite inputs are
+// never an error.
+
+// ExpWithMode is Exp with strict-mode range checking.
+func ExpWithMode(f Float16, mode ConversionMode) (Float16, error) {
+	return unaryWithMode("Exp", f, mode, Exp, math.Exp, false)
+}
+
+// Exp2WithMode is Exp2 with strict-mode range checking.
+func Exp2WithMode(f Float16, mode ConversionMode) (Float16, error) {
+	return unaryWithMode("Exp2", f, mode, Exp2, math.Exp2, false)
+}
+
+// SinhWithMode is Sinh with strict-mode range checking.
+func SinhWithMode(f Float16, mode ConversionMode) (Float16, error) {
+	return unaryWithMode("Sinh", f, mode, Sinh, math.Sinh, f.IsZero())
+}
+
+// CoshWithMode is Cosh with strict-mode range checking.
+func CoshWithMode(f Float16, mode ConversionMode) (Float16, error) {
+	return unaryWithMode("Cosh", f, mode, Cosh, math.Cosh, false)
+}
+
+// GammaWithMode is Gamma with strict-mode range checking.
+func GammaWithMode(f Float16, mode ConversionMode) (Float16, error) {
+	return unaryWithMode("Gamma", f, mode, Gamma, math.Gamma, f.IsZero())
+}
+
+// PowWithMode is Pow with strict-mode range checking.
+func PowWithMode(f, exp Float16, mode ConversionMode) (Float16, error) {
+	if mode != ModeStrict || !f.IsFinite() || !exp.IsFinite() {
+		return Pow(f, exp), nil
+	}
+	return checkMathResult("Pow", math.Pow(f.ToFloat64(), exp.ToFloat64()), f.IsZero())
+}
+
+func unaryWithMode(op string, f Float16, mode ConversionMode, fn func(Float16) Float16, ref func(float64) float64, exact bool) (Float16, error) {
+	if mode != ModeStrict || !f.IsFinite() {
+		return fn(f), nil
+	}
+	return checkMathResult(op, ref(f.ToFloat64()), exact)
+}
+
+// checkMathResult converts r with the strict conversion, attributing any
+// error to op. Unless exact is set, a zero or infinite r is taken to be the
+// float64 evaluation itself underflowing or overflowing; exact marks inputs
+// where such a result is the true value, such as a pole of Gamma at zero.
+func checkMathResult(op string, r float64, exact bool) (Float16, error) {
+	if !exact && r == 0 {
+		return 0, &Float16Error{Op: op, Msg: "underflow", Code: ErrUnderflow}
+	}
+	if !exact && math.IsInf(r, 0) {
+		return 0, &Float16Error{Op: op, Msg: "overflow", Code: ErrOverflow}
+	}
+	result, err := FromFloat64WithMode(r, ModeStrict, DefaultRoundingMode)
+	var fe *Float16Error
+	if errors.As(err, &fe) {
+		return 0, &Float16Error{Op: op, Msg: fe.Msg, Code: fe.Code}
+	}
+	return result, err
+}
+
+// NormCDF returns the standard normal cumulative distribution function
+// Φ(f). It is evaluated through Erfc in float64, so the lower tail keeps full
+// relative accuracy down to the smallest subnormal instead of collapsing to
+// zero through 1+Erf cancellation.
+func NormCDF(f Float16) Float16 {
+	if f.IsNaN() {
+		return f
+	}
+	return fromMath64(0.5 * math.Erfc(-f.ToFloat64()/math.Sqrt2))
+}
+
+// NormPDF returns the standard normal probability density at f.
+func NormPDF(f Float16) Float16 {
+	if f.IsNaN() {
+		return f
+	}
+	x := f.ToFloat64()
+	return fromMath64(math.Exp(-0.5*x*x) / math.Sqrt(2*math.Pi))
+}
+
+// NormQuantile returns the inverse of NormCDF: the x with Φ(x) = p. p = 0
+// and p = 1 return -Inf and +Inf. A NaN p or a p outside [0, 1] returns NaN
+// and an error.
+func NormQuantile(p Float16) (Float16, error) {
+	if p.IsNaN() {
+		return p, &Float16Error{
+			Op:   "NormQuantile",
+			Msg:  "probability is NaN",
+			Code: ErrNaN,
+		}
+	}
+	x := p.ToFloat64()
+	if x < 0 || x > 1 {
+		return defaultNaN(), &Float16Error{
+			Op:   "NormQuantile",
+			Msg:  fmt.Sprintf("probability %v outside [0, 1]", p),
+			Code: ErrInvalidOperation,
+		}
+	}
+	// Erfcinv keeps the lower tail accurate where 2p-1 would round to -1
+	q := -math.Sqrt2 * math.Erfcinv(2*x)
+	if q == 0 {
+		q = 0 // the median is +0, not -0
+	}
+	return fromMath64(q), nil
+}
+
+// Apply widens f to float32, evaluates op and rounds the result back to
+// Float16 with the default rounding. Special values are passed to op
+// unchanged, so handling NaN, infinities and signed zeros is op's
+// responsibility.
+func Apply(f Float16, op func(float32) float32) Float16 {
+	return FromFloat32(op(f.ToFloat32()))
+}
+
+// Apply2 is the two-operand form of Apply.
+func Apply2(a, b Float16, op func(float32, float32) float32) Float16 {
+	return FromFloat32(op(a.ToFloat32(), b.ToFloat32()))
 }
@@ -0,0 +1,152 @@
+package float16
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// accuracyManifest records, for each unary math function, the largest error
+// in ULP (see UlpDistance) over all 65536 inputs against the float64 function
+// of package math rounded once to Float16
+const accuracyManifest = "testdata/math_accuracy.txt"
+
+var updateAccuracy = flag.Bool("update-accuracy", false, "rewrite "+accuracyManifest+" with the measured errors")
+
+var unaryMathFuncs = []struct {
+	name string
+	f    func(Float16) Float16
+	ref  func(float64) float64
+}{
+	{"Sqrt", Sqrt, math.Sqrt},
+	{"Cbrt", Cbrt, math.Cbrt},
+	{"Rsqrt", Rsqrt, func(x float64) float64 { return 1 / math.Sqrt(x) }},
+	{"Exp", Exp, math.Exp},
+	{"Exp2", Exp2, math.Exp2},
+	{"Exp10", Exp10, func(x float64) float64 { return math.Pow(10, x) }},
+	{"Log", Log, math.Log},
+	{"Log2", Log2, math.Log2},
+	{"Log10", Log10, math.Log10},
+	{"Sin", Sin, math.Sin},
+	{"Cos", Cos, math.Cos},
+	{"Tan", Tan, math.Tan},
+	{"Asin", Asin, math.Asin},
+	{"Acos", Acos, math.Acos},
+	{"Atan", Atan, math.Atan},
+	{"Sinh", Sinh, math.Sinh},
+	{"Cosh", Cosh, math.Cosh},
+	{"Tanh", Tanh, math.Tanh},
+	{"Erf", Erf, math.Erf},
+	{"Erfc", Erfc, math.Erfc},
+	{"Gamma", Gamma, math.Gamma},
+	{"J0", J0, math.J0},
+	{"J1", J1, math.J1},
+	{"Y0", Y0, math.Y0},
+	{"Y1", Y1, math.Y1},
+	{"Floor", Floor, math.Floor},
+	{"Ceil", Ceil, math.Ceil},
+	{"Round", Round, math.Round},
+	{"Trunc", Trunc, math.Trunc},
+}
+
+// readAccuracyManifest parses lines of the form "Name maxULP", skipping blank
+// lines and # comments
+func readAccuracyManifest(path string) (map[string]uint16, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	limits := make(map[string]uint16)
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: want \"name maxULP\", got %q", path, line, text)
+		}
+		ulp, err := strconv.ParseUint(fields[1], 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", path, line, err)
+		}
+		limits[fields[0]] = uint16(ulp)
+	}
+	return limits, scanner.Err()
+}
+
+func writeAccuracyManifest(path string, measured []uint16) error {
+	var b strings.Builder
+	b.WriteString("# Maximum error in ULP of each unary math function over all 65536 inputs,\n")
+	b.WriteString("# against the float64 function of package math rounded once to Float16.\n")
+	b.WriteString("# TestMathAccuracyManifest fails if a function gets worse, so entries may\n")
+	b.WriteString("# only go down. Regenerate with: go test -run MathAccuracy -update-accuracy\n")
+	for i, fn := range unaryMathFuncs {
+		fmt.Fprintf(&b, "%s %d\n", fn.name, measured[i])
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+func TestMathAccuracyManifest(t *testing.T) {
+	measured := make([]uint16, len(unaryMathFuncs))
+	worst := make([]Float16, len(unaryMathFuncs))
+	for i, fn := range unaryMathFuncs {
+		for b := 0; b < 1<<16; b++ {
+			x := Float16(b)
+			if d := UlpDistance(fn.f(x), FromFloat64(fn.ref(x.ToFloat64()))); d > measured[i] {
+				measured[i], worst[i] = d, x
+			}
+		}
+	}
+	if *updateAccuracy {
+		if err := writeAccuracyManifest(accuracyManifest, measured); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	limits, err := readAccuracyManifest(accuracyManifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, fn := range unaryMathFuncs {
+		limit, ok := limits[fn.name]
+		switch {
+		case !ok:
+			t.Errorf("%s: no entry in %s", fn.name, accuracyManifest)
+		case measured[i] > limit:
+			x := worst[i]
+			t.Errorf("%s: max error %d ULP exceeds manifest %d; at %v got %v, want %v",
+				fn.name, measured[i], limit, x, fn.f(x), FromFloat64(fn.ref(x.ToFloat64())))
+		case measured[i] < limit:
+			t.Logf("%s: max error %d ULP is below manifest %d; consider tightening it", fn.name, measured[i], limit)
+		}
+	}
+}
+
+func TestReadAccuracyManifest(t *testing.T) {
+	path := t.TempDir() + "/manifest.txt"
+	if err := os.WriteFile(path, []byte("# comment\n\nExp 2\nLog   0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	limits, err := readAccuracyManifest(path)
+	if err != nil || len(limits) != 2 || limits["Exp"] != 2 || limits["Log"] != 0 {
+		t.Errorf("readAccuracyManifest = (%v, %v)", limits, err)
+	}
+	for _, bad := range []string{"Exp\n", "Exp one\n", "Exp 70000\n"} {
+		if err := os.WriteFile(path, []byte(bad), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := readAccuracyManifest(path); err == nil {
+			t.Errorf("readAccuracyManifest accepted %q", bad)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package float16
 
 import (
+	"errors"
+	"math"
+	"math/big"
 	"testing"
 )
 
@@ -334,3 +337,406 @@ func TestY1(t *testing.T) {
 	}
 }
 */
+
+func TestModRemainderAgainstFloat64(t *testing.T) {
+	// Operands cover subnormals, normals of both signs, and the extremes
+	var operands []Float16
+	for b := uint16(0); b < 0x7C00; b += 0x00B3 {
+		operands = append(operands, Float16(b), Float16(b|SignMask))
+	}
+	operands = append(operands, SmallestSubnormal, LargestSubnormal, SmallestNormal, MaxValue, MinValue)
+
+	for _, x := range operands {
+		for _, y := range operands {
+			if y.IsZero() {
+				continue
+			}
+			xf, yf := x.ToFloat64(), y.ToFloat64()
+			for _, c := range []struct {
+				name string
+				got  Float16
+				want float64
+			}{
+				{"Mod", Mod(x, y), math.Mod(xf, yf)},
+				{"Remainder", Remainder(x, y), math.Remainder(xf, yf)},
+			} {
+				if c.got.ToFloat64() != c.want || c.got.Signbit() != math.Signbit(c.want) {
+					t.Fatalf("%s(%v, %v) = %v (%#04x), want %v", c.name, x, y, c.got, c.got.Bits(), c.want)
+				}
+			}
+		}
+	}
+}
+
+func TestModRemainderSpecialCases(t *testing.T) {
+	three := FromFloat32(3)
+	tests := []struct {
+		name string
+		got  Float16
+		want Float16
+	}{
+		{"Mod(+0, 3)", Mod(PositiveZero, three), PositiveZero},
+		{"Mod(-0, 3)", Mod(NegativeZero, three), NegativeZero},
+		{"Mod(-6, 3)", Mod(FromFloat32(-6), three), NegativeZero},
+		{"Mod(2, +Inf)", Mod(FromFloat32(2), PositiveInfinity), FromFloat32(2)},
+		{"Mod(-2, -Inf)", Mod(FromFloat32(-2), NegativeInfinity), FromFloat32(-2)},
+		{"Remainder(-0, 3)", Remainder(NegativeZero, three), NegativeZero},
+		{"Remainder(-6, 3)", Remainder(FromFloat32(-6), three), NegativeZero},
+		// Half-way quotients round to the even multiple
+		{"Remainder(1.5, 1)", Remainder(FromFloat32(1.5), One()), FromFloat32(-0.5)},
+		{"Remainder(2.5, 1)", Remainder(FromFloat32(2.5), One()), FromFloat32(0.5)},
+		{"Remainder(-2.5, 1)", Remainder(FromFloat32(-2.5), One()), FromFloat32(-0.5)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %v (%#04x), want %v (%#04x)", tt.got, tt.got.Bits(), tt.want, tt.want.Bits())
+			}
+		})
+	}
+
+	for _, got := range []Float16{
+		Mod(PositiveZero, QuietNaN), Mod(PositiveInfinity, three), Mod(three, NegativeZero),
+		Remainder(PositiveZero, QuietNaN), Remainder(NegativeInfinity, three), Remainder(three, PositiveZero),
+	} {
+		if !got.IsNaN() {
+			t.Errorf("expected NaN, got %v", got)
+		}
+	}
+}
+
+func TestNormCDFAndPDFExhaustive(t *testing.T) {
+	cdf := func(x float64) float64 { return 0.5 * math.Erfc(-x/math.Sqrt2) }
+	if bad := ExhaustiveVerifyUnary(NormCDF, cdf, 2); len(bad) > 0 {
+		t.Errorf("NormCDF differs by more than 2 ULP at %d inputs, first %v", len(bad), bad[0])
+	}
+	pdf := func(x float64) float64 { return math.Exp(-0.5*x*x) / math.Sqrt(2*math.Pi) }
+	if bad := ExhaustiveVerifyUnary(NormPDF, pdf, 2); len(bad) > 0 {
+		t.Errorf("NormPDF differs by more than 2 ULP at %d inputs, first %v", len(bad), bad[0])
+	}
+
+	tests := []struct {
+		name string
+		in   Float16
+		want Float16
+	}{
+		{"zero", PositiveZero, FromFloat32(0.5)},
+		{"+Inf", PositiveInfinity, One()},
+		{"-Inf", NegativeInfinity, PositiveZero},
+		{"far lower tail", FromInt(-10), PositiveZero},
+		{"far upper tail", FromInt(10), One()},
+		// Φ(-4) ≈ 3.17e-5 must not be lost to cancellation
+		{"lower tail", FromInt(-4), FromFloat64(3.1671241833119857e-05)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormCDF(tt.in); got != tt.want {
+				t.Errorf("NormCDF(%v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+	if !NormCDF(QuietNaN).IsNaN() || !NormPDF(QuietNaN).IsNaN() {
+		t.Error("NaN input should produce NaN")
+	}
+}
+
+func TestNormQuantile(t *testing.T) {
+	quantile := func(p Float16) Float16 {
+		q, _ := NormQuantile(p)
+		return q
+	}
+	ref := func(p float64) float64 { return -math.Sqrt2 * math.Erfcinv(2*p) }
+	if bad := ExhaustiveVerifyUnary(quantile, ref, 2); len(bad) > 0 {
+		t.Errorf("NormQuantile differs by more than 2 ULP at %d inputs, first %v", len(bad), bad[0])
+	}
+
+	for _, tt := range []struct {
+		p    Float16
+		want Float16
+	}{
+		{PositiveZero, NegativeInfinity},
+		{NegativeZero, NegativeInfinity},
+		{One(), PositiveInfinity},
+		{FromFloat32(0.5), PositiveZero},
+	} {
+		got, err := NormQuantile(tt.p)
+		if err != nil || got != tt.want {
+			t.Errorf("NormQuantile(%v) = %v, %v, want %v", tt.p, got, err, tt.want)
+		}
+	}
+	for _, p := range []Float16{FromFloat32(-0.25), FromFloat32(1.5), PositiveInfinity, NegativeInfinity, QuietNaN} {
+		got, err := NormQuantile(p)
+		if err == nil || !got.IsNaN() {
+			t.Errorf("NormQuantile(%v) = %v, %v, want NaN and an error", p, got, err)
+		}
+	}
+
+	// Round trip: the error in p is bounded by the rounding of the quantile
+	// times the density there, plus the rounding of the CDF itself
+	for b := 1; b < int(One()); b++ {
+		p := Float16(b)
+		q, _ := NormQuantile(p)
+		ulpQ := math.Abs(NextAfter(q, PositiveInfinity).ToFloat64() - q.ToFloat64())
+		ulpP := math.Abs(NextAfter(p, PositiveInfinity).ToFloat64() - p.ToFloat64())
+		tol := NormPDF(q).ToFloat64()*ulpQ + 2*ulpP
+		if got := NormCDF(q).ToFloat64(); math.Abs(got-p.ToFloat64()) > tol {
+			t.Fatalf("NormCDF(NormQuantile(%v)) = %v, tolerance %v", p, got, tol)
+		}
+	}
+}
+
+func TestMathWithModeThresholds(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       func(Float16, ConversionMode) (Float16, error)
+		plain    func(Float16) Float16
+		lastOK   Float16
+		firstBad Float16
+		code     ErrorCode
+	}{
+		// ln(65504) ≈ 11.0898
+		{"Exp overflow", ExpWithMode, Exp, FromBits(0x498b), FromBits(0x498c), ErrOverflow},
+		// e^x reaches the smallest normal 2^-14 at x ≈ -9.704
+		{"Exp underflow", ExpWithMode, Exp, FromBits(0xc8da), FromBits(0xc8db), ErrUnderflow},
+		{"Exp2 overflow", Exp2WithMode, Exp2, FromBits(0x4bff), FromInt(16), ErrOverflow},
+		{"Exp2 underflow", Exp2WithMode, Exp2, FromInt(-14), FromBits(0xcb01), ErrUnderflow},
+		{"Sinh overflow", SinhWithMode, Sinh, FromBits(0x49e4), FromBits(0x49e5), ErrOverflow},
+		{"Sinh negative overflow", SinhWithMode, Sinh, FromBits(0xc9e4), FromBits(0xc9e5), ErrOverflow},
+		{"Sinh underflow", SinhWithMode, Sinh, FromBits(0x0400), FromBits(0x03ff), ErrUnderflow},
+		{"Cosh overflow", CoshWithMode, Cosh, FromBits(0x49e4), FromBits(0x49e5), ErrOverflow},
+		// Γ(9.2266) ≈ 66000
+		{"Gamma overflow", GammaWithMode, Gamma, FromBits(0x489c), FromBits(0x489d), ErrOverflow},
+		{"Gamma near pole", GammaWithMode, Gamma, FromBits(0x0101), FromBits(0x0100), ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(tt.lastOK, ModeStrict)
+			if err != nil {
+				t.Fatalf("strict(%v) error %v", tt.lastOK, err)
+			}
+			if got != tt.plain(tt.lastOK) {
+				t.Errorf("strict(%v) = %v, want %v", tt.lastOK, got, tt.plain(tt.lastOK))
+			}
+
+			_, err = tt.fn(tt.firstBad, ModeStrict)
+			var fe *Float16Error
+			if !errors.As(err, &fe) || fe.Code != tt.code {
+				t.Fatalf("strict(%v) error %v, want code %v", tt.firstBad, err, tt.code)
+			}
+
+			ieee, err := tt.fn(tt.firstBad, ModeIEEE)
+			if err != nil || ieee != tt.plain(tt.firstBad) {
+				t.Errorf("IEEE(%v) = %v, %v, want %v", tt.firstBad, ieee, err, tt.plain(tt.firstBad))
+			}
+			if tt.code == ErrOverflow && !ieee.IsInf(0) {
+				t.Errorf("IEEE(%v) = %v, want infinity", tt.firstBad, ieee)
+			}
+		})
+	}
+}
+
+func TestMathWithModeIEEEMatchesPlain(t *testing.T) {
+	fns := []struct {
+		fn    func(Float16, ConversionMode) (Float16, error)
+		plain func(Float16) Float16
+	}{{ExpWithMode, Exp}, {Exp2WithMode, Exp2}, {SinhWithMode, Sinh}, {CoshWithMode, Cosh}, {GammaWithMode, Gamma}}
+	for i := 0; i < 1<<16; i += 7 {
+		f := Float16(i)
+		for _, c := range fns {
+			got, err := c.fn(f, ModeIEEE)
+			if want := c.plain(f); err != nil || got != want {
+				t.Fatalf("IEEE mode at %#04x = %v, %v, want %v", i, got, err, want)
+			}
+		}
+	}
+	// Non-finite inputs are never errors, even in strict mode
+	for _, f := range []Float16{PositiveInfinity, NegativeInfinity, QuietNaN} {
+		for _, c := range fns {
+			if _, err := c.fn(f, ModeStrict); err != nil {
+				t.Errorf("strict mode with input %v: %v", f, err)
+			}
+		}
+	}
+}
+
+func TestMathWithModeStrictMatchesPlain(t *testing.T) {
+	fns := []struct {
+		name  string
+		fn    func(Float16, ConversionMode) (Float16, error)
+		plain func(Float16) Float16
+	}{{"Exp", ExpWithMode, Exp}, {"Exp2", Exp2WithMode, Exp2}, {"Sinh", SinhWithMode, Sinh}, {"Cosh", CoshWithMode, Cosh}, {"Gamma", GammaWithMode, Gamma}}
+	for _, c := range fns {
+		for i := 0; i < 1<<16; i++ {
+			f := Float16(i)
+			got, err := c.fn(f, ModeStrict)
+			if err != nil {
+				continue
+			}
+			if want := c.plain(f); got != want && !(got.IsNaN() && want.IsNaN()) {
+				t.Errorf("%sWithMode(%#04x, ModeStrict) = %#04x, plain %#04x", c.name, i, uint16(got), uint16(want))
+			}
+		}
+	}
+}
+
+func TestPowWithMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		base, ex Float16
+		want     Float16
+		code     ErrorCode
+		wantErr  bool
+	}{
+		{"fits", FromInt(2), FromInt(15), FromInt(32768), 0, false},
+		{"overflow", FromInt(2), FromInt(16), 0, ErrOverflow, true},
+		{"underflow", FromInt(2), FromInt(-15), 0, ErrUnderflow, true},
+		{"float64 underflow", FromInt(2), FromInt(-2000), 0, ErrUnderflow, true},
+		{"pole", PositiveZero, FromInt(-1), 0, ErrInfinity, true},
+		{"exact zero", PositiveZero, FromInt(3), PositiveZero, 0, false},
+		{"domain", FromInt(-2), FromFloat32(0.5), 0, ErrNaN, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PowWithMode(tt.base, tt.ex, ModeStrict)
+			if tt.wantErr {
+				var fe *Float16Error
+				if !errors.As(err, &fe) || fe.Code != tt.code || fe.Op != "Pow" {
+					t.Fatalf("PowWithMode(%v, %v) error %v, want code %v", tt.base, tt.ex, err, tt.code)
+				}
+			} else if err != nil || got != tt.want {
+				t.Fatalf("PowWithMode(%v, %v) = %v, %v, want %v", tt.base, tt.ex, got, err, tt.want)
+			}
+
+			ieee, err := PowWithMode(tt.base, tt.ex, ModeIEEE)
+			if want := Pow(tt.base, tt.ex); err != nil || (ieee != want && !(ieee.IsNaN() && want.IsNaN())) {
+				t.Errorf("IEEE mode = %v, %v, want %v", ieee, err, want)
+			}
+		})
+	}
+}
+
+func TestTanCorrectlyRounded(t *testing.T) {
+	for _, f := range AllFinite() {
+		want := fromRatRoundToOdd(new(big.Rat).SetFloat64(math.Tan(f.ToFloat64())))
+		if f.IsZero() {
+			want = f
+		}
+		if got := Tan(f); got != want {
+			t.Fatalf("Tan(%v) = %v, want %v", f, got, want)
+		}
+	}
+	// 113π/2 ≈ 177.499988, so tan(-177.5) ≈ 83000 overflows
+	if got := Tan(FromFloat32(-177.5)); !got.IsInf(1) {
+		t.Errorf("Tan(-177.5) = %v, want +Inf", got)
+	}
+}
+
+func TestTanpi(t *testing.T) {
+	tests := []struct {
+		name string
+		in   Float16
+		want Float16
+	}{
+		{"+0", PositiveZero, PositiveZero},
+		{"-0", NegativeZero, NegativeZero},
+		{"1 is positive odd", One(), NegativeZero},
+		{"2 is positive even", FromInt(2), PositiveZero},
+		{"-1 is negative odd", FromInt(-1), PositiveZero},
+		{"-2 is negative even", FromInt(-2), NegativeZero},
+		{"quarter", FromFloat32(0.25), One()},
+		{"minus quarter", FromFloat32(-0.25), FromInt(-1)},
+		{"three quarters", FromFloat32(0.75), FromInt(-1)},
+		{"pole at 1/2", FromFloat32(0.5), PositiveInfinity},
+		{"pole at 3/2", FromFloat32(1.5), NegativeInfinity},
+		{"pole at -1/2", FromFloat32(-0.5), NegativeInfinity},
+		{"pole at -3/2", FromFloat32(-1.5), PositiveInfinity},
+		// Above 512 the spacing is 1/2, so every value is a pole or an integer
+		{"large pole even", FromFloat32(600.5), PositiveInfinity},
+		{"large pole odd", FromFloat32(601.5), NegativeInfinity},
+		{"large even integer", FromInt(1000), PositiveZero},
+		{"large odd integer", FromInt(1001), NegativeZero},
+		{"max value", MaxValue, PositiveZero},
+		{"min value", MinValue, NegativeZero},
+		{"NaN", QuietNaN, QuietNaN},
+		{"Inf", PositiveInfinity, QuietNaN},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Tanpi(tt.in)
+			if tt.want.IsNaN() {
+				if !got.IsNaN() {
+					t.Errorf("Tanpi(%v) = %v, want NaN", tt.in, got)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Tanpi(%v) = %v (%#04x), want %v (%#04x)", tt.in, got, got.Bits(), tt.want, tt.want.Bits())
+			}
+		})
+	}
+
+	// Signs on both sides of each pole
+	for _, pole := range []float32{-2.5, -1.5, -0.5, 0.5, 1.5, 2.5, 100.5} {
+		p := FromFloat32(pole)
+		below, above := Tanpi(NextAfter(p, NegativeInfinity)), Tanpi(NextAfter(p, PositiveInfinity))
+		if !(below.ToFloat64() > 1) || !(above.ToFloat64() < -1) {
+			t.Errorf("around pole %v: tanpi below = %v, above = %v", pole, below, above)
+		}
+	}
+
+	// Sweep against the float64 reference away from poles and integers
+	for _, f := range AllFinite() {
+		x := f.ToFloat64()
+		r := math.Mod(x, 1)
+		if r == 0 || math.Abs(r) == 0.5 {
+			continue
+		}
+		want := FromFloat64(math.Tan(math.Pi * r))
+		if d := UlpDistance(Tanpi(f), want); d > 1 {
+			t.Fatalf("Tanpi(%v) = %v, reference %v (%d ULP)", f, Tanpi(f), want, d)
+		}
+	}
+}
+
+func TestMathDefaultNaN(t *testing.T) {
+	original := GetConfig()
+	defer Configure(original)
+	custom := FromBits(0x7E55)
+	cfg := GetConfig()
+	cfg.DefaultNaN = custom
+	Configure(cfg)
+
+	half := FromFloat32(0.5)
+	for name, got := range map[string]Float16{
+		"Pow(-1, 0.5)": Pow(FromInt(-1), half),
+		"Pow(-3, 1.5)": Pow(FromInt(-3), FromFloat32(1.5)),
+		"Gamma(-1)":    Gamma(FromInt(-1)),
+		"Gamma(-Inf)":  Gamma(NegativeInfinity),
+		"Rsqrt(-1)":    Rsqrt(FromInt(-1)),
+		"Rsqrt(-Inf)":  Rsqrt(NegativeInfinity),
+		"Asin(2)":      Asin(Two16),
+	} {
+		if got != custom {
+			t.Errorf("%s = %#04x, want %#04x", name, got.Bits(), custom.Bits())
+		}
+	}
+
+	// A NaN created from non-NaN operands is always the default NaN
+	unary := map[string]func(Float16) Float16{"Gamma": Gamma, "Rsqrt": Rsqrt}
+	for name, fn := range unary {
+		for i := 0; i < 1<<16; i++ {
+			f := Float16(i)
+			if got := fn(f); !f.IsNaN() && got.IsNaN() && got != custom {
+				t.Fatalf("%s(%#04x) = %#04x, want %#04x", name, i, got.Bits(), custom.Bits())
+			}
+		}
+	}
+	for i := 0; i < 1<<16; i += 61 {
+		for j := 0; j < 1<<16; j += 67 {
+			f, e := Float16(i), Float16(j)
+			if got := Pow(f, e); got.IsNaN() && got != custom {
+				t.Fatalf("Pow(%#04x, %#04x) = %#04x, want %#04x", i, j, got.Bits(), custom.Bits())
+			}
+		}
+	}
+}
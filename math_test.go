@@ -14,8 +14,8 @@ func TestCbrtFunction(t *testing.T) {
 		// Basic test cases
 		{"Cbrt(1.0)", 0x3C00, 0x3C00},  // 1.0 -> 1.0
 		{"Cbrt(8.0)", 0x4800, 0x4000},  // 8.0 -> 2.0
-		{"Cbrt(27.0)", 0x51C0, 0x4240}, // 27.0 -> 3.0
-		{"Cbrt(64.0)", 0x5800, 0x4400}, // 64.0 -> 4.0
+		{"Cbrt(27.0)", 0x4EC0, 0x4200}, // 27.0 -> 3.0
+		{"Cbrt(64.0)", 0x5400, 0x4400}, // 64.0 -> 4.0
 
 		// Additional test cases for better coverage
 		{"Cbrt(0.0)", 0x0000, 0x0000},   // +0.0 -> +0.0
@@ -131,6 +131,86 @@ func TestSqrtNegative(t *testing.T) {
 	}
 }
 
+func TestRsqrtSlice(t *testing.T) {
+	all := make([]Float16, 1<<16)
+	for i := range all {
+		all[i] = Float16(i)
+	}
+	got := RsqrtSlice(all)
+	for i, v := range all {
+		want := Rsqrt(v)
+		if got[i] != want {
+			t.Fatalf("RsqrtSlice[%#04x] = %#04x, want %#04x", i, got[i].Bits(), want.Bits())
+		}
+	}
+
+	s := []Float16{Four16, PositiveZero}
+	RsqrtSliceInto(s, s)
+	if s[0] != Half16 || !s[1].IsInf(1) {
+		t.Errorf("in-place RsqrtSliceInto = %v, want [0.5 +Inf]", s)
+	}
+	if !panics(func() { RsqrtSliceInto(make([]Float16, 1), s) }) {
+		t.Error("RsqrtSliceInto did not panic on length mismatch")
+	}
+}
+
+func TestRsqrtApprox(t *testing.T) {
+	special := []struct {
+		arg, want Float16
+	}{
+		{PositiveZero, PositiveInfinity},
+		{NegativeZero, NegativeInfinity},
+		{PositiveInfinity, PositiveZero},
+	}
+	for _, tt := range special {
+		for it := range 3 {
+			if got := RsqrtApprox(tt.arg, it); got != tt.want {
+				t.Errorf("RsqrtApprox(%v, %d) = %v, want %v", tt.arg, it, got, tt.want)
+			}
+		}
+		if got := Rsqrt(tt.arg); got != tt.want {
+			t.Errorf("Rsqrt(%v) = %v, want %v", tt.arg, got, tt.want)
+		}
+	}
+	for _, f := range []Float16{negOne16, NegativeInfinity, QuietNaN} {
+		if !RsqrtApprox(f, 2).IsNaN() || !Rsqrt(f).IsNaN() {
+			t.Errorf("Rsqrt(%v) or RsqrtApprox(%v, 2) is not NaN", f, f)
+		}
+	}
+	// Negative inputs give the default NaN, as Sqrt does
+	for i := 0x8001; i <= 0xfc00; i++ {
+		f := Float16(i)
+		if got, want := Rsqrt(f), Sqrt(f); got != want || RsqrtApprox(f, 1) != want {
+			t.Fatalf("Rsqrt(%#04x) = %#04x, RsqrtApprox %#04x, want Sqrt's %#04x", i, got.Bits(), RsqrtApprox(f, 1).Bits(), want.Bits())
+		}
+	}
+
+	// The documented bounds on the relative error of the estimate, plus half
+	// an ULP for the final rounding, and the resulting distance from Rsqrt
+	bounds := []struct {
+		iterations int
+		relErr     float64
+		ulp        uint16
+	}{
+		{0, 0.0344, 69},
+		{1, 0.00176, 3},
+		{2, 4.7e-6, 1},
+		{3, 1.4e-7, 0},
+	}
+	for _, b := range bounds {
+		for bits := 1; bits < 0x7C00; bits++ {
+			f := Float16(bits)
+			got, exact := RsqrtApprox(f, b.iterations), 1/math.Sqrt(f.ToFloat64())
+			if rel := math.Abs(got.ToFloat64()-exact) / exact; rel > b.relErr+0x1p-11 {
+				t.Fatalf("RsqrtApprox(%v, %d) = %v, relative error %g", f, b.iterations, got, rel)
+			}
+			if d := UlpDistance(got, Rsqrt(f)); d > b.ulp {
+				t.Fatalf("RsqrtApprox(%v, %d) = %v is %d ULP from Rsqrt %v", f, b.iterations, got, d, Rsqrt(f))
+			}
+		}
+	}
+}
+
 func TestBasicMathFunctions(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -145,7 +225,7 @@ func TestBasicMathFunctions(t *testing.T) {
 		{"Sqrt(2.0)", Sqrt, 0x4000, 0x3DA8, 1e-3},  // 2.0 -> ~1.414 (approximate)
 
 		// Cbrt tests
-		{"Cbrt(27.0)", Cbrt, 0x51C0, 0x4240, 1e-5},  // 27.0 -> 3.0
+		{"Cbrt(27.0)", Cbrt, 0x4EC0, 0x4200, 1e-5},  // 27.0 -> 3.0
 		{"Cbrt(8.0)", Cbrt, 0x4800, 0x4000, 1e-5},   // 8.0 -> 2.0
 		{"Cbrt(1.0)", Cbrt, 0x3C00, 0x3C00, 1e-5},   // 1.0 -> 1.0
 		{"Cbrt(0.125)", Cbrt, 0x3000, 0x3800, 1e-3}, // 0.125 -> 0.5 (approximate)
@@ -360,7 +440,7 @@ func TestMod_Extra(t *testing.T) {
 		{"5.0 mod -3.0", ToFloat16(5.0), ToFloat16(-3.0), ToFloat16(2.0)},
 		{"-5.0 mod -3.0", ToFloat16(-5.0), ToFloat16(-3.0), ToFloat16(-2.0)},
 		{"inf mod 1", PositiveInfinity, ToFloat16(1.0), QuietNaN},
-		{"1 mod inf", ToFloat16(1.0), PositiveInfinity, QuietNaN},
+		{"1 mod inf", ToFloat16(1.0), PositiveInfinity, ToFloat16(1.0)},
 	}
 
 	for _, tt := range tests {
@@ -382,3 +462,243 @@ func TestHypot_Inf(t *testing.T) {
 		t.Errorf("Hypot(inf, nan) = %v, want +Inf", got)
 	}
 }
+
+func TestApply(t *testing.T) {
+	sqrt := func(x float32) float32 { return float32(math.Sqrt(float64(x))) }
+	hypot := func(x, y float32) float32 { return float32(math.Hypot(float64(x), float64(y))) }
+
+	for i := 0; i < 1<<16; i += 7 {
+		f := Float16(i)
+		got, want := Apply(f, sqrt), Sqrt(f)
+		if got != want && !(got.IsNaN() && want.IsNaN()) {
+			t.Fatalf("Apply(%v, sqrt) = %v, want %v", f, got, want)
+		}
+	}
+	for a := 0; a < 1<<16; a += 509 {
+		for b := 0; b < 1<<16; b += 617 {
+			x, y := Float16(a), Float16(b)
+			got, want := Apply2(x, y, hypot), Hypot(x, y)
+			if got != want && !(got.IsNaN() && want.IsNaN()) {
+				t.Fatalf("Apply2(%v, %v, hypot) = %v, want %v", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestPowSlice(t *testing.T) {
+	s := []Float16{PositiveZero, FromFloat32(0.25), One(), FromInt(4), FromInt(-4), PositiveInfinity, QuietNaN}
+	for _, exp := range []Float16{FromFloat32(0.5), FromInt(2), FromFloat32(1 / 2.2), FromInt(-1)} {
+		got := PowSlice(s, exp)
+		for i, v := range s {
+			want := Pow(v, exp)
+			if got[i] != want && !(got[i].IsNaN() && want.IsNaN()) {
+				t.Errorf("PowSlice(..., %v)[%d] = %v, want %v", exp, i, got[i], want)
+			}
+		}
+	}
+	if got := PowSlice([]Float16{FromInt(-4)}, FromFloat32(0.5)); !got[0].IsNaN() {
+		t.Errorf("negative base with fractional exponent = %v, want NaN", got[0])
+	}
+	if got := PowSlice([]Float16{FromInt(-2)}, FromInt(3)); got[0] != FromInt(-8) {
+		t.Errorf("negative base with integer exponent = %v, want -8", got[0])
+	}
+
+	// In place
+	PowSliceInto(s[:4], s[:4], FromInt(2))
+	if s[1] != FromFloat32(0.0625) || s[3] != FromInt(16) {
+		t.Errorf("in-place PowSliceInto = %v", s[:4])
+	}
+}
+
+func TestPowSliceElementwise(t *testing.T) {
+	base := []Float16{FromInt(2), FromInt(9), FromInt(-8), FromInt(-8), FromInt(10)}
+	exp := []Float16{FromInt(10), FromFloat32(0.5), FromFloat32(1.0 / 3), FromInt(2), FromInt(-2)}
+	got := PowSliceElementwise(base, exp)
+	for i := range base {
+		want := Pow(base[i], exp[i])
+		if got[i] != want && !(got[i].IsNaN() && want.IsNaN()) {
+			t.Errorf("PowSliceElementwise()[%d] = %v, want %v", i, got[i], want)
+		}
+	}
+	if !got[2].IsNaN() {
+		t.Errorf("(-8)^(1/3) = %v, want NaN", got[2])
+	}
+
+	for _, tt := range []struct {
+		name           string
+		dst, base, exp []Float16
+	}{
+		{"exp too short", make([]Float16, 2), make([]Float16, 2), make([]Float16, 1)},
+		{"dst too short", make([]Float16, 1), make([]Float16, 2), make([]Float16, 2)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic on length mismatch")
+				}
+			}()
+			PowSliceElementwiseInto(tt.dst, tt.base, tt.exp)
+		})
+	}
+}
+
+func TestMathHotPathConstants(t *testing.T) {
+	// The precomputed constants match what the conversion pipeline produced
+	// before, so no function result changes
+	tests := []struct {
+		name string
+		got  Float16
+		want Float16
+	}{
+		{"One16", One16, FromFloat32(1)},
+		{"negOne16", negOne16, FromFloat32(-1)},
+		{"Two16", Two16, FromFloat32(2)},
+		{"Ten16", Ten16, FromFloat32(10)},
+		{"HalfPi", HalfPi, Div(Pi, FromFloat32(2))},
+		{"HalfPi nearest", HalfPi, ReferenceFromFloat64(math.Pi/2, RoundNearestEven)},
+		{"Tau", Tau, ReferenceFromFloat64(2*math.Pi, RoundNearestEven)},
+		{"One", One(), FromFloat32(1)},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = 0x%04x, want 0x%04x", tt.name, uint16(tt.got), uint16(tt.want))
+		}
+	}
+}
+
+func TestMathConstantPathsExhaustive(t *testing.T) {
+	// Every input, including the special cases that return a constant, gives
+	// the float64 result of the math package function rounded once to Float16
+	funcs := []struct {
+		name string
+		f    func(Float16) Float16
+		ref  func(float64) float64
+	}{
+		{"Exp", Exp, math.Exp},
+		{"Exp2", Exp2, math.Exp2},
+		{"Cos", Cos, math.Cos},
+		{"Cosh", Cosh, math.Cosh},
+		{"Tanh", Tanh, math.Tanh},
+		{"Atan", Atan, math.Atan},
+		{"Erf", Erf, math.Erf},
+		{"Erfc", Erfc, math.Erfc},
+	}
+	for _, fn := range funcs {
+		for b := 0; b < 1<<16; b++ {
+			x := Float16(b)
+			got := fn.f(x)
+			want := FromFloat64(fn.ref(x.ToFloat64()))
+			if got != want && !(got.IsNaN() && want.IsNaN()) {
+				t.Fatalf("%s(%v) = 0x%04x, want 0x%04x", fn.name, x, uint16(got), uint16(want))
+			}
+		}
+	}
+	for _, exp := range []Float16{PositiveZero, NegativeZero} {
+		for b := 0; b < 1<<16; b++ {
+			if got := Pow(Float16(b), exp); got != One16 {
+				t.Fatalf("Pow(0x%04x, %v) = %v, want 1", b, exp, got)
+			}
+		}
+	}
+}
+
+func BenchmarkMath(b *testing.B) {
+	var sink Float16
+	for _, fn := range []struct {
+		name string
+		f    func(Float16) Float16
+		x    Float16
+	}{
+		{"Exp/zero", Exp, PositiveZero},
+		{"Exp/finite", Exp, Half16},
+		{"Cos/zero", Cos, PositiveZero},
+		{"Cos/finite", Cos, Half16},
+		{"Atan/inf", Atan, PositiveInfinity},
+		{"Atan/finite", Atan, Half16},
+	} {
+		b.Run(fn.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sink = fn.f(fn.x)
+			}
+		})
+	}
+	_ = sink
+}
+
+func TestAsinAcosDomain(t *testing.T) {
+	pinned := []struct {
+		name      string
+		got, want Float16
+	}{
+		{"Asin(1)", Asin(One16), HalfPi},
+		{"Asin(-1)", Asin(One16.Neg()), HalfPi.Neg()},
+		{"Acos(1)", Acos(One16), PositiveZero},
+		{"Acos(-1)", Acos(One16.Neg()), Pi},
+		{"Acos(0)", Acos(PositiveZero), HalfPi},
+		{"Acos(-0)", Acos(NegativeZero), HalfPi},
+		{"Asin(-0)", Asin(NegativeZero), NegativeZero},
+	}
+	for _, p := range pinned {
+		if p.got != p.want {
+			t.Errorf("%s = %#04x, want %#04x", p.name, p.got.Bits(), p.want.Bits())
+		}
+	}
+	// The constants are the correctly rounded values
+	if HalfPi != FromFloat64(math.Pi/2) || Pi != FromFloat64(math.Pi) {
+		t.Errorf("HalfPi = %v, Pi = %v are not the nearest Float16 values", HalfPi, Pi)
+	}
+
+	for b := 0; b < 1<<16; b++ {
+		x := Float16(b)
+		if x.IsNaN() {
+			continue
+		}
+		asin, acos := Asin(x), Acos(x)
+		if x.Abs() > One16 {
+			if !asin.IsNaN() || !acos.IsNaN() {
+				t.Fatalf("Asin, Acos(%v) = %v, %v outside the domain, want NaN", x, asin, acos)
+			}
+			continue
+		}
+		if want := FromFloat64(math.Asin(x.ToFloat64())); asin != want {
+			t.Fatalf("Asin(%v) = %#04x, want %#04x", x, asin.Bits(), want.Bits())
+		}
+		if want := FromFloat64(math.Acos(x.ToFloat64())); acos != want {
+			t.Fatalf("Acos(%v) = %#04x, want %#04x", x, acos.Bits(), want.Bits())
+		}
+	}
+}
+
+func TestOverflowThresholds(t *testing.T) {
+	// Each function overflows exactly where the float64 result rounded once
+	// reaches 65520; check every input on both sides of the threshold
+	funcs := []struct {
+		name        string
+		f           func(Float16) Float16
+		ref         func(float64) float64
+		finite, inf Float16 // the last finite and first infinite input
+	}{
+		{"Exp", Exp, math.Exp, FromFloat32(11.0859375), FromFloat32(11.09375)},
+		{"Exp2", Exp2, math.Exp2, FromFloat32(15.9921875), FromFloat32(16)},
+		{"Sinh", Sinh, math.Sinh, FromFloat32(11.78125), FromFloat32(11.7890625)},
+		{"Cosh", Cosh, math.Cosh, FromFloat32(11.78125), FromFloat32(11.7890625)},
+		{"Sinh(-x)", func(f Float16) Float16 { return Sinh(f.Neg()) }, func(x float64) float64 { return math.Sinh(-x) }, FromFloat32(11.78125), FromFloat32(11.7890625)},
+		{"Cosh(-x)", func(f Float16) Float16 { return Cosh(f.Neg()) }, func(x float64) float64 { return math.Cosh(-x) }, FromFloat32(11.78125), FromFloat32(11.7890625)},
+		{"Pow(x, 2)", func(f Float16) Float16 { return Pow(f, Two16) }, func(x float64) float64 { return x * x }, FromFloat32(255.875), FromFloat32(256)},
+		{"Pow(2, x)", func(f Float16) Float16 { return Pow(Two16, f) }, math.Exp2, FromFloat32(15.9921875), FromFloat32(16)},
+	}
+	for _, fn := range funcs {
+		if r := fn.f(fn.finite); !r.IsFinite() {
+			t.Errorf("%s(%v) = %v, want finite", fn.name, fn.finite, r)
+		}
+		if r := fn.f(fn.inf); !r.IsInf(0) {
+			t.Errorf("%s(%v) = %v, want ±Inf", fn.name, fn.inf, r)
+		}
+		for x := fn.finite - 16; x <= fn.inf+16; x++ {
+			got, want := fn.f(x), FromFloat64(fn.ref(x.ToFloat64()))
+			if got.IsInf(0) != want.IsInf(0) || got != want {
+				t.Errorf("%s(%v) = %v, reference %v", fn.name, x, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package float16
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// Conversion metrics
+//
+// A MetricsHook installed through Config.Metrics is notified when conversions
+// to Float16 overflow, underflow or receive NaN input. Slice converters report
+// one batched count per event kind and call, so the hook is invoked at most
+// three times per slice regardless of its length. When no hook is installed
+// the converters skip event detection entirely.
+
+// MetricsHook receives counts of conversion events. Implementations must be
+// safe for concurrent use. Each method is only called with n > 0.
+type MetricsHook interface {
+	// OnOverflow reports n finite inputs that converted to an infinity
+	OnOverflow(n int)
+	// OnUnderflow reports n non-zero inputs that converted to a zero
+	OnUnderflow(n int)
+	// OnNaN reports n NaN inputs
+	OnNaN(n int)
+}
+
+// ConversionCounters is a MetricsHook that accumulates event totals in
+// atomic counters.
+type ConversionCounters struct {
+	overflow  atomic.Int64
+	underflow atomic.Int64
+	nan       atomic.Int64
+}
+
+// OnOverflow implements MetricsHook.
+func (c *ConversionCounters) OnOverflow(n int) { c.overflow.Add(int64(n)) }
+
+// OnUnderflow implements MetricsHook.
+func (c *ConversionCounters) OnUnderflow(n int) { c.underflow.Add(int64(n)) }
+
+// OnNaN implements MetricsHook.
+func (c *ConversionCounters) OnNaN(n int) { c.nan.Add(int64(n)) }
+
+// Overflows returns the number of overflow events recorded
+func (c *ConversionCounters) Overflows() int64 { return c.overflow.Load() }
+
+// Underflows returns the number of underflow events recorded
+func (c *ConversionCounters) Underflows() int64 { return c.underflow.Load() }
+
+// NaNs returns the number of NaN inputs recorded
+func (c *ConversionCounters) NaNs() int64 { return c.nan.Load() }
+
+// Reset sets all counters to zero
+func (c *ConversionCounters) Reset() {
+	c.overflow.Store(0)
+	c.underflow.Store(0)
+	c.nan.Store(0)
+}
+
+// metricsHolder wraps the hook so it can be swapped atomically
+type metricsHolder struct {
+	hook MetricsHook
+}
+
+var activeMetrics atomic.Pointer[metricsHolder]
+
+// setMetricsHook installs h as the active hook; nil disables reporting
+func setMetricsHook(h MetricsHook) {
+	if h == nil {
+		activeMetrics.Store(nil)
+		return
+	}
+	activeMetrics.Store(&metricsHolder{hook: h})
+}
+
+// metricsHook returns the active hook or nil
+func metricsHook() MetricsHook {
+	if m := activeMetrics.Load(); m != nil {
+		return m.hook
+	}
+	return nil
+}
+
+// conversionEvents tallies events for a batch of conversions
+type conversionEvents struct {
+	overflow, underflow, nan int
+}
+
+// observe classifies a single conversion from in to out
+func (e *conversionEvents) observe(in float64, out Float16) {
+	switch {
+	case math.IsNaN(in):
+		e.nan++
+	case out.IsInf(0) && !math.IsInf(in, 0):
+		e.overflow++
+	case out.IsZero() && in != 0:
+		e.underflow++
+	}
+}
+
+// report forwards non-zero tallies to h
+func (e *conversionEvents) report(h MetricsHook) {
+	if e.overflow > 0 {
+		h.OnOverflow(e.overflow)
+	}
+	if e.underflow > 0 {
+		h.OnUnderflow(e.underflow)
+	}
+	if e.nan > 0 {
+		h.OnNaN(e.nan)
+	}
+}
+
+// reportSlice32 records the events of converting src to dst
+func reportSlice32(h MetricsHook, src []float32, dst []Float16) {
+	var e conversionEvents
+	for i, v := range src {
+		e.observe(float64(v), dst[i])
+	}
+	e.report(h)
+}
+
+// reportSlice64 records the events of converting src to dst
+func reportSlice64(h MetricsHook, src []float64, dst []Float16) {
+	var e conversionEvents
+	for i, v := range src {
+		e.observe(v, dst[i])
+	}
+	e.report(h)
+}
+
+// Precision warnings
+
+var activeInexactHook atomic.Pointer[func(float64)]
+
+// setInexactHook installs h as the precision warning hook; nil disables it
+func setInexactHook(h func(float64)) {
+	if h == nil {
+		activeInexactHook.Store(nil)
+		return
+	}
+	activeInexactHook.Store(&h)
+}
+
+// inexactHook returns the precision warning hook or nil
+func inexactHook() func(float64) {
+	if h := activeInexactHook.Load(); h != nil {
+		return *h
+	}
+	return nil
+}
+
+// isInexact reports whether converting in to out lost information. NaN inputs
+// are never reported.
+func isInexact(in float64, out Float16) bool {
+	return !math.IsNaN(in) && out.ToFloat64() != in
+}
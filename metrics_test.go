@@ -0,0 +1,155 @@
+package float16
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// countingHook records every call so batching can be verified
+type countingHook struct {
+	ConversionCounters
+	calls int
+}
+
+func (h *countingHook) OnOverflow(n int)  { h.calls++; h.ConversionCounters.OnOverflow(n) }
+func (h *countingHook) OnUnderflow(n int) { h.calls++; h.ConversionCounters.OnUnderflow(n) }
+func (h *countingHook) OnNaN(n int)       { h.calls++; h.ConversionCounters.OnNaN(n) }
+
+// withMetrics installs h for the duration of the test
+func withMetrics(t *testing.T, h MetricsHook) {
+	t.Helper()
+	original := GetConfig()
+	cfg := GetConfig()
+	cfg.Metrics = h
+	Configure(cfg)
+	t.Cleanup(func() { Configure(original) })
+}
+
+func TestMetricsSliceBatching(t *testing.T) {
+	h := &countingHook{}
+	withMetrics(t, h)
+
+	nan := float32(math.NaN())
+	src := []float32{1, 1e6, -1e6, 1e-9, -1e-9, 1e-10, nan, nan, nan, 0, float32(math.Inf(1)), 2}
+	ToSlice16(src)
+
+	if h.Overflows() != 2 || h.Underflows() != 3 || h.NaNs() != 3 {
+		t.Errorf("counts = (%d, %d, %d), want (2, 3, 3)", h.Overflows(), h.Underflows(), h.NaNs())
+	}
+	if h.calls != 3 {
+		t.Errorf("hook called %d times, want one batched call per event kind", h.calls)
+	}
+
+	h.Reset()
+	h.calls = 0
+	ToSlice16([]float32{1, 2, 3})
+	if h.calls != 0 {
+		t.Errorf("hook called %d times for event-free slice, want 0", h.calls)
+	}
+}
+
+func TestMetricsConverters(t *testing.T) {
+	c := &ConversionCounters{}
+	withMetrics(t, c)
+
+	FromSlice64([]float64{1e300, math.NaN(), 1e-300})
+	ToSlice16WithMode([]float32{7e4, 1e-12}, ModeStrict, RoundNearestEven)
+	FromFloat64WithMode(1e5, ModeIEEE, RoundNearestEven)
+	FromFloat64WithMode(math.NaN(), ModeStrict, RoundNearestEven)
+	FromFloat64WithMode(0.5, ModeIEEE, RoundNearestEven)
+	if _, err := PackFloat32ToFloat16Bytes(make([]byte, 4), []float32{-1e9, 3}, binary.LittleEndian); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Overflows() != 4 || c.Underflows() != 2 || c.NaNs() != 2 {
+		t.Errorf("counts = (%d, %d, %d), want (4, 2, 2)", c.Overflows(), c.Underflows(), c.NaNs())
+	}
+
+	c.Reset()
+	if c.Overflows() != 0 || c.Underflows() != 0 || c.NaNs() != 0 {
+		t.Error("Reset did not clear counters")
+	}
+}
+
+func TestMetricsDisabled(t *testing.T) {
+	c := &ConversionCounters{}
+	withMetrics(t, c)
+
+	cfg := GetConfig()
+	if cfg.Metrics != MetricsHook(c) {
+		t.Error("GetConfig did not return the installed hook")
+	}
+	cfg.Metrics = nil
+	Configure(cfg)
+
+	ToSlice16([]float32{1e9})
+	if c.Overflows() != 0 {
+		t.Error("hook invoked after being removed")
+	}
+}
+
+func benchmarkMetricsInput() []float32 {
+	s := make([]float32, 4096)
+	for i := range s {
+		s[i] = float32(i) * 37.5
+	}
+	return s
+}
+
+func BenchmarkToSlice16NilMetrics(b *testing.B) {
+	s := benchmarkMetricsInput()
+	for i := 0; i < b.N; i++ {
+		ToSlice16(s)
+	}
+}
+
+func BenchmarkToSlice16WithMetrics(b *testing.B) {
+	original := GetConfig()
+	cfg := GetConfig()
+	cfg.Metrics = &ConversionCounters{}
+	Configure(cfg)
+	defer Configure(original)
+
+	s := benchmarkMetricsInput()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ToSlice16(s)
+	}
+}
+
+func TestOnInexactHook(t *testing.T) {
+	original := GetConfig()
+	defer Configure(original)
+
+	var reported []float64
+	cfg := GetConfig()
+	cfg.OnInexact = func(v float64) { reported = append(reported, v) }
+	Configure(cfg)
+
+	if _, err := FromFloat64WithMode(0.5, ModeIEEE, RoundNearestEven); err != nil {
+		t.Fatal(err)
+	}
+	if len(reported) != 0 {
+		t.Fatalf("hook fired for exact value: %v", reported)
+	}
+	if _, err := FromFloat64WithMode(0.1, ModeIEEE, RoundNearestEven); err != nil {
+		t.Fatal(err)
+	}
+	if len(reported) != 1 || reported[0] != 0.1 {
+		t.Fatalf("reported = %v, want [0.1]", reported)
+	}
+
+	reported = nil
+	ToSlice16WithMode([]float32{0.5, 0.1, 2, 1e6, float32(math.NaN())}, ModeIEEE, RoundNearestEven)
+	if len(reported) != 2 || reported[0] != float64(float32(0.1)) || reported[1] != 1e6 {
+		t.Errorf("reported = %v, want [0.1 1e6]", reported)
+	}
+
+	reported = nil
+	Configure(original)
+	FromFloat64WithMode(0.1, ModeIEEE, RoundNearestEven)
+	if len(reported) != 0 {
+		t.Error("nil hook should not report")
+	}
+}
@@ -0,0 +1,127 @@
+package float16
+
+import "fmt"
+
+// Reductions with an explicit NaN policy
+//
+// SumSlice, DotProduct and the other plain reductions propagate NaN only
+// implicitly, through Add. The variants here take a NaNPolicySum, the way
+// NumPy offers both sum and nansum, and check for NaN inside the accumulation
+// loop so that skipping costs no separate pass over the data.
+
+// NaNPolicySum selects how a slice reduction treats NaN elements
+type NaNPolicySum int
+
+const (
+	// NaNSumPropagate makes the result NaN if any element is NaN
+	NaNSumPropagate NaNPolicySum = iota
+	// NaNSumSkip leaves NaN elements out of the reduction and counts them
+	NaNSumSkip
+	// NaNSumError stops at the first NaN element and reports its index
+	NaNSumError
+)
+
+// String returns the name of the policy
+func (p NaNPolicySum) String() string {
+	switch p {
+	case NaNSumPropagate:
+		return "Propagate"
+	case NaNSumSkip:
+		return "Skip"
+	case NaNSumError:
+		return "Error"
+	}
+	return fmt.Sprintf("NaNPolicySum(%d)", int(p))
+}
+
+// nanAt returns the error reported by op under NaNSumError for a NaN at index i
+func nanAt(op string, i int) error {
+	return &Float16Error{
+		Op:   op,
+		Msg:  fmt.Sprintf("NaN at index %d", i),
+		Code: ErrNaN,
+	}
+}
+
+// reduceNaN folds the elements of s into init with fold, treating NaNs as
+// policy says. n is the number of skipped elements, or the index of the first
+// NaN when err is set.
+func reduceNaN(op string, s []Float16, policy NaNPolicySum, init Float16, fold func(Float16, Float16) Float16) (result Float16, n int, err error) {
+	result = init
+	for i, v := range s {
+		if v.IsNaN() {
+			switch policy {
+			case NaNSumSkip:
+				n++
+				continue
+			case NaNSumError:
+				return defaultNaN(), i, nanAt(op, i)
+			}
+			return defaultNaN(), 0, nil
+		}
+		result = fold(result, v)
+	}
+	return result, n, nil
+}
+
+// SumSliceNaN returns the sum of s, accumulated with Add as in SumSlice, with
+// NaN elements handled according to policy. Under NaNSumSkip, n is the number
+// of NaNs left out; under NaNSumError the sum stops at the first NaN and n is
+// its index, which the returned ErrNaN error also reports. Otherwise n is 0.
+// Unknown policies propagate. An empty slice sums to +0.
+func SumSliceNaN(s []Float16, policy NaNPolicySum) (sum Float16, n int, err error) {
+	return reduceNaN("SumSliceNaN", s, policy, PositiveZero, Add)
+}
+
+// DotProductNaN returns the dot product of a and b as DotProduct computes it,
+// with pairs holding a NaN handled according to policy; n counts skipped pairs
+// or gives the index of the first NaN pair as in SumSliceNaN. It panics if the
+// lengths differ.
+func DotProductNaN(a, b []Float16, policy NaNPolicySum) (dot Float16, n int, err error) {
+	if len(a) != len(b) {
+		panic("float16: slice length mismatch")
+	}
+	dot = PositiveZero
+	for i := range a {
+		if a[i].IsNaN() || b[i].IsNaN() {
+			switch policy {
+			case NaNSumSkip:
+				n++
+				continue
+			case NaNSumError:
+				return defaultNaN(), i, nanAt("DotProductNaN", i)
+			}
+			return defaultNaN(), 0, nil
+		}
+		dot = Add(dot, Mul(a[i], b[i]))
+	}
+	return dot, n, nil
+}
+
+// MeanSliceNaN returns the mean of s, computed as in ComputeSliceStats, with
+// NaN elements handled according to policy; skipped NaNs do not count toward
+// the length. n is as in SumSliceNaN. The mean of an empty slice, or of one
+// holding only skipped NaNs, is NaN.
+func MeanSliceNaN(s []Float16, policy NaNPolicySum) (mean Float16, n int, err error) {
+	sum, n, err := reduceNaN("MeanSliceNaN", s, policy, PositiveZero, Add)
+	if err != nil || len(s) == n {
+		return defaultNaN(), n, err
+	}
+	return Div(sum, FromFloat32(float32(len(s)-n))), n, nil
+}
+
+// MinSliceNaN returns the smallest element of s, with NaN elements handled
+// according to policy; n is as in SumSliceNaN. Under NaNSumSkip it agrees
+// with SliceMin. The result is NaN for an empty slice or one holding only
+// skipped NaNs.
+func MinSliceNaN(s []Float16, policy NaNPolicySum) (min Float16, n int, err error) {
+	return reduceNaN("MinSliceNaN", s, policy, defaultNaN(), Min)
+}
+
+// MaxSliceNaN returns the largest element of s, with NaN elements handled
+// according to policy; n is as in SumSliceNaN. Under NaNSumSkip it agrees
+// with SliceMax. The result is NaN for an empty slice or one holding only
+// skipped NaNs.
+func MaxSliceNaN(s []Float16, policy NaNPolicySum) (max Float16, n int, err error) {
+	return reduceNaN("MaxSliceNaN", s, policy, defaultNaN(), Max)
+}
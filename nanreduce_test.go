@@ -0,0 +1,133 @@
+package float16
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNaNPolicyReductions(t *testing.T) {
+	nan := QuietNaN
+	reductions := []struct {
+		name   string
+		reduce func([]Float16, NaNPolicySum) (Float16, int, error)
+	}{
+		{"SumSliceNaN", SumSliceNaN},
+		{"DotProductNaN", func(s []Float16, p NaNPolicySum) (Float16, int, error) {
+			ones := make([]Float16, len(s))
+			for i := range ones {
+				ones[i] = One16
+			}
+			return DotProductNaN(s, ones, p)
+		}},
+		{"MeanSliceNaN", MeanSliceNaN},
+		{"MinSliceNaN", MinSliceNaN},
+		{"MaxSliceNaN", MaxSliceNaN},
+	}
+	// want holds the Skip results of the reductions in the order above
+	tests := []struct {
+		name    string
+		s       []Float16
+		want    []Float16
+		skipped int
+		first   int // index of the first NaN, or -1
+	}{
+		{"no NaN", []Float16{One16, Three16, Two16}, []Float16{FromFloat32(6), FromFloat32(6), Two16, One16, Three16}, 0, -1},
+		{"leading", []Float16{nan, One16, Three16}, []Float16{Four16, Four16, Two16, One16, Three16}, 1, 0},
+		{"trailing", []Float16{Two16, Four16, nan, nan}, []Float16{FromFloat32(6), FromFloat32(6), Three16, Two16, Four16}, 2, 2},
+		{"only NaN", []Float16{nan, SignalingNaN}, []Float16{PositiveZero, PositiveZero, nan, nan, nan}, 2, 0},
+		{"empty", nil, []Float16{PositiveZero, PositiveZero, nan, nan, nan}, 0, -1},
+	}
+	for _, tt := range tests {
+		for j, r := range reductions {
+			t.Run(tt.name+"/"+r.name, func(t *testing.T) {
+				want := tt.want[j]
+				same := func(got Float16) bool {
+					return got == want || (got.IsNaN() && want.IsNaN())
+				}
+
+				got, n, err := r.reduce(tt.s, NaNSumSkip)
+				if !same(got) || n != tt.skipped || err != nil {
+					t.Errorf("Skip = (%v, %d, %v), want (%v, %d, nil)", got, n, err, want, tt.skipped)
+				}
+
+				got, n, err = r.reduce(tt.s, NaNSumPropagate)
+				if tt.first >= 0 {
+					if !got.IsNaN() || n != 0 || err != nil {
+						t.Errorf("Propagate = (%v, %d, %v), want (NaN, 0, nil)", got, n, err)
+					}
+				} else if !same(got) || n != 0 || err != nil {
+					t.Errorf("Propagate = (%v, %d, %v), want (%v, 0, nil)", got, n, err, want)
+				}
+
+				got, n, err = r.reduce(tt.s, NaNSumError)
+				if tt.first < 0 {
+					if !same(got) || n != 0 || err != nil {
+						t.Errorf("Error = (%v, %d, %v), want (%v, 0, nil)", got, n, err, want)
+					}
+					return
+				}
+				var fe *Float16Error
+				if !got.IsNaN() || n != tt.first || !errors.As(err, &fe) || fe.Code != ErrNaN {
+					t.Errorf("Error = (%v, %d, %v), want (NaN, %d, ErrNaN)", got, n, err, tt.first)
+				}
+			})
+		}
+	}
+}
+
+func TestNaNPolicyReductionsAgree(t *testing.T) {
+	s := GenerateBenchData(1000, ProfileMixedSpecial)
+	sum, _, _ := SumSliceNaN(s, NaNSumPropagate)
+	if want := SumSlice(s); sum != want && !(sum.IsNaN() && want.IsNaN()) {
+		t.Errorf("SumSliceNaN(Propagate) = %v, SumSlice = %v", sum, want)
+	}
+	if min, _, _ := MinSliceNaN(s, NaNSumSkip); min != SliceMin(s) {
+		t.Errorf("MinSliceNaN(Skip) = %v, SliceMin = %v", min, SliceMin(s))
+	}
+	if max, _, _ := MaxSliceNaN(s, NaNSumSkip); max != SliceMax(s) {
+		t.Errorf("MaxSliceNaN(Skip) = %v, SliceMax = %v", max, SliceMax(s))
+	}
+
+	// Skipping matches reducing the slice with its NaNs filtered out
+	var clean []Float16
+	for _, v := range s {
+		if !v.IsNaN() {
+			clean = append(clean, v)
+		}
+	}
+	sum, n, _ := SumSliceNaN(s, NaNSumSkip)
+	if sum != SumSlice(clean) || n != len(s)-len(clean) {
+		t.Errorf("SumSliceNaN(Skip) = (%v, %d), want (%v, %d)", sum, n, SumSlice(clean), len(s)-len(clean))
+	}
+	if mean, _, _ := MeanSliceNaN(s, NaNSumSkip); mean != ComputeSliceStats(clean).Mean {
+		t.Errorf("MeanSliceNaN(Skip) = %v, want %v", mean, ComputeSliceStats(clean).Mean)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("DotProductNaN with mismatched lengths did not panic")
+		}
+	}()
+	DotProductNaN([]Float16{One16}, nil, NaNSumSkip)
+}
+
+func BenchmarkSumSliceNaNSkip(b *testing.B) {
+	s := GenerateBenchData(4096, ProfileMixedSpecial)
+	b.Run("fused", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			SumSliceNaN(s, NaNSumSkip)
+		}
+	})
+	b.Run("scan-then-sum", func(b *testing.B) {
+		clean := make([]Float16, 0, len(s))
+		for i := 0; i < b.N; i++ {
+			clean = clean[:0]
+			for _, v := range s {
+				if !v.IsNaN() {
+					clean = append(clean, v)
+				}
+			}
+			SumSlice(clean)
+		}
+	})
+}
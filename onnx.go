@@ -0,0 +1,39 @@
+package float16
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// ONNX export
+//
+// An ONNX TensorProto with data_type FLOAT16 (10) holds its elements either
+// in raw_data, as consecutive 2-byte little-endian IEEE binary16 bit
+// patterns, or in int32_data, one bit pattern per int32 entry zero-extended
+// from uint16. ONNX does not pack two values into one int32. Float16 is
+// already IEEE binary16, so both layouts carry the bit patterns unchanged.
+
+// ToONNXBits returns the bit patterns of s as the uint16 values ONNX stores
+// for FLOAT16 tensors. Converting each to int32 gives the int32_data entries.
+func ToONNXBits(s []Float16) []uint16 {
+	bits := make([]uint16, len(s))
+	for i, v := range s {
+		bits[i] = uint16(v)
+	}
+	return bits
+}
+
+// WriteONNXFloat16 writes s to w in the raw_data layout of an ONNX FLOAT16
+// tensor: 2 bytes per element, little-endian, with no header or length.
+// ReadSlice with binary.LittleEndian decodes it.
+func WriteONNXFloat16(w io.Writer, s []Float16) error {
+	buf := make([]byte, 2*len(s))
+	if _, err := PutSlice(buf, s, binary.LittleEndian); err != nil {
+		return err
+	}
+	if _, err := w.Write(buf); err != nil {
+		return fmt.Errorf("float16 WriteONNXFloat16: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package float16
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// onnxRaw is the raw_data of an ONNX FLOAT16 tensor holding
+// [1, -2, 0.5, -0, +Inf, 65504]
+var onnxRaw = []byte{0x00, 0x3c, 0x00, 0xc0, 0x00, 0x38, 0x00, 0x80, 0x00, 0x7c, 0xff, 0x7b}
+
+func TestONNXFloat16(t *testing.T) {
+	s := []Float16{One16, FromFloat32(-2), Half16, NegativeZero, PositiveInfinity, MaxValue}
+
+	bits := ToONNXBits(s)
+	for i, v := range s {
+		if bits[i] != v.Bits() {
+			t.Errorf("ToONNXBits[%d] = %#04x, want %#04x", i, bits[i], v.Bits())
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteONNXFloat16(&buf, s); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), onnxRaw) {
+		t.Errorf("WriteONNXFloat16 = % x, want % x", buf.Bytes(), onnxRaw)
+	}
+	back, err := ReadSlice(onnxRaw, binary.LittleEndian)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range s {
+		if back[i] != s[i] {
+			t.Errorf("decoded[%d] = %v, want %v", i, back[i], s[i])
+		}
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestWriteONNXFloat16Error(t *testing.T) {
+	err := WriteONNXFloat16(failingWriter{}, []Float16{One16})
+	if err == nil || err.Error() != "float16 WriteONNXFloat16: disk full" {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package float16
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Nullable values
+
+// OptionalFloat16 is a Float16 that may be null. Null is tracked separately
+// from NaN, so data sets where NaN is a legitimate value can still mark
+// missing entries. The zero value is null.
+type OptionalFloat16 struct {
+	Value Float16
+	Valid bool
+}
+
+// NewOptionalFloat16 returns a non-null OptionalFloat16 holding v
+func NewOptionalFloat16(v Float16) OptionalFloat16 {
+	return OptionalFloat16{Value: v, Valid: true}
+}
+
+// String returns "null" for a null value and v.Value.String() otherwise
+func (o OptionalFloat16) String() string {
+	if !o.Valid {
+		return "null"
+	}
+	return o.Value.String()
+}
+
+// Equal reports whether o and p are both null, or both non-null with values
+// that compare equal under IEEE 754 rules. A NaN value is therefore not equal
+// to itself, while two nulls are.
+func (o OptionalFloat16) Equal(p OptionalFloat16) bool {
+	if !o.Valid || !p.Valid {
+		return o.Valid == p.Valid
+	}
+	return Equal(o.Value, p.Value)
+}
+
+// Compare returns -1, 0 or +1 ordering o relative to p. Nulls sort before all
+// values and values follow IEEE 754 totalOrder (see Float16.OrderedKey), so
+// the result is a strict weak ordering suitable for slices.SortFunc.
+func (o OptionalFloat16) Compare(p OptionalFloat16) int {
+	if !o.Valid || !p.Valid {
+		switch {
+		case o.Valid:
+			return 1
+		case p.Valid:
+			return -1
+		}
+		return 0
+	}
+	return cmp.Compare(o.Value.OrderedKey(), p.Value.OrderedKey())
+}
+
+// MarshalJSON implements json.Marshaler. A null value encodes as null, NaN
+// and infinities as the strings "NaN", "+Inf" and "-Inf", and finite values as
+// the shortest number that round-trips.
+func (o OptionalFloat16) MarshalJSON() ([]byte, error) {
+	if !o.Valid {
+		return []byte("null"), nil
+	}
+	if !o.Value.IsFinite() {
+		return []byte(`"` + o.Value.String() + `"`), nil
+	}
+	return []byte(FormatFloat(o.Value, 'g', -2)), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting everything
+// MarshalJSON produces.
+func (o *OptionalFloat16) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		*o = OptionalFloat16{}
+		return nil
+	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	v, err := parseOptionalText(s)
+	if err != nil {
+		return fmt.Errorf("float16 OptionalFloat16.UnmarshalJSON: %w", err)
+	}
+	*o = NewOptionalFloat16(v)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. A null value encodes as
+// empty text, matching an empty CSV cell; other values encode as in
+// MarshalJSON without quotes.
+func (o OptionalFloat16) MarshalText() ([]byte, error) {
+	if !o.Valid {
+		return nil, nil
+	}
+	if !o.Value.IsFinite() {
+		return []byte(o.Value.String()), nil
+	}
+	return []byte(FormatFloat(o.Value, 'g', -2)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Empty text decodes as
+// null.
+func (o *OptionalFloat16) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*o = OptionalFloat16{}
+		return nil
+	}
+	v, err := parseOptionalText(string(text))
+	if err != nil {
+		return fmt.Errorf("float16 OptionalFloat16.UnmarshalText: %w", err)
+	}
+	*o = NewOptionalFloat16(v)
+	return nil
+}
+
+// parseOptionalText parses "NaN", an optionally signed "Inf", or a decimal
+// rounded to nearest
+func parseOptionalText(s string) (Float16, error) {
+	switch s {
+	case "NaN":
+		return defaultNaN(), nil
+	case "Inf", "+Inf":
+		return PositiveInfinity, nil
+	case "-Inf":
+		return NegativeInfinity, nil
+	}
+	return ParseDebugString(s)
+}
+
+// OptionalFromSlice converts s to nullable form, treating every element whose
+// bit pattern equals sentinel as null. Comparing bits lets a particular NaN
+// serve as the sentinel without nulling other NaNs. FillNulls with the same
+// sentinel reverses it.
+func OptionalFromSlice(s []Float16, sentinel Float16) []OptionalFloat16 {
+	result := make([]OptionalFloat16, len(s))
+	for i, v := range s {
+		result[i] = OptionalFloat16{Value: v, Valid: v != sentinel}
+	}
+	return result
+}
+
+// FillNulls returns the values of s with every null replaced by v
+func FillNulls(s []OptionalFloat16, v Float16) []Float16 {
+	result := make([]Float16, len(s))
+	for i, o := range s {
+		if o.Valid {
+			result[i] = o.Value
+		} else {
+			result[i] = v
+		}
+	}
+	return result
+}
+
+// MaskNulls returns a mask that is true at the positions of s that are null
+func MaskNulls(s []OptionalFloat16) []bool {
+	mask := make([]bool, len(s))
+	for i, o := range s {
+		mask[i] = !o.Valid
+	}
+	return mask
+}
+
+// ComputeOptionalSliceStats is ComputeSliceStats for nullable data. Nulls are
+// always skipped and counted in nulls; Length counts only the values used.
+// NaN values are kept, and so propagate into Sum and Mean, unless skipNaN is
+// set, in which case they are skipped as well but not counted as nulls.
+func ComputeOptionalSliceStats(s []OptionalFloat16, skipNaN bool) (stats SliceStats, nulls int) {
+	values := make([]Float16, 0, len(s))
+	for _, o := range s {
+		switch {
+		case !o.Valid:
+			nulls++
+		case skipNaN && o.Value.IsNaN():
+		default:
+			values = append(values, o.Value)
+		}
+	}
+	return ComputeSliceStats(values), nulls
+}
@@ -0,0 +1,166 @@
+package float16
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestOptionalFloat16JSON(t *testing.T) {
+	in := []OptionalFloat16{
+		NewOptionalFloat16(FromFloat32(1.5)),
+		{},
+		NewOptionalFloat16(QuietNaN),
+		NewOptionalFloat16(NegativeInfinity),
+		NewOptionalFloat16(NegativeZero),
+		NewOptionalFloat16(FromFloat32(0.1)),
+	}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if want := `[1.5,null,"NaN","-Inf",-0,0.1]`; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	var out []OptionalFloat16
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(out) != len(in) {
+		t.Fatalf("Unmarshal gave %d elements, want %d", len(out), len(in))
+	}
+	for i := range in {
+		if out[i].Valid != in[i].Valid || out[i].Value != in[i].Value {
+			t.Errorf("element %d: got %+v, want %+v", i, out[i], in[i])
+		}
+	}
+
+	t.Run("struct field", func(t *testing.T) {
+		var row struct{ X, Y OptionalFloat16 }
+		if err := json.Unmarshal([]byte(`{"X":null,"Y":"+Inf"}`), &row); err != nil {
+			t.Fatalf("Unmarshal: %v", err)
+		}
+		if row.X.Valid || !row.Y.Valid || row.Y.Value != PositiveInfinity {
+			t.Errorf("Unmarshal = %+v", row)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, s := range []string{`"abc"`, `true`, `"0x1p-2"`} {
+			var o OptionalFloat16
+			if err := json.Unmarshal([]byte(s), &o); err == nil {
+				t.Errorf("Unmarshal(%s) = %+v, want error", s, o)
+			}
+		}
+	})
+}
+
+func TestOptionalFloat16Text(t *testing.T) {
+	tests := []struct {
+		name string
+		o    OptionalFloat16
+		text string
+	}{
+		{"null", OptionalFloat16{}, ""},
+		{"value", NewOptionalFloat16(FromFloat32(-2.25)), "-2.25"},
+		{"NaN", NewOptionalFloat16(QuietNaN), "NaN"},
+		{"Inf", NewOptionalFloat16(PositiveInfinity), "+Inf"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := tt.o.MarshalText()
+			if err != nil || string(text) != tt.text {
+				t.Fatalf("MarshalText = (%q, %v), want %q", text, err, tt.text)
+			}
+			var back OptionalFloat16
+			if err := back.UnmarshalText(text); err != nil {
+				t.Fatalf("UnmarshalText(%q): %v", text, err)
+			}
+			if back.Valid != tt.o.Valid || back.Value != tt.o.Value {
+				t.Errorf("UnmarshalText(%q) = %+v, want %+v", text, back, tt.o)
+			}
+		})
+	}
+}
+
+func TestOptionalFloat16Compare(t *testing.T) {
+	null, one, nan := OptionalFloat16{}, NewOptionalFloat16(One16), NewOptionalFloat16(QuietNaN)
+
+	if !null.Equal(null) || null.Equal(one) || one.Equal(null) || !one.Equal(one) {
+		t.Error("Equal mishandles nulls")
+	}
+	if nan.Equal(nan) {
+		t.Error("NaN values compare equal")
+	}
+	if !NewOptionalFloat16(NegativeZero).Equal(NewOptionalFloat16(PositiveZero)) {
+		t.Error("-0 and +0 compare unequal")
+	}
+
+	s := []OptionalFloat16{nan, one, null, NewOptionalFloat16(NegativeInfinity), null}
+	slices.SortFunc(s, OptionalFloat16.Compare)
+	want := []OptionalFloat16{null, null, NewOptionalFloat16(NegativeInfinity), one, nan}
+	for i := range want {
+		if s[i] != want[i] {
+			t.Errorf("sorted[%d] = %v, want %v", i, s[i], want[i])
+		}
+	}
+}
+
+func TestOptionalSliceConversion(t *testing.T) {
+	sentinel := Float16(0x7e01) // a NaN distinct from QuietNaN
+	plain := []Float16{One16, sentinel, QuietNaN, Two16, sentinel}
+
+	opt := OptionalFromSlice(plain, sentinel)
+	if got, want := MaskNulls(opt), []bool{false, true, false, false, true}; !slices.Equal(got, want) {
+		t.Errorf("MaskNulls = %v, want %v", got, want)
+	}
+	if !opt[2].Valid || !opt[2].Value.IsNaN() {
+		t.Errorf("QuietNaN became %+v, want a valid NaN", opt[2])
+	}
+	if back := FillNulls(opt, sentinel); !slices.Equal(back, plain) {
+		t.Errorf("FillNulls(sentinel) = %v, want %v", back, plain)
+	}
+	if got := FillNulls(opt, PositiveZero); got[1] != PositiveZero || got[4] != PositiveZero {
+		t.Errorf("FillNulls(0) = %v", got)
+	}
+	if len(FillNulls(nil, One16)) != 0 || len(MaskNulls(nil)) != 0 {
+		t.Error("empty input gave non-empty output")
+	}
+}
+
+func TestComputeOptionalSliceStats(t *testing.T) {
+	s := []OptionalFloat16{
+		NewOptionalFloat16(FromFloat32(1)),
+		{},
+		NewOptionalFloat16(QuietNaN),
+		NewOptionalFloat16(FromFloat32(3)),
+		{},
+		NewOptionalFloat16(FromFloat32(-2)),
+	}
+
+	stats, nulls := ComputeOptionalSliceStats(s, true)
+	if nulls != 2 || stats.Length != 3 {
+		t.Errorf("skipNaN: nulls = %d, Length = %d, want 2 and 3", nulls, stats.Length)
+	}
+	if stats.Sum.ToFloat32() != 2 || stats.Min.ToFloat32() != -2 || stats.Max.ToFloat32() != 3 {
+		t.Errorf("skipNaN: stats = %+v", stats)
+	}
+	if got := stats.Mean.ToFloat64(); math.Abs(got-2.0/3) > 1e-3 {
+		t.Errorf("skipNaN: Mean = %v, want 2/3", got)
+	}
+
+	stats, nulls = ComputeOptionalSliceStats(s, false)
+	if nulls != 2 || stats.Length != 4 {
+		t.Errorf("keep NaN: nulls = %d, Length = %d, want 2 and 4", nulls, stats.Length)
+	}
+	if !stats.Sum.IsNaN() || !stats.Mean.IsNaN() {
+		t.Errorf("keep NaN: Sum = %v, Mean = %v, want NaN", stats.Sum, stats.Mean)
+	}
+
+	stats, nulls = ComputeOptionalSliceStats([]OptionalFloat16{{}, {}}, true)
+	if nulls != 2 || stats != (SliceStats{}) {
+		t.Errorf("all null: (%+v, %d)", stats, nulls)
+	}
+}
@@ -0,0 +1,160 @@
+package float16
+
+import "slices"
+
+// Monotone integer keys
+
+// OrderedKey returns an unsigned key whose natural ordering matches the IEEE
+// 754 totalOrder predicate: negative NaNs < -Inf < negative finite values <
+// -0 < +0 < positive finite values < +Inf < positive NaNs. NaNs with larger
+// payloads sort further from zero. Every bit pattern maps to a distinct key.
+func (f Float16) OrderedKey() uint16 {
+	if f&SignMask != 0 {
+		return ^uint16(f)
+	}
+	return uint16(f) | SignMask
+}
+
+// FromOrderedKey is the inverse of Float16.OrderedKey.
+func FromOrderedKey(k uint16) Float16 {
+	if k&SignMask != 0 {
+		return Float16(k &^ SignMask)
+	}
+	return Float16(^k)
+}
+
+// TotalOrderInt returns a signed integer such that a.TotalOrderInt() <
+// b.TotalOrderInt() exactly when a precedes b in IEEE 754 totalOrder. Positive
+// values map to their bit pattern (0 to 32767) and negative values to the
+// negated magnitude minus one (-1 for -0 down to -32768), so the key is
+// symmetric about -0.5 and suitable for delta coding and radix sorting.
+func (f Float16) TotalOrderInt() int32 {
+	if f&SignMask != 0 {
+		return -int32(f&^SignMask) - 1
+	}
+	return int32(f)
+}
+
+// FromTotalOrderInt is the inverse of Float16.TotalOrderInt. Values outside
+// [-32768, 32767] are clamped to that range, which maps them to the NaNs with
+// the largest payloads.
+func FromTotalOrderInt(i int32) Float16 {
+	i = max(min(i, 0x7FFF), -0x8000)
+	if i < 0 {
+		return Float16(-(i + 1)) | SignMask
+	}
+	return Float16(i)
+}
+
+// AllFinite returns every finite Float16 value in ascending numeric order,
+// with -0 immediately before +0. It is intended for exhaustive tests.
+func AllFinite() []Float16 {
+	result := make([]Float16, 0, 2*int(PositiveInfinity))
+	for b := int(MaxValue); b >= 0; b-- {
+		result = append(result, Float16(b)|SignMask)
+	}
+	for b := 0; b <= int(MaxValue); b++ {
+		result = append(result, Float16(b))
+	}
+	return result
+}
+
+// Ordered16 is a Float16 re-encoded as its OrderedKey. Because the underlying
+// type is uint16 it satisfies cmp.Ordered, and its natural ordering is the
+// IEEE 754 totalOrder of the wrapped value, so it can be used directly with
+// slices.Sort, slices.BinarySearch, min/max and ordered generic containers.
+// Equality is bitwise: -0 and +0 are distinct and NaNs compare equal to
+// themselves.
+type Ordered16 uint16
+
+// NewOrdered16 returns the Ordered16 for f.
+func NewOrdered16(f Float16) Ordered16 {
+	return Ordered16(f.OrderedKey())
+}
+
+// Value returns the wrapped Float16.
+func (o Ordered16) Value() Float16 {
+	return FromOrderedKey(uint16(o))
+}
+
+// String returns the string form of the wrapped value.
+func (o Ordered16) String() string {
+	return o.Value().String()
+}
+
+// ToOrdered16Slice converts each element of s to an Ordered16.
+func ToOrdered16Slice(s []Float16) []Ordered16 {
+	result := make([]Ordered16, len(s))
+	for i, v := range s {
+		result[i] = NewOrdered16(v)
+	}
+	return result
+}
+
+// FromOrdered16Slice converts each element of s back to a Float16.
+func FromOrdered16Slice(s []Ordered16) []Float16 {
+	result := make([]Float16, len(s))
+	for i, v := range s {
+		result[i] = v.Value()
+	}
+	return result
+}
+
+// IsSortedAscending reports whether s is in non-decreasing numeric order.
+// Equal neighbours are allowed and -0 equals +0. A slice containing any NaN
+// is never sorted, since NaN is unordered.
+func IsSortedAscending(s []Float16) bool {
+	for i, v := range s {
+		if v.IsNaN() || (i > 0 && Less(v, s[i-1])) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSortedDescending reports whether s is in non-increasing numeric order,
+// with the same equality and NaN rules as IsSortedAscending.
+func IsSortedDescending(s []Float16) bool {
+	for i, v := range s {
+		if v.IsNaN() || (i > 0 && Less(s[i-1], v)) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsMonotonic reports whether s is sorted in either direction.
+func IsMonotonic(s []Float16) bool {
+	return IsSortedAscending(s) || IsSortedDescending(s)
+}
+
+// UniqueSorted returns the distinct values of s in ascending numeric order,
+// for example to build a codebook. -0 and +0 are equal and appear once, as
+// +0. All NaNs, whatever their sign or payload, collapse into a single
+// default NaN (QuietNaN unless Config.DefaultNaN says otherwise) placed at
+// the end. Sorting uses TotalOrderInt keys, so it costs one integer sort.
+func UniqueSorted(s []Float16) []Float16 {
+	keys := make([]int32, 0, len(s))
+	hasNaN := false
+	for _, v := range s {
+		switch {
+		case v.IsNaN():
+			hasNaN = true
+		case v.IsZero():
+			keys = append(keys, PositiveZero.TotalOrderInt())
+		default:
+			keys = append(keys, v.TotalOrderInt())
+		}
+	}
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+
+	result := make([]Float16, len(keys), len(keys)+1)
+	for i, k := range keys {
+		result[i] = FromTotalOrderInt(k)
+	}
+	if hasNaN {
+		result = append(result, defaultNaN())
+	}
+	return result
+}
@@ -0,0 +1,209 @@
+package float16
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestOrderedKeyRoundTrip(t *testing.T) {
+	for i := 0; i < 1<<16; i++ {
+		f := Float16(i)
+		if got := FromOrderedKey(f.OrderedKey()); got != f {
+			t.Fatalf("FromOrderedKey(%#04x.OrderedKey()) = %#04x", i, got.Bits())
+		}
+	}
+}
+
+func TestOrderedKeyMonotone(t *testing.T) {
+	ordered := []Float16{
+		NegativeQNaN, NegativeInfinity, MinValue, FromFloat32(-1), FromBits(0x8001),
+		NegativeZero, PositiveZero, SmallestSubnormal, SmallestNormal, One(), MaxValue,
+		PositiveInfinity, SignalingNaN, QuietNaN,
+	}
+	for i := 1; i < len(ordered); i++ {
+		if ordered[i-1].OrderedKey() >= ordered[i].OrderedKey() {
+			t.Errorf("key(%v) >= key(%v)", ordered[i-1], ordered[i])
+		}
+	}
+
+	// For non-NaN values the key order agrees with Less
+	for a := 0; a < 1<<16; a += 97 {
+		for b := 0; b < 1<<16; b += 89 {
+			fa, fb := Float16(a), Float16(b)
+			if fa.IsNaN() || fb.IsNaN() || (fa.IsZero() && fb.IsZero()) {
+				continue
+			}
+			if Less(fa, fb) != (fa.OrderedKey() < fb.OrderedKey()) {
+				t.Fatalf("order mismatch for %v and %v", fa, fb)
+			}
+		}
+	}
+}
+
+func TestTotalOrderInt(t *testing.T) {
+	all := AllFinite()
+	if len(all) != 2*0x7C00 {
+		t.Fatalf("AllFinite() has %d values, want %d", len(all), 2*0x7C00)
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i-1].TotalOrderInt() >= all[i].TotalOrderInt() {
+			t.Fatalf("TotalOrderInt not increasing at %v, %v", all[i-1], all[i])
+		}
+		if !all[i-1].IsZero() && !Less(all[i-1], all[i]) {
+			t.Fatalf("AllFinite not ascending at %v, %v", all[i-1], all[i])
+		}
+	}
+
+	for i := 0; i < 1<<16; i++ {
+		f := Float16(i)
+		k := f.TotalOrderInt()
+		if k < -0x8000 || k > 0x7FFF {
+			t.Fatalf("TotalOrderInt(%#04x) = %d out of range", i, k)
+		}
+		if got := FromTotalOrderInt(k); got != f {
+			t.Fatalf("FromTotalOrderInt(%d) = %#04x, want %#04x", k, got.Bits(), i)
+		}
+		// Agrees with the unsigned OrderedKey
+		if int32(f.OrderedKey())-0x8000 != k {
+			t.Fatalf("TotalOrderInt(%#04x) = %d disagrees with OrderedKey %d", i, k, f.OrderedKey())
+		}
+	}
+
+	if NegativeZero.TotalOrderInt() != -1 || PositiveZero.TotalOrderInt() != 0 {
+		t.Error("zeros should map to -1 and 0")
+	}
+	if FromTotalOrderInt(1<<20) != FromBits(0x7FFF) || FromTotalOrderInt(-1<<20) != FromBits(0xFFFF) {
+		t.Error("out-of-range inputs should clamp")
+	}
+}
+
+// sortedOrdered returns a sorted copy of s; it compiles only for cmp.Ordered types
+func sortedOrdered[T cmp.Ordered](s []T) []T {
+	s = slices.Clone(s)
+	slices.Sort(s)
+	return s
+}
+
+func TestOrdered16Sort(t *testing.T) {
+	values := []Float16{
+		QuietNaN, QuietNaN | SignMask, FromBits(0x7C01), FromBits(0xFE00 | 0x0123),
+		PositiveInfinity, NegativeInfinity, PositiveZero, NegativeZero,
+		MaxValue, MinValue, SmallestSubnormal, SmallestSubnormal | SignMask,
+		One(), FromInt(-1), FromFloat32(0.5), FromFloat32(-1000),
+	}
+	rng := rand.New(rand.NewSource(3))
+	rng.Shuffle(len(values), func(i, j int) { values[i], values[j] = values[j], values[i] })
+
+	got := FromOrdered16Slice(sortedOrdered(ToOrdered16Slice(values)))
+	want := slices.Clone(values)
+	slices.SortFunc(want, func(a, b Float16) int { return cmp.Compare(a.TotalOrderInt(), b.TotalOrderInt()) })
+	if !slices.Equal(got, want) {
+		t.Fatalf("sorted Ordered16 = %v, want %v", got, want)
+	}
+	if got[0] != FromBits(0xFE00|0x0123) || got[len(got)-1] != FromBits(0x7E00) {
+		t.Errorf("NaNs should sort to the extremes, got %v ... %v", got[0], got[len(got)-1])
+	}
+	if lo, hi := min(NewOrdered16(One()), NewOrdered16(NegativeZero)), max(NewOrdered16(PositiveZero), NewOrdered16(NegativeZero)); lo.Value() != NegativeZero || hi.Value() != PositiveZero {
+		t.Errorf("min/max = %v, %v, want -0, +0", lo, hi)
+	}
+}
+
+func TestOrdered16BinarySearch(t *testing.T) {
+	all := ToOrdered16Slice(AllFinite())
+	if !slices.IsSorted(all) {
+		t.Fatal("AllFinite() is not sorted as Ordered16")
+	}
+	for i, o := range all {
+		j, found := slices.BinarySearch(all, o)
+		if !found || j != i {
+			t.Fatalf("BinarySearch(%v) = %d, %v, want %d, true", o, j, found, i)
+		}
+	}
+	if _, found := slices.BinarySearch(all, NewOrdered16(PositiveInfinity)); found {
+		t.Error("+Inf should not be found among finite values")
+	}
+}
+
+func TestIsSorted(t *testing.T) {
+	tests := []struct {
+		name            string
+		s               []Float16
+		ascending, desc bool
+	}{
+		{"empty", nil, true, true},
+		{"single", []Float16{One()}, true, true},
+		{"ascending", []Float16{NegativeInfinity, FromInt(-2), SmallestSubnormal, One(), MaxValue, PositiveInfinity}, true, false},
+		{"descending", []Float16{FromInt(3), FromInt(2), FromInt(-7)}, false, true},
+		{"equal elements", []Float16{FromInt(2), FromInt(2), FromInt(2)}, true, true},
+		{"signed zeros are equal", []Float16{PositiveZero, NegativeZero, PositiveZero}, true, true},
+		{"plateau then rise", []Float16{One(), One(), FromInt(2)}, true, false},
+		{"unsorted", []Float16{One(), FromInt(3), FromInt(2)}, false, false},
+		{"NaN", []Float16{One(), QuietNaN, FromInt(2)}, false, false},
+		{"single NaN", []Float16{QuietNaN}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSortedAscending(tt.s); got != tt.ascending {
+				t.Errorf("IsSortedAscending = %v, want %v", got, tt.ascending)
+			}
+			if got := IsSortedDescending(tt.s); got != tt.desc {
+				t.Errorf("IsSortedDescending = %v, want %v", got, tt.desc)
+			}
+			if got := IsMonotonic(tt.s); got != (tt.ascending || tt.desc) {
+				t.Errorf("IsMonotonic = %v", got)
+			}
+		})
+	}
+}
+
+func TestUniqueSorted(t *testing.T) {
+	tests := []struct {
+		name string
+		s    []Float16
+		want []Float16
+	}{
+		{"empty", nil, []Float16{}},
+		{"duplicates", []Float16{Three16, One16, Three16, Two16, One16}, []Float16{One16, Two16, Three16}},
+		{"signed zeros", []Float16{PositiveZero, NegativeZero, One16.Neg(), NegativeZero}, []Float16{One16.Neg(), PositiveZero}},
+		{"only negative zero", []Float16{NegativeZero}, []Float16{PositiveZero}},
+		{"NaN payloads", []Float16{QuietNaN, Two16, SignalingNaN, NegativeQNaN, FromBits(0x7C01), One16}, []Float16{One16, Two16, QuietNaN}},
+		{"infinities", []Float16{PositiveInfinity, NegativeInfinity, MaxValue, PositiveInfinity}, []Float16{NegativeInfinity, MaxValue, PositiveInfinity}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UniqueSorted(tt.s); !slices.Equal(got, tt.want) {
+				t.Errorf("UniqueSorted(%v) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+
+	r := rand.New(rand.NewSource(1))
+	s := make([]Float16, 5000)
+	for i := range s {
+		s[i] = Float16(r.Intn(1 << 16))
+	}
+	got := UniqueSorted(s)
+	if last := got[len(got)-1]; !last.IsNaN() {
+		t.Fatalf("last element %v is not NaN", last)
+	}
+	body := got[:len(got)-1]
+	seen := make(map[Float16]bool)
+	for _, v := range s {
+		switch {
+		case v.IsZero():
+			seen[PositiveZero] = true
+		case !v.IsNaN():
+			seen[v] = true
+		}
+	}
+	for i, v := range body {
+		if v.IsNaN() || (i > 0 && !Less(body[i-1], v)) {
+			t.Fatalf("output not strictly ascending at %d: %v", i, body[max(i-1, 0):i+1])
+		}
+	}
+	if len(body) != len(seen) {
+		t.Errorf("got %d distinct values, want %d", len(body), len(seen))
+	}
+}
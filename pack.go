@@ -0,0 +1,87 @@
+package float16
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Component packing
+//
+// The conversion and arithmetic kernels assemble results from a sign,
+// biased exponent and mantissa. In release builds packComponents masks each
+// field into place. When Config.DebugChecks is set the kernels validate the
+// fields instead, so a kernel bug that computes an out-of-range exponent is
+// reported as an error rather than silently wrapping into the sign bit.
+
+// debugChecks mirrors Config.DebugChecks for lock-free access in the kernels
+var debugChecks atomic.Bool
+
+// packComponents assembles a Float16 from its fields, masking each to width
+func packComponents(sign, exp, mant uint16) Float16 {
+	return Float16((sign&1)<<15 | (exp&ExponentMax)<<MantissaLen | mant&MantissaMask)
+}
+
+// packComponentsChecked assembles a Float16 from its fields, returning an
+// error if sign > 1, exp > 31 or mant > 0x3FF
+func packComponentsChecked(sign, exp, mant uint16) (Float16, error) {
+	if sign > 1 || exp > ExponentMax || mant > MantissaMask {
+		return 0, &Float16Error{
+			Op:   "packComponents",
+			Msg:  fmt.Sprintf("invalid components sign=%d exp=%d mant=%#x", sign, exp, mant),
+			Code: ErrInvalidOperation,
+		}
+	}
+	return Float16(sign<<15 | exp<<MantissaLen | mant), nil
+}
+
+// composeResult packs kernel output, validating the fields when debug checks
+// are enabled. On failure it returns the default NaN with the error.
+func composeResult(sign, exp, mant uint16) (Float16, error) {
+	if debugChecks.Load() {
+		f, err := packComponentsChecked(sign, exp, mant)
+		if err != nil {
+			return defaultNaN(), err
+		}
+		return f, nil
+	}
+	return packComponents(sign, exp, mant), nil
+}
+
+// MustComposeBits returns the Float16 with the given sign bit (0 or 1),
+// biased exponent (0-31) and mantissa (0-0x3FF), the fields exactly as they
+// sit in the bit pattern. It panics if any field is out of range.
+func MustComposeBits(sign, exp, mant uint16) Float16 {
+	f, err := packComponentsChecked(sign, exp, mant)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// Compose returns the Float16 with the given sign bit (0 or 1), unbiased
+// exponent and 10-bit mantissa. Normal values have exponents -14 to 15 and
+// the value ±1.mantissa·2^unbiasedExp. The exponent -15, one below the normal
+// minimum, selects the subnormals ±0.mantissa·2^-14, and 16 selects the
+// infinities (mantissa 0) and NaNs. It returns an ErrInvalidOperation error
+// for any other sign or exponent and for a mantissa above 0x3FF. Unlike
+// MustComposeBits it takes the exponent unbiased, as written in 1.5·2^-3.
+func Compose(sign int, unbiasedExp int, mantissa uint16) (Float16, error) {
+	if sign < 0 || sign > 1 || unbiasedExp < -ExponentBias || unbiasedExp > ExponentMax-ExponentBias || mantissa > MantissaMask {
+		return 0, &Float16Error{
+			Op:   "Compose",
+			Msg:  fmt.Sprintf("invalid components sign=%d exp=%d mant=%#x", sign, unbiasedExp, mantissa),
+			Code: ErrInvalidOperation,
+		}
+	}
+	return packComponents(uint16(sign), uint16(unbiasedExp+ExponentBias), mantissa), nil
+}
+
+// MustCompose is like Compose but panics if the fields are out of range,
+// which makes it convenient for building test vectors.
+func MustCompose(sign int, unbiasedExp int, mantissa uint16) Float16 {
+	f, err := Compose(sign, unbiasedExp, mantissa)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
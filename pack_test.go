@@ -0,0 +1,150 @@
+package float16
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPackComponentsChecked(t *testing.T) {
+	valid := []struct {
+		sign, exp, mant uint16
+		want            Float16
+	}{
+		{0, 15, 0, One()},
+		{1, 0, 0, NegativeZero},
+		{0, 30, 0x3FF, MaxValue},
+		{0, 31, 0, PositiveInfinity},
+		{0, 0, 1, SmallestSubnormal},
+	}
+	for _, v := range valid {
+		got, err := packComponentsChecked(v.sign, v.exp, v.mant)
+		if err != nil || got != v.want {
+			t.Errorf("packComponentsChecked(%d, %d, %#x) = (%v, %v), want %v", v.sign, v.exp, v.mant, got, err, v.want)
+		}
+		if got := packComponents(v.sign, v.exp, v.mant); got != v.want {
+			t.Errorf("packComponents(%d, %d, %#x) = %v, want %v", v.sign, v.exp, v.mant, got, v.want)
+		}
+	}
+
+	invalid := [][3]uint16{{2, 15, 0}, {0, 32, 0}, {0, 15, 0x400}, {1, 0xFFFF, 0xFFFF}}
+	for _, c := range invalid {
+		_, err := packComponentsChecked(c[0], c[1], c[2])
+		var fe *Float16Error
+		if !errors.As(err, &fe) || fe.Code != ErrInvalidOperation {
+			t.Errorf("packComponentsChecked(%d, %d, %#x) err = %v, want ErrInvalidOperation", c[0], c[1], c[2], err)
+		}
+	}
+
+	// The release packer masks instead: exp=32 must not spill into the sign bit
+	if got := packComponents(0, 32, 0); got.Signbit() {
+		t.Errorf("packComponents(0, 32, 0) = %#04x set the sign bit", got.Bits())
+	}
+}
+
+func TestComposeResultDebugChecks(t *testing.T) {
+	original := GetConfig()
+	defer Configure(original)
+
+	cfg := GetConfig()
+	cfg.DebugChecks = true
+	Configure(cfg)
+
+	if _, err := composeResult(0, 32, 0); err == nil {
+		t.Error("debug checks did not detect exp=32")
+	}
+	if f, err := composeResult(0, 15, 0); err != nil || f != One() {
+		t.Errorf("composeResult(0, 15, 0) = (%v, %v), want 1", f, err)
+	}
+
+	// Kernels still produce correct results with validation enabled
+	for i := 0; i < 1<<16; i += 3 {
+		f := Float16(i)
+		if f.IsNaN() {
+			continue
+		}
+		if got := FromFloat32(f.ToFloat32()); got != f {
+			t.Fatalf("FromFloat32 round trip of %#04x = %#04x", i, got.Bits())
+		}
+		if _, err := AddWithMode(f, One(), ModeIEEEArithmetic, RoundTowardZero); err != nil {
+			t.Fatalf("AddWithMode(%v, 1) reported %v", f, err)
+		}
+	}
+
+	cfg.DebugChecks = false
+	Configure(cfg)
+	if f, err := composeResult(0, 32, 0); err != nil || f != PositiveZero {
+		t.Errorf("release composeResult(0, 32, 0) = (%v, %v), want masked 0", f, err)
+	}
+}
+
+func TestMustCompose(t *testing.T) {
+	if got := MustCompose(1, 1, 0x200); got != FromFloat32(-3) {
+		t.Errorf("MustCompose(1, 1, 0x200) = %v, want -3", got)
+	}
+	if got := MustComposeBits(1, 16, 0x200); got != FromFloat32(-3) {
+		t.Errorf("MustComposeBits(1, 16, 0x200) = %v, want -3", got)
+	}
+	for name, fn := range map[string]func(){
+		"MustCompose":     func() { MustCompose(0, 17, 0) },
+		"MustComposeBits": func() { MustComposeBits(0, 40, 0) },
+	} {
+		if !panics(fn) {
+			t.Errorf("%s did not panic on invalid exponent", name)
+		}
+	}
+}
+
+func TestCompose(t *testing.T) {
+	tests := []struct {
+		sign, exp int
+		mant      uint16
+		want      Float16
+		wantErr   bool
+	}{
+		{0, 0, 0, One16, false},
+		{1, -3, 0x200, FromFloat32(-0.1875), false},
+		{0, -14, 0, SmallestNormal, false},
+		{0, -15, 1, SmallestSubnormal, false},
+		{0, -15, 0x200, FromFloat64(0x1p-15), false},
+		{1, -15, 0, NegativeZero, false},
+		{0, 15, 0x3FF, MaxValue, false},
+		{1, 16, 0, NegativeInfinity, false},
+		{0, 16, 0x200, QuietNaN, false},
+		{0, -16, 0, 0, true},
+		{0, 17, 0, 0, true},
+		{0, 0, 0x400, 0, true},
+		{2, 0, 0, 0, true},
+		{-1, 0, 0, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := Compose(tt.sign, tt.exp, tt.mant)
+		if tt.wantErr {
+			var fe *Float16Error
+			if !errors.As(err, &fe) || fe.Code != ErrInvalidOperation {
+				t.Errorf("Compose(%d, %d, %#x) error = %v, want ErrInvalidOperation", tt.sign, tt.exp, tt.mant, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Compose(%d, %d, %#x) = %#04x, %v, want %#04x", tt.sign, tt.exp, tt.mant, uint16(got), err, uint16(tt.want))
+		}
+	}
+}
+
+func benchmarkFromFloat32(b *testing.B, debug bool) {
+	original := GetConfig()
+	cfg := GetConfig()
+	cfg.DebugChecks = debug
+	Configure(cfg)
+	defer Configure(original)
+
+	var sink Float16
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sink += FromFloat32(float32(i&1023) * 0.37)
+	}
+	_ = sink
+}
+
+func BenchmarkFromFloat32Release(b *testing.B) { benchmarkFromFloat32(b, false) }
+func BenchmarkFromFloat32Debug(b *testing.B)   { benchmarkFromFloat32(b, true) }
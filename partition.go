@@ -0,0 +1,18 @@
+package float16
+
+// PartitionFinite separates s into its finite values, in their original
+// order, and the indices in s of its NaN and infinite elements. s is not
+// modified. Both results are non-nil so callers can range over them without
+// nil checks.
+func PartitionFinite(s []Float16) (finite []Float16, nonFiniteIndices []int) {
+	finite = make([]Float16, 0, len(s))
+	nonFiniteIndices = []int{}
+	for i, v := range s {
+		if v.IsFinite() {
+			finite = append(finite, v)
+		} else {
+			nonFiniteIndices = append(nonFiniteIndices, i)
+		}
+	}
+	return finite, nonFiniteIndices
+}
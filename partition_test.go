@@ -0,0 +1,48 @@
+package float16
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPartitionFinite(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          []Float16
+		wantFinite  []Float16
+		wantIndices []int
+	}{
+		{
+			name:        "interspersed",
+			in:          []Float16{One(), QuietNaN, FromInt(2), PositiveInfinity, NegativeZero, NegativeInfinity, FromBits(0xFC01), MaxValue},
+			wantFinite:  []Float16{One(), FromInt(2), NegativeZero, MaxValue},
+			wantIndices: []int{1, 3, 5, 6},
+		},
+		{"all finite", []Float16{SmallestSubnormal, MinValue}, []Float16{SmallestSubnormal, MinValue}, []int{}},
+		{"none finite", []Float16{QuietNaN, PositiveInfinity}, []Float16{}, []int{0, 1}},
+		{"empty", nil, []Float16{}, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := slices.Clone(tt.in)
+			finite, indices := PartitionFinite(tt.in)
+			if !slices.Equal(finite, tt.wantFinite) {
+				t.Errorf("finite = %v, want %v", finite, tt.wantFinite)
+			}
+			if !slices.Equal(indices, tt.wantIndices) {
+				t.Errorf("indices = %v, want %v", indices, tt.wantIndices)
+			}
+			if finite == nil || indices == nil {
+				t.Error("results should be non-nil")
+			}
+			if !slices.Equal(tt.in, orig) {
+				t.Error("input was modified")
+			}
+			for _, i := range indices {
+				if tt.in[i].IsFinite() {
+					t.Errorf("index %d refers to finite value %v", i, tt.in[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package float16
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Polynomial approximation
+//
+// FitPolynomial generates coefficients for pure-Float16 polynomial kernels.
+// It fits over every representable value in the interval, weighting each
+// point by the inverse ULP of the target so the fit minimizes error in ULPs
+// rather than absolute error, then reweights the worst points (Lawson's
+// iteration) to move the least-squares fit toward the minimax one. Each
+// candidate is scored by evaluating its rounded coefficients with Polyval,
+// so the reported error includes coefficient rounding and the roundings
+// of Horner's scheme in Float16.
+
+// lawsonIterations is the number of reweighting passes after the initial
+// least-squares fit
+const lawsonIterations = 30
+
+// Polyval evaluates the polynomial with coefficients c, lowest degree first,
+// at x using Horner's scheme in Float16 arithmetic, so every multiply and add
+// rounds to Float16. An empty c gives +0.
+func Polyval(c []Float16, x Float16) Float16 {
+	if len(c) == 0 {
+		return PositiveZero
+	}
+	r := c[len(c)-1]
+	for i := len(c) - 2; i >= 0; i-- {
+		r = Add(Mul(r, x), c[i])
+	}
+	return r
+}
+
+// FitPolynomial fits a polynomial of the given degree to f over the
+// representable values in [lo, hi] and returns its coefficients rounded to
+// Float16, lowest degree first, for use with Polyval. The second result is
+// the maximum error of Polyval against the exact f(x) over those points, in
+// units of the Float16 spacing at f(x); a correctly rounded kernel scores at
+// most 0.5. Points where f is not finite are skipped. It panics if lo or hi
+// is not finite, lo > hi, or degree is negative.
+func FitPolynomial(f func(float64) float64, lo, hi Float16, degree int) ([]Float16, float64) {
+	if !lo.IsFinite() || !hi.IsFinite() || Greater(lo, hi) {
+		panic("float16: FitPolynomial needs a finite interval with lo <= hi")
+	}
+	if degree < 0 {
+		panic("float16: FitPolynomial degree must not be negative")
+	}
+
+	var xs, ys, units []float64
+	var points []Float16
+	for x := lo; ; x = NextAfter(x, PositiveInfinity) {
+		// Zero is visited once: as +0 when stepping up from below, since
+		// NextAfter(-0, +Inf) skips +0 for the smallest subnormal
+		if x.IsNegativeZero() && !lo.IsNegativeZero() {
+			x = PositiveZero
+		}
+		xf := x.ToFloat64()
+		if y := f(xf); !math.IsNaN(y) && !math.IsInf(y, 0) {
+			points = append(points, x)
+			xs = append(xs, xf)
+			ys = append(ys, y)
+			units = append(units, ulpAt(y))
+		}
+		if x == hi || (x.IsZero() && hi.IsZero()) {
+			break
+		}
+	}
+	if len(points) == 0 {
+		return make([]Float16, degree+1), 0
+	}
+
+	weights := make([]float64, len(xs))
+	for i := range weights {
+		weights[i] = 1 / units[i]
+	}
+	var best []Float16
+	bestErr := math.Inf(1)
+	residual := make([]float64, len(xs))
+	for range lawsonIterations + 1 {
+		exact := weightedLeastSquares(xs, ys, weights, degree)
+		c := make([]Float16, len(exact))
+		for i, v := range exact {
+			c[i] = FromFloat64(v)
+		}
+		maxErr := 0.0
+		for i, x := range points {
+			maxErr = max(maxErr, math.Abs(Polyval(c, x).ToFloat64()-ys[i])/units[i])
+			// Lawson reweights by the error of the unrounded fit in ULPs
+			p := 0.0
+			for k := len(exact) - 1; k >= 0; k-- {
+				p = p*xs[i] + exact[k]
+			}
+			residual[i] = math.Abs(p-ys[i]) / units[i]
+		}
+		if maxErr < bestErr {
+			best, bestErr = c, maxErr
+		}
+
+		total := 0.0
+		for i := range weights {
+			weights[i] *= math.Sqrt(residual[i])
+			total += weights[i]
+		}
+		if total == 0 || math.IsInf(total, 0) || math.IsNaN(total) {
+			break
+		}
+		for i := range weights {
+			weights[i] /= total
+		}
+	}
+	return best, bestErr
+}
+
+// ulpAt returns the spacing of Float16 values at the magnitude of y, using
+// the subnormal spacing near zero and extending the top binade beyond
+// MaxValue
+func ulpAt(y float64) float64 {
+	if y == 0 {
+		return 0x1p-24
+	}
+	_, e := math.Frexp(y)
+	return math.Ldexp(1, max(e-11, -24))
+}
+
+// weightedLeastSquares returns the coefficients, lowest degree first, of the
+// polynomial minimizing the sum of (weights[i]·(p(xs[i]) - ys[i]))². It
+// solves the weighted Vandermonde system by Householder QR, which avoids
+// squaring its condition number as the normal equations would.
+func weightedLeastSquares(xs, ys, weights []float64, degree int) []float64 {
+	m, n := len(xs), degree+1
+	// a holds the columns of the weighted Vandermonde matrix
+	a := make([][]float64, n)
+	for j := range a {
+		a[j] = make([]float64, m)
+	}
+	b := make([]float64, m)
+	for i, x := range xs {
+		p := weights[i]
+		for j := range n {
+			a[j][i] = p
+			p *= x
+		}
+		b[i] = weights[i] * ys[i]
+	}
+
+	for k := range min(n, m) {
+		col := a[k]
+		norm := 0.0
+		for i := k; i < m; i++ {
+			norm = math.Hypot(norm, col[i])
+		}
+		if norm == 0 {
+			continue
+		}
+		if col[k] > 0 {
+			norm = -norm
+		}
+		// Reflect so that col[k] becomes norm and the entries below vanish;
+		// v = col[k:] - norm·e_k is stored in place
+		col[k] -= norm
+		vv := -norm * col[k]
+		apply := func(y []float64) {
+			dot := 0.0
+			for i := k; i < m; i++ {
+				dot += col[i] * y[i]
+			}
+			s := dot / vv
+			for i := k; i < m; i++ {
+				y[i] -= s * col[i]
+			}
+		}
+		for j := k + 1; j < n; j++ {
+			apply(a[j])
+		}
+		apply(b)
+		col[k] = norm
+	}
+
+	// Back substitution on the upper triangle
+	c := make([]float64, n)
+	for k := min(n, m) - 1; k >= 0; k-- {
+		s := b[k]
+		for j := k + 1; j < n; j++ {
+			s -= a[j][k] * c[j]
+		}
+		if a[k][k] != 0 {
+			c[k] = s / a[k][k]
+		}
+	}
+	return c
+}
+
+// PolynomialSource returns Go source declaring a variable with the given
+// name that holds the coefficients c, one per line with its value as a
+// comment, for pasting into a package that imports float16.
+func PolynomialSource(name string, c []Float16) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "// %s holds polynomial coefficients, lowest degree first\n", name)
+	fmt.Fprintf(&sb, "var %s = []float16.Float16{\n", name)
+	for _, v := range c {
+		fmt.Fprintf(&sb, "\t%#v, // %v\n", v, v)
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
@@ -0,0 +1,116 @@
+package float16
+
+import (
+	"go/parser"
+	"go/token"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestPolyval(t *testing.T) {
+	tests := []struct {
+		c    []Float16
+		x    Float16
+		want Float16
+	}{
+		{nil, Two16, PositiveZero},
+		{[]Float16{Three16}, MaxValue, Three16},
+		{[]Float16{One16, Two16, Three16}, Two16, FromFloat64(17)},
+		{[]Float16{NegativeZero, One16}, NegativeZero, NegativeZero},
+		{[]Float16{One16, Half16}, PositiveInfinity, PositiveInfinity},
+	}
+	for _, tt := range tests {
+		if got := Polyval(tt.c, tt.x); got != tt.want {
+			t.Errorf("Polyval(%v, %v) = %v, want %v", tt.c, tt.x, got, tt.want)
+		}
+	}
+}
+
+func TestFitPolynomialExp(t *testing.T) {
+	c, reported := FitPolynomial(math.Exp, PositiveZero, One16, 4)
+	if len(c) != 5 {
+		t.Fatalf("got %d coefficients, want 5", len(c))
+	}
+
+	// Recompute the error over every representable point in [0, 1]
+	worst := 0.0
+	for x := PositiveZero; !Greater(x, One16); x = NextAfter(x, PositiveInfinity) {
+		y := math.Exp(x.ToFloat64())
+		exp := math.Floor(math.Log2(y))
+		spacing := math.Pow(2, exp-10)
+		worst = max(worst, math.Abs(Polyval(c, x).ToFloat64()-y)/spacing)
+	}
+	if worst != reported {
+		t.Errorf("reported error %v, exhaustive evaluation gives %v", reported, worst)
+	}
+	if reported > 2 {
+		t.Errorf("degree 4 exp error = %v ULP, want at most 2", reported)
+	}
+}
+
+func TestFitPolynomialSin(t *testing.T) {
+	hi := FromFloat64(math.Pi / 4)
+	for degree := 1; degree <= 6; degree++ {
+		c, e := FitPolynomial(math.Sin, PositiveZero, hi, degree)
+		if e <= 1 {
+			t.Logf("degree %d reaches %.3f ULP: %v", degree, e, c)
+			return
+		}
+	}
+	t.Error("no degree up to 6 fits sin on [0, π/4] within 1 ULP")
+}
+
+func TestFitPolynomialSamplesZeroOnce(t *testing.T) {
+	var zeros, negZeros int
+	f := func(x float64) float64 {
+		if x == 0 {
+			zeros++
+			if math.Signbit(x) {
+				negZeros++
+			}
+			return 5
+		}
+		return x * x
+	}
+	// x² fits exactly everywhere but zero, so the error comes from f(0) = 5
+	c, e := FitPolynomial(f, FromInt(-1), One16, 2)
+	if zeros != 1 || negZeros != 0 {
+		t.Errorf("zero sampled %d times, %d as -0, want only +0", zeros, negZeros)
+	}
+	if e < 1 {
+		t.Errorf("FitPolynomial over [-1, 1] reports %v ULP with f(0) = 5: %v", e, c)
+	}
+}
+
+func TestFitPolynomialPanics(t *testing.T) {
+	cases := map[string]func(){
+		"reversed":        func() { FitPolynomial(math.Exp, One16, PositiveZero, 2) },
+		"infinite":        func() { FitPolynomial(math.Exp, PositiveZero, PositiveInfinity, 2) },
+		"NaN":             func() { FitPolynomial(math.Exp, QuietNaN, One16, 2) },
+		"negative degree": func() { FitPolynomial(math.Exp, PositiveZero, One16, -1) },
+	}
+	for name, fn := range cases {
+		if !panics(fn) {
+			t.Errorf("%s interval did not panic", name)
+		}
+	}
+}
+
+func TestPolynomialSource(t *testing.T) {
+	src := PolynomialSource("expCoeffs", []Float16{One16, Half16})
+	want := "// expCoeffs holds polynomial coefficients, lowest degree first\n" +
+		"var expCoeffs = []float16.Float16{\n" +
+		"\tfloat16.FromBits(0x3c00), // 1\n" +
+		"\tfloat16.FromBits(0x3800), // 0.5\n" +
+		"}\n"
+	if src != want {
+		t.Errorf("PolynomialSource =\n%s\nwant\n%s", src, want)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "", "package p\n\n"+src, 0); err != nil {
+		t.Errorf("generated source does not parse: %v", err)
+	}
+	if !strings.Contains(PolynomialSource("empty", nil), "{\n}") {
+		t.Error("empty coefficient list not emitted as an empty literal")
+	}
+}
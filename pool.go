@@ -0,0 +1,119 @@
+package float16
+
+import "fmt"
+
+// Pooling over 2-D planes
+
+// PoolEdge selects how pooling treats windows that extend past the plane
+type PoolEdge int
+
+const (
+	// PoolDropPartial produces outputs only for windows that lie fully inside
+	// the plane
+	PoolDropPartial PoolEdge = iota
+	// PoolExcludePadding keeps partial edge windows; averages divide by the
+	// number of cells inside the plane
+	PoolExcludePadding
+	// PoolIncludePadding keeps partial edge windows; averages treat the cells
+	// outside the plane as zeros and divide by the full window size
+	PoolIncludePadding
+)
+
+// MaxPool2D returns the maximum of each kw×kh window of the w×h row-major
+// plane src, stepping by stride in both directions and dropping partial
+// windows. It returns the pooled plane and its width and height. A window
+// containing a NaN produces NaN, and +0 ranks above -0 as in Max. The maxima
+// are copied from the input without conversion.
+func MaxPool2D(src []Float16, w, h, kw, kh, stride int) ([]Float16, int, int, error) {
+	return MaxPool2DWithEdge(src, w, h, kw, kh, stride, PoolDropPartial)
+}
+
+// MaxPool2DWithEdge is like MaxPool2D with explicit edge handling. Both
+// padding modes keep partial windows and take the maximum over the cells
+// inside the plane.
+func MaxPool2DWithEdge(src []Float16, w, h, kw, kh, stride int, edge PoolEdge) ([]Float16, int, int, error) {
+	outW, outH, err := poolShape("MaxPool2D", src, w, h, kw, kh, stride, edge)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	dst := make([]Float16, outW*outH)
+	for oy := 0; oy < outH; oy++ {
+		y0, y1 := oy*stride, min(oy*stride+kh, h)
+		for ox := 0; ox < outW; ox++ {
+			x0, x1 := ox*stride, min(ox*stride+kw, w)
+			best := src[y0*w+x0]
+		window:
+			for y := y0; y < y1; y++ {
+				for _, v := range src[y*w+x0 : y*w+x1] {
+					if v.IsNaN() {
+						best = v
+						break window
+					}
+					if Greater(v, best) || v == PositiveZero && best == NegativeZero {
+						best = v
+					}
+				}
+			}
+			dst[oy*outW+ox] = best
+		}
+	}
+	return dst, outW, outH, nil
+}
+
+// AvgPool2D returns the mean of each kw×kh window of the w×h row-major plane
+// src, stepping by stride in both directions and dropping partial windows.
+// Sums are accumulated in float32 and rounded once. NaNs propagate.
+func AvgPool2D(src []Float16, w, h, kw, kh, stride int) ([]Float16, int, int, error) {
+	return AvgPool2DWithEdge(src, w, h, kw, kh, stride, PoolDropPartial)
+}
+
+// AvgPool2DWithEdge is like AvgPool2D with explicit edge handling.
+func AvgPool2DWithEdge(src []Float16, w, h, kw, kh, stride int, edge PoolEdge) ([]Float16, int, int, error) {
+	outW, outH, err := poolShape("AvgPool2D", src, w, h, kw, kh, stride, edge)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	dst := make([]Float16, outW*outH)
+	for oy := 0; oy < outH; oy++ {
+		y0, y1 := oy*stride, min(oy*stride+kh, h)
+		for ox := 0; ox < outW; ox++ {
+			x0, x1 := ox*stride, min(ox*stride+kw, w)
+			var sum float32
+			for y := y0; y < y1; y++ {
+				for _, v := range src[y*w+x0 : y*w+x1] {
+					sum += v.ToFloat32()
+				}
+			}
+			count := (y1 - y0) * (x1 - x0)
+			if edge == PoolIncludePadding {
+				count = kw * kh
+			}
+			dst[oy*outW+ox] = FromFloat32(sum / float32(count))
+		}
+	}
+	return dst, outW, outH, nil
+}
+
+// poolShape validates pooling arguments and returns the output dimensions
+func poolShape(op string, src []Float16, w, h, kw, kh, stride int, edge PoolEdge) (int, int, error) {
+	fail := func(msg string) (int, int, error) {
+		return 0, 0, &Float16Error{Op: op, Msg: msg, Code: ErrInvalidOperation}
+	}
+	switch {
+	case w <= 0 || h <= 0 || len(src) != w*h:
+		return fail(fmt.Sprintf("plane of length %d does not match %dx%d", len(src), w, h))
+	case kw <= 0 || kh <= 0 || kw > w || kh > h:
+		return fail(fmt.Sprintf("window %dx%d does not fit plane %dx%d", kw, kh, w, h))
+	case stride <= 0:
+		return fail(fmt.Sprintf("stride must be positive, got %d", stride))
+	case edge < PoolDropPartial || edge > PoolIncludePadding:
+		return fail(fmt.Sprintf("unknown edge mode %d", edge))
+	}
+
+	if edge == PoolDropPartial {
+		return (w-kw)/stride + 1, (h-kh)/stride + 1, nil
+	}
+	return (w-kw+stride-1)/stride + 1, (h-kh+stride-1)/stride + 1, nil
+}
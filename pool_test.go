@@ -0,0 +1,146 @@
+package float16
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// naivePool computes max and mean pooling in float64 by visiting every window cell
+func naivePool(src []Float16, w, h, kw, kh, stride int, edge PoolEdge) (maxes, means []float64, outW, outH int) {
+	outW, outH = (w-kw)/stride+1, (h-kh)/stride+1
+	if edge != PoolDropPartial {
+		outW = int(math.Ceil(float64(w-kw)/float64(stride))) + 1
+		outH = int(math.Ceil(float64(h-kh)/float64(stride))) + 1
+	}
+	for oy := 0; oy < outH; oy++ {
+		for ox := 0; ox < outW; ox++ {
+			mx, sum, n := math.Inf(-1), 0.0, 0
+			for dy := 0; dy < kh; dy++ {
+				for dx := 0; dx < kw; dx++ {
+					x, y := ox*stride+dx, oy*stride+dy
+					if x >= w || y >= h {
+						continue
+					}
+					v := src[y*w+x].ToFloat64()
+					if math.IsNaN(v) || math.IsNaN(mx) {
+						mx = math.NaN()
+					} else {
+						mx = math.Max(mx, v)
+					}
+					sum += v
+					n++
+				}
+			}
+			if edge == PoolIncludePadding {
+				n = kw * kh
+			}
+			maxes = append(maxes, mx)
+			means = append(means, sum/float64(n))
+		}
+	}
+	return maxes, means, outW, outH
+}
+
+func TestPool2DMatchesNaive(t *testing.T) {
+	rng := rand.New(rand.NewSource(9))
+	const w, h = 11, 8
+	src := make([]Float16, w*h)
+	for i := range src {
+		src[i] = FromFloat64(rng.NormFloat64() * 10)
+	}
+	src[17] = QuietNaN // poison a few windows
+	src[60] = NegativeQNaN
+
+	for _, edge := range []PoolEdge{PoolDropPartial, PoolExcludePadding, PoolIncludePadding} {
+		for _, cfg := range []struct{ kw, kh, stride int }{{2, 2, 2}, {3, 3, 2}, {3, 2, 1}, {4, 3, 3}} {
+			maxes, means, wantW, wantH := naivePool(src, w, h, cfg.kw, cfg.kh, cfg.stride, edge)
+
+			gotMax, ow, oh, err := MaxPool2DWithEdge(src, w, h, cfg.kw, cfg.kh, cfg.stride, edge)
+			if err != nil || ow != wantW || oh != wantH {
+				t.Fatalf("edge %d %+v: MaxPool2D dims (%d, %d, %v), want (%d, %d)", edge, cfg, ow, oh, err, wantW, wantH)
+			}
+			gotAvg, _, _, err := AvgPool2DWithEdge(src, w, h, cfg.kw, cfg.kh, cfg.stride, edge)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range maxes {
+				if math.IsNaN(maxes[i]) != gotMax[i].IsNaN() || (!gotMax[i].IsNaN() && gotMax[i].ToFloat64() != maxes[i]) {
+					t.Errorf("edge %d %+v: max[%d] = %v, want %v", edge, cfg, i, gotMax[i], maxes[i])
+				}
+				if math.IsNaN(means[i]) != gotAvg[i].IsNaN() {
+					t.Errorf("edge %d %+v: avg[%d] = %v, want %v", edge, cfg, i, gotAvg[i], means[i])
+				} else if !gotAvg[i].IsNaN() && gotAvg[i] != FromFloat64(means[i]) {
+					if diff := math.Abs(gotAvg[i].ToFloat64() - means[i]); diff > math.Abs(means[i])*1e-3 {
+						t.Errorf("edge %d %+v: avg[%d] = %v, want %v", edge, cfg, i, gotAvg[i], means[i])
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestPool2DEdgeSemantics(t *testing.T) {
+	// 3x1 plane with a 2-wide window and stride 2 leaves one partial window
+	src := []Float16{FromInt(2), FromInt(4), FromInt(6)}
+	avg, ow, _, _ := AvgPool2D(src, 3, 1, 2, 1, 2)
+	if ow != 1 || avg[0] != FromInt(3) {
+		t.Errorf("drop partial = %v, want [3]", avg)
+	}
+	avg, _, _, _ = AvgPool2DWithEdge(src, 3, 1, 2, 1, 2, PoolExcludePadding)
+	if len(avg) != 2 || avg[1] != FromInt(6) {
+		t.Errorf("exclude padding = %v, want [3 6]", avg)
+	}
+	avg, _, _, _ = AvgPool2DWithEdge(src, 3, 1, 2, 1, 2, PoolIncludePadding)
+	if len(avg) != 2 || avg[1] != FromInt(3) {
+		t.Errorf("include padding = %v, want [3 3]", avg)
+	}
+}
+
+func TestPool2DErrors(t *testing.T) {
+	src := make([]Float16, 16)
+	cases := []struct {
+		name                 string
+		w, h, kw, kh, stride int
+		edge                 PoolEdge
+	}{
+		{"size mismatch", 5, 3, 2, 2, 1, PoolDropPartial},
+		{"window too wide", 4, 4, 5, 2, 1, PoolDropPartial},
+		{"zero window", 4, 4, 0, 2, 1, PoolDropPartial},
+		{"zero stride", 4, 4, 2, 2, 0, PoolDropPartial},
+		{"bad edge", 4, 4, 2, 2, 1, PoolEdge(7)},
+	}
+	for _, c := range cases {
+		_, _, _, errMax := MaxPool2DWithEdge(src, c.w, c.h, c.kw, c.kh, c.stride, c.edge)
+		_, _, _, errAvg := AvgPool2DWithEdge(src, c.w, c.h, c.kw, c.kh, c.stride, c.edge)
+		for _, err := range []error{errMax, errAvg} {
+			var fe *Float16Error
+			if !errors.As(err, &fe) || fe.Code != ErrInvalidOperation {
+				t.Errorf("%s: err = %v, want ErrInvalidOperation", c.name, err)
+			}
+		}
+	}
+}
+
+func benchmarkPoolInput() []Float16 {
+	src := make([]Float16, 224*224)
+	for i := range src {
+		src[i] = FromInt(i % 97)
+	}
+	return src
+}
+
+func BenchmarkMaxPool2D224(b *testing.B) {
+	src := benchmarkPoolInput()
+	for i := 0; i < b.N; i++ {
+		_, _, _, _ = MaxPool2D(src, 224, 224, 2, 2, 2)
+	}
+}
+
+func BenchmarkAvgPool2D224(b *testing.B) {
+	src := benchmarkPoolInput()
+	for i := 0; i < b.N; i++ {
+		_, _, _, _ = AvgPool2D(src, 224, 224, 2, 2, 2)
+	}
+}
@@ -0,0 +1,105 @@
+package float16
+
+import (
+	"fmt"
+	"math"
+)
+
+// Packed affine quantization
+//
+// PackQuantized maps each value x to the unsigned code
+// round((x - zeroPoint) / scale) of 4 or 8 bits, where zeroPoint is the
+// minimum of the data and scale spreads the range over all codes, and packs
+// the codes into bytes. UnpackQuantized reverses it as zeroPoint + code·scale.
+// 4-bit codes are packed two per byte, the even-indexed element in the low
+// nibble.
+
+// PackQuantized quantizes s to bits-wide codes, 4 or 8, and returns them
+// packed with the scale and zero point needed to reconstruct the values. The
+// scale is (max - min) / (2^bits - 1) rounded up to a Float16, so no code
+// overflows, and every reconstructed value is within scale of the original.
+// The minimum reconstructs exactly. A constant slice gives scale 0 and all
+// codes 0. It returns an ErrInvalidOperation error for other bit widths and
+// for infinities, and an ErrNaN error for NaNs.
+func PackQuantized(s []Float16, bits int) (codes []byte, scale Float16, zeroPoint Float16, err error) {
+	if bits != 4 && bits != 8 {
+		return nil, 0, 0, &Float16Error{
+			Op:   "PackQuantized",
+			Msg:  fmt.Sprintf("bit width %d is not 4 or 8", bits),
+			Code: ErrInvalidOperation,
+		}
+	}
+	if len(s) == 0 {
+		return []byte{}, 0, 0, nil
+	}
+
+	lo, hi := s[0], s[0]
+	for i, v := range s {
+		switch {
+		case v.IsNaN():
+			return nil, 0, 0, &Float16Error{Op: "PackQuantized", Msg: fmt.Sprintf("NaN at index %d", i), Code: ErrNaN}
+		case v.IsInf(0):
+			return nil, 0, 0, &Float16Error{
+				Op:   "PackQuantized",
+				Msg:  fmt.Sprintf("infinity at index %d", i),
+				Code: ErrInvalidOperation,
+			}
+		case Less(v, lo):
+			lo = v
+		case Greater(v, hi):
+			hi = v
+		}
+	}
+
+	levels := float64(int(1)<<bits - 1)
+	base := lo.ToFloat64()
+	// The range of finite Float16 values is exact in float64 and its quotient
+	// by the number of levels stays far below MaxValue
+	scale = fromFloat64Rounded((hi.ToFloat64()-base)/levels, RoundTowardPositive)
+	codes = make([]byte, (len(s)*bits+7)/8)
+	if scale == 0 {
+		return codes, scale, lo, nil
+	}
+	step := scale.ToFloat64()
+	for i, v := range s {
+		code := min(byte(math.RoundToEven((v.ToFloat64()-base)/step)), byte(levels))
+		if bits == 8 {
+			codes[i] = code
+		} else {
+			codes[i/2] |= code << (4 * (i % 2))
+		}
+	}
+	return codes, scale, lo, nil
+}
+
+// UnpackQuantized returns the n values encoded in codes by PackQuantized
+// with the given bit width, scale and zero point. Code 0 gives zeroPoint
+// exactly; other codes give zeroPoint + code·scale, computed exactly and
+// rounded once, saturating at ±MaxValue since the rounded-up scale can carry
+// the top code past the original maximum. It panics if bits is not 4 or 8 or
+// codes holds fewer than n codes.
+func UnpackQuantized(codes []byte, bits int, scale, zeroPoint Float16, n int) []Float16 {
+	if bits != 4 && bits != 8 {
+		panic("float16: quantized bit width must be 4 or 8")
+	}
+	if n < 0 || len(codes)*8/bits < n {
+		panic("float16: not enough quantized codes")
+	}
+	step, base := scale.ToFloat64(), zeroPoint.ToFloat64()
+	maxValue := MaxValue.ToFloat64()
+	dst := make([]Float16, n)
+	for i := range dst {
+		var code byte
+		if bits == 8 {
+			code = codes[i]
+		} else {
+			code = codes[i/2] >> (4 * (i % 2)) & 0xF
+		}
+		if code == 0 {
+			dst[i] = zeroPoint
+			continue
+		}
+		dst[i] = FromFloat64(min(max(base+float64(code)*step, -maxValue), maxValue))
+	}
+	return dst
+}
@@ -0,0 +1,98 @@
+package float16
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestPackQuantizedRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	inputs := map[string][]Float16{
+		"normal":   make([]Float16, 1001),
+		"positive": make([]Float16, 64),
+		"narrow":   make([]Float16, 33),
+		"extremes": {MinValue, MaxValue, NegativeZero, SmallestSubnormal, One16},
+	}
+	for i := range inputs["normal"] {
+		inputs["normal"][i] = FromFloat64(r.NormFloat64() * 10)
+	}
+	for i := range inputs["positive"] {
+		inputs["positive"][i] = FromFloat64(1000 + r.Float64()*100)
+	}
+	for i := range inputs["narrow"] {
+		inputs["narrow"][i] = FromFloat64(1 + r.Float64()*0x1p-8)
+	}
+
+	for name, s := range inputs {
+		for _, bits := range []int{4, 8} {
+			codes, scale, zp, err := PackQuantized(s, bits)
+			if err != nil {
+				t.Fatalf("%s/%d: %v", name, bits, err)
+			}
+			if want := (len(s)*bits + 7) / 8; len(codes) != want {
+				t.Errorf("%s/%d: %d code bytes, want %d", name, bits, len(codes), want)
+			}
+			if zp != SliceMin(s) {
+				t.Errorf("%s/%d: zero point %v is not the minimum", name, bits, zp)
+			}
+			got := UnpackQuantized(codes, bits, scale, zp, len(s))
+			for i, v := range s {
+				if d := math.Abs(got[i].ToFloat64() - v.ToFloat64()); d > scale.ToFloat64() {
+					t.Fatalf("%s/%d: element %d = %v reconstructs as %v, error %g > scale %v", name, bits, i, v, got[i], d, scale)
+				}
+			}
+		}
+	}
+}
+
+func TestPackQuantizedLayout(t *testing.T) {
+	// Range 15 over 4 bits gives scale 1, so each code is the value itself
+	s := []Float16{0, One16, Two16, FromFloat32(15), Five16}
+	codes, scale, zp, err := PackQuantized(s, 4)
+	if err != nil || scale != One16 || zp != 0 {
+		t.Fatalf("PackQuantized = %v, %v, %v", scale, zp, err)
+	}
+	if want := []byte{0x10, 0xF2, 0x05}; !slices.Equal(codes, want) {
+		t.Errorf("codes = %#v, want %#v", codes, want)
+	}
+	if got := UnpackQuantized(codes, 4, scale, zp, len(s)); !slices.Equal(got, s) {
+		t.Errorf("UnpackQuantized = %v, want %v", got, s)
+	}
+
+	codes, scale, zp, err = PackQuantized([]Float16{Three16, Three16}, 8)
+	if err != nil || scale != 0 || zp != Three16 || !slices.Equal(codes, []byte{0, 0}) {
+		t.Errorf("constant slice = %v, %v, %v, %v", codes, scale, zp, err)
+	}
+	if got := UnpackQuantized(codes, 8, scale, zp, 2); !slices.Equal(got, []Float16{Three16, Three16}) {
+		t.Errorf("constant slice unpacks as %v", got)
+	}
+}
+
+func TestPackQuantizedErrors(t *testing.T) {
+	tests := []struct {
+		s    []Float16
+		bits int
+		code ErrorCode
+	}{
+		{[]Float16{One16}, 2, ErrInvalidOperation},
+		{[]Float16{One16}, 16, ErrInvalidOperation},
+		{[]Float16{One16, QuietNaN}, 8, ErrNaN},
+		{[]Float16{PositiveInfinity}, 4, ErrInvalidOperation},
+	}
+	for _, tt := range tests {
+		_, _, _, err := PackQuantized(tt.s, tt.bits)
+		var fe *Float16Error
+		if !errors.As(err, &fe) || fe.Code != tt.code {
+			t.Errorf("PackQuantized(%v, %d) error = %v, want code %v", tt.s, tt.bits, err, tt.code)
+		}
+	}
+	if !panics(func() { UnpackQuantized([]byte{0}, 4, One16, 0, 3) }) {
+		t.Error("UnpackQuantized did not panic on short codes")
+	}
+	if !panics(func() { UnpackQuantized([]byte{0}, 2, One16, 0, 1) }) {
+		t.Error("UnpackQuantized did not panic on a bad bit width")
+	}
+}
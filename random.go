@@ -0,0 +1,81 @@
+package float16
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Random variates
+
+// RandomExponential returns an exponentially distributed value with the given
+// rate (mean 1/rate), drawn from r and rounded to Float16. It returns NaN if
+// rate is NaN or not positive, and zero if rate is +Inf.
+func RandomExponential(r *rand.Rand, rate Float16) Float16 {
+	if rate.IsNaN() || rate.Signbit() || rate.IsZero() {
+		return defaultNaN()
+	}
+	return FromFloat64(r.ExpFloat64() / rate.ToFloat64())
+}
+
+// Bernoulli returns true with probability p. Every Float16 in [0, 1] is a
+// multiple of 2^-24, so the draw compares a uniform 24-bit integer with
+// p·2^24 and the probability is exactly p: p = 0 never returns true and p = 1
+// always does. It panics if p is NaN or outside [0, 1].
+func Bernoulli(r *rand.Rand, p Float16) bool {
+	pf := p.ToFloat64()
+	if !(pf >= 0 && pf <= 1) {
+		panic("float16: Bernoulli probability must be in [0, 1]")
+	}
+	threshold := int64(pf * (1 << 24))
+	return r.Int63n(1<<24) < threshold
+}
+
+// Categorical returns an index drawn with probability proportional to
+// probs[i]. Prefix sums are accumulated in float64. It returns an error if any
+// probability is NaN, negative or infinite, if the total is zero, or if the
+// total differs from 1 by more than the rounding of the probabilities to
+// Float16 can explain, half an ulp relative to each, so 2^-11 of the total.
+func Categorical(r *rand.Rand, probs []Float16) (int, error) {
+	var total float64
+	for i, p := range probs {
+		pf := p.ToFloat64()
+		if !(pf >= 0) || math.IsInf(pf, 0) {
+			return 0, &Float16Error{
+				Op:   "Categorical",
+				Msg:  fmt.Sprintf("invalid probability %v at index %d", p, i),
+				Code: ErrInvalidOperation,
+			}
+		}
+		total += pf
+	}
+	if total == 0 {
+		return 0, &Float16Error{Op: "Categorical", Msg: "probabilities sum to zero", Code: ErrInvalidOperation}
+	}
+	// Each probability is within 2^-11 of its intended value relative to
+	// itself; the float64 sum adds far less
+	tolerance := total*math.Ldexp(1, -MantissaLen-1) + float64(len(probs))*0x1p-52
+	if math.Abs(total-1) > tolerance {
+		return 0, &Float16Error{
+			Op:   "Categorical",
+			Msg:  fmt.Sprintf("probabilities sum to %g, want 1", total),
+			Code: ErrInvalidOperation,
+		}
+	}
+
+	u := r.Float64() * total
+	var prefix float64
+	last := 0
+	for i, p := range probs {
+		if p.IsZero() {
+			continue
+		}
+		prefix += p.ToFloat64()
+		if u < prefix {
+			return i, nil
+		}
+		last = i
+	}
+	// Rounding in the prefix sums can leave u at the very top of the range
+	return last, nil
+}
@@ -0,0 +1,157 @@
+package float16
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// withinBinomial reports whether count is within 5 standard deviations of n*p
+func withinBinomial(count, n int, p float64) bool {
+	mean := float64(n) * p
+	sd := math.Sqrt(float64(n) * p * (1 - p))
+	return math.Abs(float64(count)-mean) <= 5*sd+1
+}
+
+func TestRandomExponential(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	rate := FromInt(4)
+	const n = 20000
+	var sum float64
+	for i := 0; i < n; i++ {
+		v := RandomExponential(r, rate)
+		if v.Signbit() || !v.IsFinite() {
+			t.Fatalf("invalid sample %v", v)
+		}
+		sum += v.ToFloat64()
+	}
+	// Mean 1/rate with standard error (1/rate)/sqrt(n)
+	if mean := sum / n; math.Abs(mean-0.25) > 5*0.25/math.Sqrt(n) {
+		t.Errorf("sample mean = %v, want ~0.25", mean)
+	}
+
+	for _, bad := range []Float16{PositiveZero, FromInt(-1), QuietNaN} {
+		if !RandomExponential(r, bad).IsNaN() {
+			t.Errorf("RandomExponential(rate=%v) should be NaN", bad)
+		}
+	}
+	if got := RandomExponential(r, PositiveInfinity); !got.IsZero() {
+		t.Errorf("RandomExponential(rate=+Inf) = %v, want 0", got)
+	}
+}
+
+func TestBernoulli(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 10000; i++ {
+		if Bernoulli(r, PositiveZero) {
+			t.Fatal("Bernoulli(0) returned true")
+		}
+		if !Bernoulli(r, One()) {
+			t.Fatal("Bernoulli(1) returned false")
+		}
+	}
+
+	const n = 40000
+	for _, p := range []Float16{FromFloat32(0.3), FromFloat32(0.5), SmallestNormal, FromFloat32(0.999)} {
+		count := 0
+		for i := 0; i < n; i++ {
+			if Bernoulli(r, p) {
+				count++
+			}
+		}
+		if !withinBinomial(count, n, p.ToFloat64()) {
+			t.Errorf("Bernoulli(%v): %d successes in %d trials", p, count, n)
+		}
+	}
+
+	for _, bad := range []Float16{QuietNaN, FromFloat32(-0.1), FromFloat32(1.5)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Bernoulli(%v) did not panic", bad)
+				}
+			}()
+			Bernoulli(r, bad)
+		}()
+	}
+}
+
+func TestCategorical(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	probs := []Float16{FromFloat32(0.1), PositiveZero, FromFloat32(0.6), FromFloat32(0.3)}
+	const n = 30000
+	counts := make([]int, len(probs))
+	for i := 0; i < n; i++ {
+		k, err := Categorical(r, probs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts[k]++
+	}
+	if counts[1] != 0 {
+		t.Errorf("zero-probability category drawn %d times", counts[1])
+	}
+	for i, p := range probs {
+		if !withinBinomial(counts[i], n, p.ToFloat64()) {
+			t.Errorf("category %d: %d draws, expected ~%v", i, counts[i], float64(n)*p.ToFloat64())
+		}
+	}
+
+	// A thousand probabilities of 0.001 round up by about 4e-4 each, but the
+	// total stays within 2^-11 of 1
+	thousandths := make([]Float16, 1000)
+	for i := range thousandths {
+		thousandths[i] = FromFloat64(0.001)
+	}
+	if _, err := Categorical(r, thousandths); err != nil {
+		t.Errorf("1000 × 0.001: %v", err)
+	}
+
+	// The tolerance must not grow with the length of the slice
+	long := make([]Float16, 4096)
+	for i := range long {
+		long[i] = FromFloat64(1.5 / 4096)
+	}
+
+	bad := map[string][]Float16{
+		"NaN":           {FromFloat32(0.5), QuietNaN},
+		"negative":      {FromFloat32(1.5), FromFloat32(-0.5)},
+		"zero total":    {PositiveZero, NegativeZero},
+		"empty":         nil,
+		"not summing":   {FromFloat32(0.2), FromFloat32(0.2)},
+		"infinite":      {PositiveInfinity},
+		"long, sum 1.5": long,
+	}
+	for name, p := range bad {
+		var fe *Float16Error
+		if _, err := Categorical(r, p); !errors.As(err, &fe) || fe.Code != ErrInvalidOperation {
+			t.Errorf("%s: err = %v, want ErrInvalidOperation", name, err)
+		}
+	}
+}
+
+func TestRandomDeterminism(t *testing.T) {
+	draw := func() []int {
+		r := rand.New(rand.NewSource(99))
+		var out []int
+		probs := []Float16{FromFloat32(0.25), FromFloat32(0.75)}
+		for i := 0; i < 50; i++ {
+			k, _ := Categorical(r, probs)
+			out = append(out, k, int(RandomExponential(r, One())))
+			if Bernoulli(r, FromFloat32(0.5)) {
+				out = append(out, 1)
+			}
+		}
+		return out
+	}
+	a, b := draw(), draw()
+	if len(a) != len(b) {
+		t.Fatal("draw sequences differ in length")
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("draw %d differs: %d vs %d", i, a[i], b[i])
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package float16
+
+import (
+	"math"
+	"math/big"
+)
+
+// Reference conversion
+//
+// ReferenceFromFloat64 is a slow but obviously correct oracle for the fast
+// conversions: all rounding is delegated to math/big, which rounds exactly in
+// every mode, and the bit pattern is assembled from the rounded value.
+
+// bigRoundingModes maps each RoundingMode to the equivalent big.Float mode
+var bigRoundingModes = map[RoundingMode]big.RoundingMode{
+	RoundNearestEven:    big.ToNearestEven,
+	RoundTowardZero:     big.ToZero,
+	RoundTowardPositive: big.ToPositiveInf,
+	RoundTowardNegative: big.ToNegativeInf,
+	RoundNearestAway:    big.ToNearestAway,
+}
+
+// ReferenceFromFloat64 converts f64 to Float16 with exact rounding in the
+// given mode, using big.Float arithmetic only. It is meant as a testing
+// oracle for ToFloat16, FromFloat64 and the other conversions, and is far
+// too slow for anything else. Finite values past MaxValue overflow to
+// infinity when the mode rounds away from zero in that direction and
+// saturate at ±MaxValue otherwise; NaNs follow the package NaN policy.
+// Unknown modes round to nearest even.
+func ReferenceFromFloat64(f64 float64, mode RoundingMode) Float16 {
+	switch {
+	case math.IsNaN(f64):
+		return nanFromFloat64(f64)
+	case math.IsInf(f64, 1):
+		return PositiveInfinity
+	case math.IsInf(f64, -1):
+		return NegativeInfinity
+	}
+	var sign Float16
+	if math.Signbit(f64) {
+		sign = SignMask
+	}
+	if f64 == 0 {
+		return sign
+	}
+
+	bigMode, ok := bigRoundingModes[mode]
+	if !ok {
+		bigMode = big.ToNearestEven
+	}
+	x := new(big.Float).SetFloat64(f64)
+
+	if math.Abs(f64) < 0x1p-14 {
+		// Subnormal range: the result is k·2^-24 for an integer k <= 1024,
+		// which is also its bit pattern (1024 is SmallestNormal). Adding
+		// ±2^24 at 25 bits of precision rounds x·2^24 to an integer in the
+		// requested mode.
+		offset := big.NewFloat(math.Copysign(0x1p24, f64))
+		scaled := new(big.Float).SetMantExp(x, 24)
+		r := new(big.Float).SetPrec(25).SetMode(bigMode).Add(scaled, offset)
+		r.Sub(r, offset).Abs(r)
+		k, _ := r.Int64()
+		return sign | Float16(k)
+	}
+
+	r := new(big.Float).SetPrec(11).SetMode(bigMode).Set(x)
+	r.Abs(r)
+	if r.Cmp(big.NewFloat(MaxValue.ToFloat64())) > 0 {
+		if roundsToInfinity(bigMode, sign != 0) {
+			return sign | PositiveInfinity
+		}
+		return sign | MaxValue
+	}
+	// r = m·2^e with m in [0.5, 1) and 11 significant bits
+	m := new(big.Float)
+	e := r.MantExp(m)
+	frac, _ := m.SetMantExp(m, 11).Int64()
+	return sign | Float16(e-1+ExponentBias)<<10 | Float16(frac-1024)
+}
+
+// roundsToInfinity reports whether mode turns an overflow of the given sign
+// into infinity rather than the largest finite value
+func roundsToInfinity(mode big.RoundingMode, negative bool) bool {
+	switch mode {
+	case big.ToZero:
+		return false
+	case big.ToPositiveInf:
+		return !negative
+	case big.ToNegativeInf:
+		return negative
+	}
+	return true
+}
@@ -0,0 +1,183 @@
+package float16
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestReferenceFromFloat64(t *testing.T) {
+	tests := []struct {
+		in   float64
+		mode RoundingMode
+		want Float16
+	}{
+		{1, RoundNearestEven, One16},
+		{0.1, RoundNearestEven, FromBits(0x2e66)},
+		{0.1, RoundTowardPositive, FromBits(0x2e67)},
+		{-0.1, RoundTowardZero, FromBits(0xae66)},
+		{1 + 0x1p-11, RoundNearestEven, One16},
+		{1 + 0x1p-11, RoundNearestAway, FromBits(0x3c01)},
+		{65519, RoundNearestEven, MaxValue},
+		{65520, RoundNearestEven, PositiveInfinity},
+		{1e6, RoundTowardZero, MaxValue},
+		{-1e6, RoundTowardPositive, MinValue},
+		{-1e6, RoundTowardNegative, NegativeInfinity},
+		{0x1p-25, RoundNearestEven, PositiveZero},
+		{0x1p-25 + 0x1p-40, RoundNearestEven, SmallestSubnormal},
+		{1e-30, RoundTowardPositive, SmallestSubnormal},
+		{-1e-30, RoundTowardPositive, NegativeZero},
+		{0x1p-14 - 0x1p-26, RoundNearestEven, SmallestNormal},
+		{3 * 0x1p-25, RoundNearestEven, FromBits(0x0002)},
+		{math.Copysign(0, -1), RoundNearestEven, NegativeZero},
+		{math.Inf(-1), RoundTowardZero, NegativeInfinity},
+	}
+	for _, tt := range tests {
+		if got := ReferenceFromFloat64(tt.in, tt.mode); got != tt.want {
+			t.Errorf("ReferenceFromFloat64(%g, %v) = 0x%04x, want 0x%04x", tt.in, tt.mode, uint16(got), uint16(tt.want))
+		}
+	}
+	if got := ReferenceFromFloat64(math.NaN(), RoundNearestEven); !got.IsNaN() {
+		t.Errorf("ReferenceFromFloat64(NaN) = %v", got)
+	}
+}
+
+// referenceSample returns float64 inputs around every Float16 rounding
+// boundary and random values across the whole Float16 range
+func referenceSample() []float64 {
+	var sample []float64
+	for b := Float16(0); b < PositiveInfinity; b++ {
+		lo, hi, _, _ := RoundingInterval(b)
+		for _, x := range []float64{b.ToFloat64(), lo, hi} {
+			sample = append(sample, x, math.Nextafter(x, 0), math.Nextafter(x, math.Inf(1)))
+		}
+	}
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 1<<16; i++ {
+		sample = append(sample, math.Ldexp(r.Float64(), r.Intn(52)-32))
+	}
+	n := len(sample)
+	for i := 0; i < n; i++ {
+		sample = append(sample, -sample[i])
+	}
+	return sample
+}
+
+func TestFastConversionsMatchReference(t *testing.T) {
+	modes := []RoundingMode{RoundNearestEven, RoundTowardZero, RoundTowardPositive, RoundTowardNegative, RoundNearestAway}
+	mismatches := map[string]int{}
+	for _, x := range referenceSample() {
+		want := ReferenceFromFloat64(x, RoundNearestEven)
+		for name, got := range map[string]Float16{
+			"ToFloat16":   ToFloat16(x),
+			"FromFloat64": FromFloat64(x),
+		} {
+			if got != want {
+				if mismatches[name] == 0 {
+					t.Errorf("%s(%v) = 0x%04x, reference 0x%04x", name, x, uint16(got), uint16(want))
+				}
+				mismatches[name]++
+			}
+		}
+		for _, mode := range modes {
+			want := ReferenceFromFloat64(x, mode)
+			got, _ := FromFloat64Counted(x, ModeIEEE, mode)
+			if got != want {
+				if mismatches["FromFloat64Counted"] == 0 {
+					t.Errorf("FromFloat64Counted(%v, %v) = 0x%04x, reference 0x%04x", x, mode, uint16(got), uint16(want))
+				}
+				mismatches["FromFloat64Counted"]++
+			}
+			if f32 := float32(x); float64(f32) == x {
+				got := FromFloat32WithRounding(f32, mode)
+				if got != want {
+					if mismatches["FromFloat32WithRounding"] == 0 {
+						t.Errorf("FromFloat32WithRounding(%v, %v) = 0x%04x, reference 0x%04x", f32, mode, uint16(got), uint16(want))
+					}
+					mismatches["FromFloat32WithRounding"]++
+				}
+			}
+		}
+	}
+	for name, n := range mismatches {
+		t.Errorf("%s: %d mismatches with ReferenceFromFloat64", name, n)
+	}
+}
+
+func TestFromFloat64WithModeMatchesReference(t *testing.T) {
+	modes := []RoundingMode{RoundNearestEven, RoundTowardZero, RoundTowardPositive, RoundTowardNegative, RoundNearestAway}
+	for _, mode := range modes {
+		mismatches := 0
+		for _, x := range referenceSample() {
+			got, err := FromFloat64WithMode(x, ModeIEEE, mode)
+			if want := ReferenceFromFloat64(x, mode); err != nil || got != want {
+				if mismatches == 0 {
+					t.Errorf("FromFloat64WithMode(%v, %v) = 0x%04x, %v, reference 0x%04x", x, mode, uint16(got), err, uint16(want))
+				}
+				mismatches++
+			}
+		}
+		if mismatches > 0 {
+			t.Errorf("%v: %d mismatches with ReferenceFromFloat64", mode, mismatches)
+		}
+	}
+
+	// Inputs that narrowing through float32 first rounded twice
+	tests := []struct {
+		x    float64
+		mode RoundingMode
+		want Float16
+	}{
+		{1.0004882812500002, RoundNearestEven, 0x3c01},
+		{1.7, RoundTowardZero, 0x3ecc},
+	}
+	for _, tt := range tests {
+		if got, _ := FromFloat64WithMode(tt.x, ModeIEEE, tt.mode); got != tt.want {
+			t.Errorf("FromFloat64WithMode(%v, %v) = 0x%04x, want 0x%04x", tt.x, tt.mode, uint16(got), uint16(tt.want))
+		}
+		if tt.mode == RoundNearestEven {
+			if got, _ := ToFloat16WithMode(tt.x, ModeStrict); got != tt.want {
+				t.Errorf("ToFloat16WithMode(%v) = 0x%04x, want 0x%04x", tt.x, uint16(got), uint16(tt.want))
+			}
+		}
+	}
+}
+
+func TestFromFloat64SubnormalRange(t *testing.T) {
+	check := func(x float64) {
+		t.Helper()
+		got, want := FromFloat64(x), ReferenceFromFloat64(x, RoundNearestEven)
+		if got != want {
+			t.Errorf("FromFloat64(%v) = 0x%04x, reference 0x%04x", x, uint16(got), uint16(want))
+		}
+	}
+
+	// float64 subnormals lie far below half of SmallestSubnormal and round
+	// to zeros that keep their sign
+	for _, x := range []float64{math.SmallestNonzeroFloat64, 0x1p-1060, math.Float64frombits(0x000FFFFFFFFFFFFF)} {
+		check(x)
+		check(-x)
+		if got := FromFloat64(-x); got != NegativeZero {
+			t.Errorf("FromFloat64(%v) = %v, want -0", -x, got)
+		}
+	}
+
+	// Values one float64 ulp either side of the midpoints between Float16
+	// subnormals: narrowing to float32 first would round them onto the
+	// midpoint and then to even, a double rounding
+	for k := 0; k < 1<<MantissaLen; k++ {
+		mid := (float64(k) + 0.5) * 0x1p-24
+		for _, x := range []float64{math.Nextafter(mid, 0), mid, math.Nextafter(mid, 1)} {
+			check(x)
+			check(-x)
+		}
+	}
+
+	// Full-precision float64 values spread over the Float16 subnormal range
+	r := rand.New(rand.NewSource(1))
+	for range 100000 {
+		x := math.Ldexp(1+r.Float64(), -15-r.Intn(12))
+		check(x)
+		check(-x)
+	}
+}
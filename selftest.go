@@ -0,0 +1,124 @@
+package float16
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+)
+
+// Startup self-check
+
+// selfCheck is one invariant verified by SelfTest
+type selfCheck struct {
+	name string
+	ok   bool
+}
+
+// selfTestStatus holds the outcome of the last SelfTest for DebugInfo
+var selfTestStatus atomic.Value // string
+
+// SelfTest verifies a set of invariants the package relies on: that the
+// special constants classify correctly, that the limits convert to their
+// documented values, that basic arithmetic and rounding behave, and that a
+// fixed sample of bit patterns round-trips through float32 and float64. It
+// catches a broken build or a refactor that silently swapped conventions, and
+// runs in microseconds, so it is cheap enough for a production startup check.
+// It returns an error naming the first invariant that does not hold, and
+// records the outcome for DebugInfo.
+func SelfTest() error {
+	err := runSelfChecks(selfChecks())
+	if err == nil {
+		err = roundTripSelfCheck()
+	}
+	if err != nil {
+		selfTestStatus.Store(err.Error())
+	} else {
+		selfTestStatus.Store("ok")
+	}
+	return err
+}
+
+// lastSelfTestStatus returns "ok", the error of the last failed SelfTest, or
+// "not run"
+func lastSelfTestStatus() string {
+	if s, ok := selfTestStatus.Load().(string); ok {
+		return s
+	}
+	return "not run"
+}
+
+func selfChecks() []selfCheck {
+	return []selfCheck{
+		{"QuietNaN.IsNaN()", QuietNaN.IsNaN()},
+		{"QuietNaN.Class() == ClassQuietNaN", QuietNaN.Class() == ClassQuietNaN},
+		{"SignalingNaN.IsNaN()", SignalingNaN.IsNaN()},
+		{"SignalingNaN.Class() == ClassSignalingNaN", SignalingNaN.Class() == ClassSignalingNaN},
+		{"QuietNaN.ToFloat32() is NaN", math.IsNaN(float64(QuietNaN.ToFloat32()))},
+		{"PositiveInfinity.IsInf(1)", PositiveInfinity.IsInf(1)},
+		{"NegativeInfinity.IsInf(-1)", NegativeInfinity.IsInf(-1)},
+		{"MaxValue.ToFloat32() == 65504", MaxValue.ToFloat32() == 65504},
+		{"MinValue == -MaxValue", MinValue == MaxValue.Neg()},
+		{"SmallestNormal.ToFloat64() == 2^-14", SmallestNormal.ToFloat64() == 0x1p-14},
+		{"SmallestSubnormal.ToFloat64() == 2^-24", SmallestSubnormal.ToFloat64() == 0x1p-24},
+		{"SmallestSubnormal.IsSubnormal()", SmallestSubnormal.IsSubnormal()},
+		{"NegativeZero.Signbit()", NegativeZero.Signbit() && NegativeZero.IsZero()},
+		{"PositiveZero.IsPositiveZero()", PositiveZero.IsPositiveZero()},
+		{"-0 precedes +0 in total order", NegativeZero.TotalOrderInt() < PositiveZero.TotalOrderInt()},
+		{"One16.ToFloat32() == 1", One16.ToFloat32() == 1},
+		{"Add(One16, One16) == Two16", Add(One16, One16) == Two16},
+		{"Sub(One16, One16) == +0", Sub(One16, One16) == PositiveZero},
+		{"Mul(Two16, Two16) == Four16", Mul(Two16, Two16) == Four16},
+		{"Div(One16, Two16) == Half16", Div(One16, Two16) == Half16},
+		{"Sqrt(Four16) == Two16", Sqrt(Four16) == Two16},
+		{"FromFloat32(65519) == MaxValue", FromFloat32(65519) == MaxValue},
+		{"FromFloat32(65520) == +Inf", FromFloat32(65520) == PositiveInfinity},
+		{"FromFloat64(2^-25) == +0", FromFloat64(0x1p-25) == PositiveZero},
+		{"FromFloat64(1.5·2^-25) == SmallestSubnormal", FromFloat64(0x1.8p-25) == SmallestSubnormal},
+	}
+}
+
+// runSelfChecks returns an error naming the first failed check
+func runSelfChecks(checks []selfCheck) error {
+	for _, c := range checks {
+		if !c.ok {
+			return &Float16Error{
+				Op:   "SelfTest",
+				Msg:  "invariant failed: " + c.name,
+				Code: ErrInvalidOperation,
+			}
+		}
+	}
+	return nil
+}
+
+// selfTestSample returns 100 fixed pseudo-random bit patterns from a 16-bit
+// Galois LFSR, a sequence that visits every nonzero pattern
+func selfTestSample() (sample [100]Float16) {
+	x := uint16(0xACE1)
+	for i := range sample {
+		x = x>>1 ^ -(x&1)&0xB400
+		sample[i] = Float16(x)
+	}
+	return sample
+}
+
+// roundTripSelfCheck converts the sample to float32 and float64 and back,
+// expecting every non-NaN value unchanged and NaNs to stay NaN
+func roundTripSelfCheck() error {
+	for _, f := range selfTestSample() {
+		via32, via64 := FromFloat32(f.ToFloat32()), FromFloat64(f.ToFloat64())
+		if f.IsNaN() {
+			if via32.IsNaN() && via64.IsNaN() {
+				continue
+			}
+		} else if via32 == f && via64 == f {
+			continue
+		}
+		return &Float16Error{
+			Op:   "SelfTest",
+			Msg:  fmt.Sprintf("invariant failed: round trip of %#04x gave %#04x via float32 and %#04x via float64", uint16(f), uint16(via32), uint16(via64)),
+			Code: ErrInvalidOperation,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package float16
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSelfTest(t *testing.T) {
+	if err := SelfTest(); err != nil {
+		t.Fatal(err)
+	}
+	if got := DebugInfo()["self_test"]; got != "ok" {
+		t.Errorf(`DebugInfo()["self_test"] = %v, want "ok"`, got)
+	}
+}
+
+func TestSelfTestReportsFailure(t *testing.T) {
+	err := runSelfChecks([]selfCheck{
+		{"holds", true},
+		{"QuietNaN swapped with SignalingNaN", false},
+		{"also broken", false},
+	})
+	var fe *Float16Error
+	if !errors.As(err, &fe) || !strings.Contains(fe.Msg, "QuietNaN swapped with SignalingNaN") {
+		t.Errorf("runSelfChecks error = %v, want the first failed invariant named", err)
+	}
+}
+
+func TestSelfTestRoundTripSample(t *testing.T) {
+	// The sample must cover a mix of classes to be worth running
+	seen := make(map[FloatClass]bool)
+	for _, f := range selfTestSample() {
+		seen[f.Class()] = true
+	}
+	for _, c := range []FloatClass{ClassPositiveNormal, ClassNegativeNormal} {
+		if !seen[c] {
+			t.Errorf("round-trip sample has no %v values", c)
+		}
+	}
+}
+
+func BenchmarkSelfTest(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		SelfTest()
+	}
+}
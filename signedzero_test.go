@@ -0,0 +1,631 @@
+package float16
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Signed zeros across the public API
+//
+// Every exported function and method whose signature mentions Float16 needs
+// an entry in signedZeroCases, or a reason in signedZeroExempt when it has no
+// zero to carry. TestSignedZeroCoverage enforces that, so a new API fails the
+// suite until its handling of -0 is stated.
+//
+// The expected signs follow IEEE 754: x + (-x) and -0 + +0 are +0 except
+// under RoundTowardNegative, products and quotients take the exclusive-or of
+// the operand signs, and odd functions such as Sin, Sqrt and J1 keep the sign
+// of a zero argument. IEEE 754 leaves the sign of a reduction open; the
+// reductions here start from +0, as a Go loop over a float accumulator does,
+// so a sum of -0 terms is +0.
+
+// signedZeroCase states the result of one API call on a signed zero. Results
+// are compared through their Go syntax, which spells out Float16 bit patterns
+// and the sign of float32 and float64 zeros.
+type signedZeroCase struct {
+	api       string
+	got, want any
+}
+
+// signedZeroExempt lists the APIs that take or return Float16 but have no
+// zero whose sign could be lost, with the reason
+var signedZeroExempt = map[string]string{
+	"Inf":               "returns an infinity",
+	"NaN":               "returns a NaN",
+	"One":               "returns 1",
+	"GenerateBenchData": "random benchmark data; its profiles are covered by throughput_test.go",
+}
+
+// vals collects the results of a multi-valued call for a case
+func vals(v ...any) []any { return v }
+
+// panics reports whether fn panics
+func panics(fn func()) (panicked bool) {
+	defer func() { panicked = recover() != nil }()
+	fn()
+	return false
+}
+
+func signedZeroCases() []signedZeroCase {
+	n0, p0 := NegativeZero, PositiveZero
+	five, negFive := Five16, Five16.Neg()
+	nz := []Float16{n0}
+	nz64 := math.Copysign(0, -1)
+	nz32 := float32(nz64)
+	nsub := SmallestSubnormal.Neg()
+	rng := rand.New(rand.NewSource(1))
+	var onnxBuf bytes.Buffer
+
+	var acc DotAccumulator
+	acc.Add(n0, One16)
+	var accSlices DotAccumulator
+	accErr := accSlices.AddSlices(nz, []Float16{One16})
+	ema, emaErr := NewEMAFilter(Half16, NaNPropagate)
+	emaFirst := ema.Update(n0)
+	emaSlice, _ := NewEMAFilter(Half16, NaNPropagate)
+	_, zeroAlphaErr := NewEMAFilter(n0, NaNPropagate)
+
+	var unmarshaled Float16
+	unmarshalErr := unmarshaled.UnmarshalBinaryBE([]byte{0x80, 0x00})
+	le := make([]byte, 2)
+	leN, leErr := PutSlice(le, nz, binary.LittleEndian)
+	be := make([]byte, 2)
+	beN, beErr := PutSliceBE(be, nz)
+
+	gathered := make([]Float16, 1)
+	gatherErr := GatherInto(gathered, []Float16{One16, n0}, 1, 1)
+	scattered := []Float16{One16, One16}
+	scatterErr := ScatterInto(scattered, nz, 1, 1)
+	reversed := []Float16{n0, p0}
+	Reverse(reversed)
+	rotated := []Float16{n0, p0}
+	Rotate(rotated, 1)
+	normalized := make([]Float16, 2)
+	NormalizeInto(normalized, []Float16{n0, five})
+	fused := []Float16{n0, p0}
+	FusedNormalizeInto(fused, fused, One16)
+	rsqrts := []Float16{n0, p0}
+	RsqrtSliceInto(rsqrts, rsqrts)
+	powInto := make([]Float16, 1)
+	PowSliceInto(powInto, nz, Three16)
+	powElemInto := make([]Float16, 1)
+	PowSliceElementwiseInto(powElemInto, nz, []Float16{Three16})
+
+	unit, unitErr := NewUnitInterval(n0)
+	lgamma, lgammaSign := Lgamma(n0)
+	partFinite, _ := PartitionFinite([]Float16{n0, QuietNaN})
+	mp, _, _, mpErr := MaxPool2D([]Float16{n0, n0, n0, n0}, 2, 2, 2, 2, 2)
+	mpe, _, _, _ := MaxPool2DWithEdge([]Float16{n0, p0, n0, n0}, 2, 2, 2, 2, 2, PoolDropPartial)
+	ap, _, _, _ := AvgPool2D([]Float16{n0, n0, n0, n0}, 2, 2, 2, 2, 2)
+	ape, _, _, _ := AvgPool2DWithEdge([]Float16{n0, n0, n0, n0}, 2, 2, 2, 2, 2, PoolExcludePadding)
+	lo, hi, loIncl, hiIncl := RoundingInterval(n0)
+
+	return []signedZeroCase{
+		// absmax.go
+		{"AbsMax", AbsMax([]Float16{n0, n0}), p0},
+		{"MinMaxAbs", vals(MinMaxAbs(nz)), vals(p0, p0, nil)},
+		{"MinMaxAbsWithPolicy", vals(MinMaxAbsWithPolicy(nz, InfInclude)), vals(p0, p0, 0, nil)},
+
+		// absorb.go
+		{"Absorbs", vals(Absorbs(n0, p0), Absorbs(p0, n0), Absorbs(n0, n0)), vals(false, true, true)},
+		{"AbsorptionThreshold", AbsorptionThreshold(n0), p0},
+
+		// accumulator.go
+		{"DotAccumulator.Add", acc.Result(), p0},
+		{"DotAccumulator.AddSlices", vals(accSlices.Result(), accErr), vals(p0, nil)},
+		{"DotAccumulator.Result", new(DotAccumulator).Result(), p0},
+
+		// activation.go
+		{"HardSigmoid", HardSigmoid(n0), Half16},
+		{"HardSwish", HardSwish(n0), n0},
+		{"HardTanh", HardTanh(n0), n0},
+		{"Sigmoid", Sigmoid(n0), Half16},
+		{"SigmoidTanh", SigmoidTanh(n0), Half16},
+
+		// arithmetic.go
+		{"Add", vals(Add(n0, p0), Add(p0, n0), Add(n0, n0), Add(One16, negOne16)), vals(p0, p0, n0, p0)},
+		{"AddSlice", AddSlice([]Float16{n0, n0}, []Float16{n0, p0}), []Float16{n0, p0}},
+		{"AddWithMode", vals(
+			first(AddWithMode(n0, p0, ModeIEEEArithmetic, RoundNearestEven)),
+			first(AddWithMode(n0, p0, ModeIEEEArithmetic, RoundTowardNegative)),
+			first(AddWithMode(One16, negOne16, ModeIEEEArithmetic, RoundTowardNegative)),
+			first(AddWithMode(One16, negOne16, ModeIEEEArithmetic, RoundTowardPositive)),
+			first(AddWithMode(n0, n0, ModeFastArithmetic, RoundNearestEven)),
+		), vals(p0, n0, n0, p0, n0)},
+		{"CompareInt", vals(CompareInt(n0, 0)), vals(0, true)},
+		{"Div", vals(Div(n0, five), Div(n0, negFive), Div(One16, n0), Div(negOne16, n0)), vals(n0, p0, NegativeInfinity, PositiveInfinity)},
+		{"DivSlice", DivSlice(nz, []Float16{five}), nz},
+		{"DivWithMode", vals(DivWithMode(n0, five, ModeIEEEArithmetic, RoundNearestEven)), vals(n0, nil)},
+		{"DotProduct", DotProduct(nz, []Float16{One16}), p0},
+		{"DotProductCompensated", DotProductCompensated(nz, []Float16{One16}), p0},
+		{"Equal", Equal(n0, p0), true},
+		{"EqualsInt", EqualsInt(n0, 0), true},
+		{"Float16.EqualFloat64", vals(n0.EqualFloat64(0), p0.EqualFloat64(nz64)), vals(true, true)},
+		{"Float16.GreaterFloat64", p0.GreaterFloat64(nz64), false},
+		{"Float16.LessFloat64", n0.LessFloat64(0), false},
+		{"Greater", Greater(p0, n0), false},
+		{"GreaterEqual", GreaterEqual(n0, p0), true},
+		{"IsInRangeInt", IsInRangeInt(n0, 0, 0), true},
+		{"Less", Less(n0, p0), false},
+		{"LessEqual", LessEqual(p0, n0), true},
+		{"Max", vals(Max(n0, p0), Max(p0, n0)), vals(p0, p0)},
+		{"Min", vals(Min(n0, p0), Min(p0, n0)), vals(n0, n0)},
+		{"Mul", vals(Mul(n0, five), Mul(n0, negFive), Mul(five, n0)), vals(n0, p0, n0)},
+		{"MulSlice", MulSlice(nz, []Float16{five}), nz},
+		{"MulWithMode", vals(MulWithMode(n0, five, ModeIEEEArithmetic, RoundNearestEven)), vals(n0, nil)},
+		{"Norm2", Norm2(nz), p0},
+		{"ScaleSlice", ScaleSlice([]Float16{n0, One16}, negOne16), []Float16{p0, negOne16}},
+		{"ScaleSliceWithMode", ScaleSliceWithMode(nz, five, RoundTowardZero), nz},
+		{"SliceMax", SliceMax([]Float16{n0, p0}), p0},
+		{"SliceMaxPropagate", SliceMaxPropagate([]Float16{p0, n0}), p0},
+		{"SliceMin", SliceMin([]Float16{p0, n0}), n0},
+		{"SliceMinPropagate", SliceMinPropagate([]Float16{n0, p0}), n0},
+		{"Sub", vals(Sub(n0, p0), Sub(p0, p0), Sub(n0, n0), Sub(One16, One16)), vals(n0, p0, p0, p0)},
+		{"SubSlice", SubSlice([]Float16{n0, One16}, []Float16{p0, One16}), []Float16{n0, p0}},
+		{"SubWithMode", vals(
+			first(SubWithMode(One16, One16, ModeIEEEArithmetic, RoundTowardNegative)),
+			first(SubWithMode(n0, n0, ModeIEEEArithmetic, RoundTowardNegative)),
+			first(SubWithMode(n0, p0, ModeIEEEArithmetic, RoundTowardPositive)),
+		), vals(n0, n0, n0)},
+		{"SumSlice", vals(SumSlice(nil), SumSlice([]Float16{n0, n0})), vals(p0, p0)},
+
+		// bfloat16.go
+		{"BFloat16.ToFloat16", BFloat16FromBits(0x8000).ToFloat16(), n0},
+		{"BFloat16FromFloat16", BFloat16FromFloat16(n0), BFloat16FromBits(0x8000)},
+		{"Float16.ToBFloat16", n0.ToBFloat16(), BFloat16FromBits(0x8000)},
+		{"Float16FromBFloat16", Float16FromBFloat16(BFloat16FromBits(0x8000)), n0},
+
+		// blockscale.go
+		{"BlockDescale", math.Signbit(float64(BlockDescale([]Float16{One16}, []Float16{n0}, 1)[0])), true},
+		{"BlockScale", vals(BlockScale([]float32{nz32}, 1)), vals([]Float16{One16}, nz, nil)},
+
+		// bytes.go
+		{"Float16.MarshalBinaryBE", n0.MarshalBinaryBE(), []byte{0x80, 0x00}},
+		{"Float16.UnmarshalBinaryBE", vals(unmarshaled, unmarshalErr), vals(n0, nil)},
+		{"PutSlice", vals(le, leN, leErr), vals([]byte{0x00, 0x80}, 2, nil)},
+		{"PutSliceBE", vals(be, beN, beErr), vals([]byte{0x80, 0x00}, 2, nil)},
+		{"ReadSlice", vals(ReadSlice([]byte{0x00, 0x80}, binary.LittleEndian)), vals(nz, nil)},
+		{"ReadSliceBE", vals(ReadSliceBE([]byte{0x80, 0x00})), vals(nz, nil)},
+
+		// calibrate.go
+		{"CalibrateClipThreshold", vals(CalibrateClipThreshold(nz, []Float16{One16})), vals(One16, 0.0)},
+		{"CalibrateClipThresholdBits", vals(CalibrateClipThresholdBits(nz, []Float16{One16}, 4)), vals(One16, 0.0)},
+
+		// conv.go
+		{"Conv2DSeparable", vals(Conv2DSeparable(nz, 1, 1, []Float16{One16}, []Float16{One16})), vals([]Float16{p0}, nil)},
+		{"Conv2DSeparableWithBorder", vals(Conv2DSeparableWithBorder(nz, 1, 1, []Float16{One16}, []Float16{One16}, BorderZero)), vals([]Float16{p0}, nil)},
+		{"GaussianKernel", panics(func() { GaussianKernel(n0, 1) }), true},
+
+		// convert.go
+		{"Bracket", vals(Bracket(nz64)), vals(n0, n0)},
+		{"Float16.ToFloat32", n0.ToFloat32(), nz32},
+		{"Float16.ToFloat64", n0.ToFloat64(), nz64},
+		{"FromFloat32", vals(FromFloat32(nz32), FromFloat32(-1e-10)), vals(n0, n0)},
+		{"FromFloat32WithRounding", vals(FromFloat32WithRounding(nz32, RoundTowardPositive), FromFloat32WithRounding(-1e-10, RoundTowardPositive)), vals(n0, n0)},
+		{"FromFloat64", vals(FromFloat64(nz64), FromFloat64(-1e-10)), vals(n0, n0)},
+		{"FromFloat64Counted", first(FromFloat64Counted(nz64, ModeIEEE, RoundNearestEven)), n0},
+		{"FromFloat64WithMode", vals(FromFloat64WithMode(nz64, ModeStrict, RoundNearestEven)), vals(n0, nil)},
+		{"FromInt", FromInt(0), p0},
+		{"FromInt32", FromInt32(0), p0},
+		{"FromInt64", FromInt64(0), p0},
+		{"FromInt64Round", FromInt64Round(0, RoundTowardNegative), p0},
+		{"FromRatio", vals(first(FromRatio(0, -5)), first(FromRatio(-1, 1<<40))), vals(p0, n0)},
+		{"FromSlice64", FromSlice64([]float64{nz64}), nz},
+		{"Parse", vals(first(Parse("-0")), first(Parse("-1e-10"))), vals(n0, n0)},
+		{"ParseFloat", vals(ParseFloat("-0", 16)), vals(n0, nil)},
+		{"ToFloat16", ToFloat16(nz64), n0},
+		{"ToFloat16NoInline", ToFloat16NoInline(nz32), n0},
+		{"ToFloat32NoInline", math.Signbit(float64(ToFloat32NoInline(n0))), true},
+		{"ToFloat16WithMode", vals(ToFloat16WithMode(nz64, ModeStrict)), vals(n0, nil)},
+		{"ToFloat32WithMode", vals(ToFloat32WithMode(n0, SubnormalExact), ToFloat32WithMode(nsub, SubnormalFlushToZero)), vals(nz32, nz32)},
+		{"ToSlice16", ToSlice16([]float32{nz32}), nz},
+		{"ToSlice16Joined", vals(ToSlice16Joined([]float32{nz32}, ModeStrict, RoundNearestEven)), vals(nz, nil)},
+		{"ToSlice16Mode", vals(ToSlice16Mode([]float32{nz32}, true), ToSlice16Mode([]float32{nz32}, false)), vals(nz, nz)},
+		{"ToSlice16WithMode", first(ToSlice16WithMode([]float32{nz32}, ModeIEEE, RoundNearestEven)), nz},
+		{"ToSlice32", ToSlice32(nz), []float32{nz32}},
+		{"ToSlice32FTZ", ToSlice32FTZ([]Float16{n0, nsub}), []float32{nz32, nz32}},
+		{"ToSlice64", ToSlice64(nz), []float64{nz64}},
+
+		// delta.go
+		{"DecodeDeltas", vals(DecodeDeltas(EncodeDeltas([]Float16{n0, p0, n0}))), vals([]Float16{n0, p0, n0}, nil)},
+		{"DeltaEncodedSize", DeltaEncodedSize(nz), len(EncodeDeltas(nz))},
+		{"EncodeDeltas", bytes.Equal(EncodeDeltas(nz), EncodeDeltas([]Float16{p0})), false},
+
+		// ema.go
+		{"EMA", vals(EMA(negOne16, One16, Half16), EMA(n0, n0, Half16)), vals(p0, p0)},
+		{"EMAFilter.ApplySlice", emaSlice.ApplySlice([]Float16{n0, n0}), []Float16{n0, p0}},
+		{"EMAFilter.Update", emaFirst, n0},
+		{"EMAFilter.Value", vals(ema.Value(), emaErr), vals(n0, nil)},
+		{"NewEMAFilter", zeroAlphaErr, nil},
+
+		// errbound.go
+		{"DotProductWithError", vals(DotProductWithError(nz, []Float16{One16})), vals(p0, 0.0)},
+		{"Norm2WithError", vals(Norm2WithError(nz)), vals(p0, 0.0)},
+		{"SumCondition", SumCondition([]Float16{n0, n0}), 1.0},
+		{"SumSliceWithError", vals(SumSliceWithError([]Float16{n0, n0})), vals(p0, 0.0)},
+
+		// explain.go
+		{"RoundingInterval", vals(lo, hi, loIncl, hiIncl), vals(-0x1p-25, nz64, true, true)},
+
+		// flags.go
+		{"AddWithFlags", vals(AddWithFlags(One16, negOne16, RoundTowardNegative)), vals(n0, Flags(0))},
+		{"DivWithFlags", vals(DivWithFlags(n0, five, RoundNearestEven)), vals(n0, Flags(0))},
+		{"MulWithFlags", vals(MulWithFlags(n0, five, RoundNearestEven)), vals(n0, Flags(0))},
+		{"SqrtWithFlags", vals(SqrtWithFlags(n0, RoundNearestEven)), vals(n0, Flags(0))},
+		{"ToFloat16WithFlags", vals(ToFloat16WithFlags(nz32, ModeIEEE, RoundNearestEven)), vals(n0, Flags(0))},
+
+		// float16.go
+		{"ComputeSliceStats", ComputeSliceStats([]Float16{n0, n0}), SliceStats{Min: n0, Max: n0, Sum: p0, Mean: p0, Length: 2}},
+		{"DistinguishableDelta", DistinguishableDelta(n0), SmallestSubnormal},
+		{"FastAdd", vals(FastAdd(n0, n0), FastAdd(n0, p0)), vals(n0, p0)},
+		{"FastMul", FastMul(n0, five), n0},
+		{"FpClassify", FpClassify(n0), ClassNegativeZero},
+		{"Frexp", vals(Frexp(n0)), vals(n0, 0)},
+		{"IsFinite", IsFinite(n0), true},
+		{"IsInf", IsInf(n0, 0), false},
+		{"IsNaN", IsNaN(n0), false},
+		{"IsNegativeZero", vals(IsNegativeZero(n0), IsNegativeZero(p0)), vals(true, false)},
+		{"IsNormal", IsNormal(n0), false},
+		{"IsPositiveZero", vals(IsPositiveZero(n0), IsPositiveZero(p0)), vals(false, true)},
+		{"IsSubnormal", IsSubnormal(n0), false},
+		{"Ldexp", vals(Ldexp(n0, 3), Ldexp(negOne16, -30)), vals(n0, n0)},
+		{"Modf", vals(Modf(n0)), vals(n0, n0)},
+		{"Modf", vals(Modf(FromFloat32(-0.5))), vals(n0, FromFloat32(-0.5))},
+		{"NextAfter", vals(NextAfter(n0, One16), NextAfter(p0, negOne16), NextAfter(n0, p0), NextAfter(p0, n0)), vals(SmallestSubnormal, nsub, p0, n0)},
+		{"Signbit", Signbit(n0), true},
+		{"ValidateSliceLength", ValidateSliceLength(nz, []Float16{p0}), nil},
+		{"VectorAdd", VectorAdd(nz, nz), nz},
+		{"VectorMul", VectorMul(nz, []Float16{five}), nz},
+		{"Zero", Zero(), p0},
+
+		// format.go
+		{"DecimalDigitsNeeded", DecimalDigitsNeeded(n0), 1},
+		{"Float16.DebugString", strings.HasPrefix(n0.DebugString(), "-"), true},
+		{"Float16.DyadicString", vals(n0.DyadicString(), p0.DyadicString()), vals("-0", "0")},
+		{"FormatFloat", vals(FormatFloat(n0, 'g', -1), FormatFloat(n0, 'f', 2), FormatFloat(n0, 'e', 1)), vals("-0", "-0.00", "-0.0e+00")},
+		{"FormatPercent", FormatPercent(n0, 1), "-0.0%"},
+		{"ParseDebugString", vals(ParseDebugString(n0.DebugString())), vals(n0, nil)},
+		{"ParseWithUnits", vals(ParseWithUnits("-0")), vals(n0, nil)},
+		{"ParseWithUnitsMode", vals(ParseWithUnitsMode("-0k", ModeStrict)), vals(n0, nil)},
+		{"ReadAllText", vals(ReadAllText(strings.NewReader("-0 0"))), vals([]Float16{n0, p0}, nil)},
+
+		// generic.go
+		{"F.Value", NewF(n0).Value(), n0},
+		{"FromSliceOf", FromSliceOf([]float64{nz64}), nz},
+		{"Map", Map[float64](nz), []float64{nz64}},
+		{"NewF", NewF(n0).Value(), n0},
+
+		// hash.go
+		{"HashSlice", HashSlice(nz, 1) == HashSlice([]Float16{p0}, 1), true},
+		{"HashSliceBits", HashSliceBits(nz, 1) == HashSliceBits([]Float16{p0}, 1), false},
+
+		// isclose.go
+		{"AllClose", AllClose(nz, []Float16{p0}, p0, p0), true},
+		{"AllCloseWithNaN", AllCloseWithNaN(nz, []Float16{p0}, p0, p0, true), true},
+		{"IsClose", vals(IsClose(n0, p0, p0, p0), IsClose(nsub, p0, p0, p0)), vals(true, false)},
+		{"IsCloseWithNaN", IsCloseWithNaN(n0, p0, n0, n0, false), true},
+
+		// layout.go
+		{"GatherInto", vals(gathered, gatherErr), vals(nz, nil)},
+		{"Reverse", reversed, []Float16{p0, n0}},
+		{"Reversed", Reversed([]Float16{n0, p0}), []Float16{p0, n0}},
+		{"Rotate", rotated, []Float16{p0, n0}},
+		{"ScatterInto", vals(scattered, scatterErr), vals([]Float16{One16, n0}, nil)},
+		{"StridedView", vals(StridedView([]Float16{p0, n0}, 1, 1, 1)), vals(nz, nil)},
+
+		// math.go
+		{"Abs", Abs(n0), p0},
+		{"Acos", Acos(n0), HalfPi},
+		{"Apply", Apply(n0, func(x float32) float32 { return x }), n0},
+		{"Apply2", Apply2(n0, n0, func(x, y float32) float32 { return x + y }), n0},
+		{"Asin", Asin(n0), n0},
+		{"Atan", Atan(n0), n0},
+		{"Atan2", vals(Atan2(n0, p0), Atan2(p0, n0), Atan2(n0, n0), Atan2(n0, negOne16)), vals(n0, Pi, Pi.Neg(), Pi.Neg())},
+		{"Cbrt", Cbrt(n0), n0},
+		{"Ceil", vals(Ceil(n0), Ceil(FromFloat32(-0.3))), vals(n0, n0)},
+		{"Clamp", vals(Clamp(n0, negOne16, One16), Clamp(negOne16, n0, One16)), vals(n0, n0)},
+		{"CopySign", vals(CopySign(One16, n0), CopySign(n0, One16)), vals(negOne16, p0)},
+		{"Cos", Cos(n0), One16},
+		{"Cosh", Cosh(n0), One16},
+		{"CoshWithMode", vals(CoshWithMode(n0, ModeStrict)), vals(One16, nil)},
+		{"Dim", vals(Dim(n0, p0), Dim(n0, n0), Dim(One16, One16)), vals(p0, p0, p0)},
+		{"Erf", Erf(n0), n0},
+		{"Erfc", Erfc(n0), One16},
+		{"Exp", Exp(n0), One16},
+		{"Exp10", vals(Exp10(n0), Exp10(p0)), vals(One16, One16)},
+		{"Exp2", Exp2(n0), One16},
+		{"Exp2WithMode", vals(Exp2WithMode(n0, ModeStrict)), vals(One16, nil)},
+		{"ExpWithMode", vals(ExpWithMode(n0, ModeStrict)), vals(One16, nil)},
+		{"Floor", vals(Floor(n0), Floor(FromFloat32(-0.3))), vals(n0, negOne16)},
+		{"Gamma", Gamma(n0), NegativeInfinity},
+		{"GammaWithMode", vals(GammaWithMode(n0, ModeIEEE)), vals(NegativeInfinity, nil)},
+		{"Hypot", Hypot(n0, n0), p0},
+		{"J0", J0(n0), One16},
+		{"J1", J1(n0), n0},
+		{"Lerp", vals(Lerp(n0, One16, p0), Lerp(One16, n0, One16)), vals(n0, n0)},
+		{"Lgamma", vals(lgamma, lgammaSign), vals(PositiveInfinity, -1)},
+		{"Log", Log(n0), NegativeInfinity},
+		{"Log10", Log10(n0), NegativeInfinity},
+		{"Log2", Log2(n0), NegativeInfinity},
+		{"Mod", vals(Mod(n0, Three16), Mod(Three16.Neg(), Three16)), vals(n0, n0)},
+		{"NormCDF", NormCDF(n0), Half16},
+		{"NormPDF", NormPDF(n0), NormPDF(p0)},
+		{"NormQuantile", vals(NormQuantile(Half16)), vals(p0, nil)},
+		{"Pow", vals(Pow(n0, Three16), Pow(n0, Two16), Pow(n0, negOne16), Pow(n0, Two16.Neg()), Pow(five, n0), Pow(NegativeInfinity, negOne16)), vals(n0, p0, NegativeInfinity, PositiveInfinity, One16, n0)},
+		{"PowSlice", PowSlice(nz, Three16), nz},
+		{"PowSliceElementwise", PowSliceElementwise(nz, []Float16{Three16}), nz},
+		{"PowSliceElementwiseInto", powElemInto, nz},
+		{"PowSliceInto", powInto, nz},
+		{"PowWithMode", vals(PowWithMode(n0, Three16, ModeStrict)), vals(n0, nil)},
+		{"Remainder", vals(Remainder(n0, Three16), Remainder(Three16.Neg(), Three16)), vals(n0, n0)},
+		{"RsqrtSlice", RsqrtSlice(nz), []Float16{NegativeInfinity}},
+		{"RsqrtSliceInto", rsqrts, []Float16{NegativeInfinity, PositiveInfinity}},
+		{"Rsqrt", vals(Rsqrt(n0), Rsqrt(p0)), vals(NegativeInfinity, PositiveInfinity)},
+		{"RsqrtApprox", RsqrtApprox(n0, 2), NegativeInfinity},
+		{"Round", vals(Round(n0), Round(FromFloat32(-0.3))), vals(n0, n0)},
+		{"RoundToEven", RoundToEven(FromFloat32(-0.5)), n0},
+		{"Sign", Sign(n0), n0},
+		{"Sin", Sin(n0), n0},
+		{"Sinh", Sinh(n0), n0},
+		{"SinhWithMode", vals(SinhWithMode(n0, ModeStrict)), vals(n0, nil)},
+		{"Sqrt", Sqrt(n0), n0},
+		{"Tan", Tan(n0), n0},
+		{"Tanh", Tanh(n0), n0},
+		{"Tanpi", Tanpi(n0), n0},
+		{"Trunc", vals(Trunc(n0), Trunc(FromFloat32(-0.3))), vals(n0, n0)},
+		{"Y0", Y0(n0), NegativeInfinity},
+		{"Y1", Y1(n0), NegativeInfinity},
+
+		// nanreduce.go
+		{"DotProductNaN", vals(DotProductNaN(nz, []Float16{One16}, NaNSumSkip)), vals(p0, 0, nil)},
+		{"MaxSliceNaN", vals(MaxSliceNaN([]Float16{n0, p0}, NaNSumSkip)), vals(p0, 0, nil)},
+		{"MeanSliceNaN", vals(MeanSliceNaN([]Float16{n0, n0}, NaNSumSkip)), vals(p0, 0, nil)},
+		{"MinSliceNaN", vals(MinSliceNaN([]Float16{p0, n0}, NaNSumSkip)), vals(n0, 0, nil)},
+		{"SumSliceNaN", vals(SumSliceNaN([]Float16{n0, n0}, NaNSumSkip)), vals(p0, 0, nil)},
+
+		// optional.go
+		{"FillNulls", FillNulls([]OptionalFloat16{{}}, n0), nz},
+		{"NewOptionalFloat16", NewOptionalFloat16(n0), OptionalFloat16{Value: n0, Valid: true}},
+		{"OptionalFromSlice", OptionalFromSlice([]Float16{n0, p0}, p0), []OptionalFloat16{{Value: n0, Valid: true}, {}}},
+
+		// order.go
+		{"AllFinite", slices.Contains(AllFinite(), n0), true},
+		{"Float16.OrderedKey", n0.OrderedKey() < p0.OrderedKey(), true},
+		{"Float16.TotalOrderInt", n0.TotalOrderInt() < p0.TotalOrderInt(), true},
+		{"FromOrdered16Slice", FromOrdered16Slice(ToOrdered16Slice(nz)), nz},
+		{"FromOrderedKey", FromOrderedKey(n0.OrderedKey()), n0},
+		{"FromTotalOrderInt", FromTotalOrderInt(n0.TotalOrderInt()), n0},
+		{"IsMonotonic", IsMonotonic([]Float16{p0, n0, p0}), true},
+		{"IsSortedAscending", vals(IsSortedAscending([]Float16{n0, p0}), IsSortedAscending([]Float16{p0, n0})), vals(true, true)},
+		{"IsSortedDescending", vals(IsSortedDescending([]Float16{n0, p0}), IsSortedDescending([]Float16{p0, n0})), vals(true, true)},
+		{"NewOrdered16", NewOrdered16(n0).Value(), n0},
+		{"Ordered16.Value", NewOrdered16(n0).Value(), n0},
+		{"ToOrdered16Slice", ToOrdered16Slice(nz)[0].Value(), n0},
+		{"UniqueSorted", UniqueSorted([]Float16{n0, p0, n0}), []Float16{p0}},
+
+		// onnx.go
+		{"ToONNXBits", ToONNXBits(nz), []uint16{0x8000}},
+		{"WriteONNXFloat16", WriteONNXFloat16(&onnxBuf, nz) == nil && onnxBuf.String() == "\x00\x80", true},
+
+		// pack.go
+		{"Compose", vals(Compose(1, -15, 0)), vals(n0, nil)},
+		{"MustCompose", MustCompose(1, -15, 0), n0},
+		{"MustComposeBits", MustComposeBits(1, 0, 0), n0},
+
+		// partition.go
+		{"PartitionFinite", partFinite, nz},
+
+		// polyfit.go
+		{"FitPolynomial", first(FitPolynomial(math.Sin, n0, p0, 1)), []Float16{n0, p0}},
+		{"Polyval", vals(Polyval([]Float16{n0, One16}, n0), Polyval(nil, n0)), vals(n0, p0)},
+		{"PolynomialSource", strings.Contains(PolynomialSource("c", []Float16{n0}), "0x8000"), true},
+
+		// pool.go
+		{"AvgPool2D", ap, []Float16{p0}},
+		{"AvgPool2DWithEdge", ape, []Float16{p0}},
+		{"MaxPool2D", vals(mp, mpErr), vals(nz, nil)},
+		{"MaxPool2DWithEdge", mpe, []Float16{p0}},
+
+		// quantize.go
+		{"PackQuantized", vals(PackQuantized([]Float16{n0, n0}, 8)), vals([]byte{0, 0}, p0, n0, nil)},
+		{"UnpackQuantized", UnpackQuantized([]byte{0x10}, 4, One16, n0, 2), []Float16{n0, One16}},
+
+		// random.go
+		{"Bernoulli", Bernoulli(rng, n0), false},
+		{"Categorical", vals(Categorical(rng, []Float16{n0, One16})), vals(1, nil)},
+		{"RandomExponential", RandomExponential(rng, n0).IsNaN(), true},
+
+		// reference.go
+		{"ReferenceFromFloat64", vals(ReferenceFromFloat64(nz64, RoundNearestEven), ReferenceFromFloat64(-1e-10, RoundTowardPositive)), vals(n0, n0)},
+
+		// types.go
+		{"ClassSlice", ClassSlice([]Float16{n0, p0, n0})[ClassNegativeZero], 2},
+		{"Float16.Abs", n0.Abs(), p0},
+		{"Float16.Bits", n0.Bits(), uint16(0x8000)},
+		{"Float16.Class", n0.Class(), ClassNegativeZero},
+		{"Float16.CopySign", vals(p0.CopySign(n0), n0.CopySign(p0)), vals(n0, p0)},
+		{"Float16.EffectiveMantissaBits", n0.EffectiveMantissaBits(), 0},
+		{"Float16.ExactInt", vals(n0.ExactInt()), vals(0, true)},
+		{"Float16.GoString", n0.GoString(), "float16.FromBits(0x8000)"},
+		{"Float16.IsFinite", n0.IsFinite(), true},
+		{"Float16.IsInf", n0.IsInf(-1), false},
+		{"Float16.IsInteger", n0.IsInteger(), true},
+		{"Float16.IsNaN", n0.IsNaN(), false},
+		{"Float16.IsNegativeZero", n0.IsNegativeZero(), true},
+		{"Float16.IsNormal", n0.IsNormal(), false},
+		{"Float16.IsPositiveZero", n0.IsPositiveZero(), false},
+		{"Float16.IsSubnormal", n0.IsSubnormal(), false},
+		{"Float16.IsZero", n0.IsZero(), true},
+		{"Float16.Neg", vals(n0.Neg(), p0.Neg()), vals(p0, n0)},
+		{"Float16.Sign", n0.Sign(), 0},
+		{"Float16.Signbit", n0.Signbit(), true},
+		{"Float16.String", n0.String(), "-0"},
+		{"Float16.ToInt", n0.ToInt(), 0},
+		{"Float16.ToInt32", n0.ToInt32(), int32(0)},
+		{"Float16.ToInt64", n0.ToInt64(), int64(0)},
+		{"FromBits", FromBits(0x8000), n0},
+
+		// unit.go
+		{"Clamp01", Clamp01(n0).Float16(), p0},
+		{"NewUnitInterval", vals(unit.Float16(), unitErr), vals(p0, nil)},
+		{"UnitInterval.Float16", Clamp01(n0).Float16(), p0},
+
+		// vec.go
+		{"Vec2.Dot", Vec2{n0, n0}.Dot(Vec2{One16, One16}), p0},
+		{"Vec2.Length", Vec2{n0, n0}.Length(), p0},
+		{"Vec2.Lerp", Vec2{n0, One16}.Lerp(Vec2{One16, n0}, p0), Vec2{n0, One16}},
+		{"Vec2.Scale", Vec2{One16, n0}.Scale(n0), Vec2{n0, p0}},
+		{"Vec3.Dot", Vec3{n0, n0, n0}.Dot(Vec3{One16, One16, One16}), p0},
+		{"Vec3.Length", Vec3{n0, n0, n0}.Length(), p0},
+		{"Vec3.Lerp", Vec3{n0, n0, n0}.Lerp(Vec3{One16, One16, One16}, p0), Vec3{n0, n0, n0}},
+		{"Vec3.Scale", Vec3{One16, n0, negOne16}.Scale(n0), Vec3{n0, p0, p0}},
+		{"Vec4.Dot", Vec4{n0, n0, n0, n0}.Dot(Vec4{One16, One16, One16, One16}), p0},
+		{"Vec4.Length", Vec4{n0, n0, n0, n0}.Length(), p0},
+		{"Vec4.Lerp", Vec4{n0, n0, n0, n0}.Lerp(Vec4{One16, One16, One16, One16}, p0), Vec4{n0, n0, n0, n0}},
+		{"Vec4.Scale", Vec4{One16, n0, negOne16, p0}.Scale(n0), Vec4{n0, p0, p0, n0}},
+
+		// vector.go
+		{"Center", Center([]Float16{negOne16, One16}), []Float16{negOne16, One16}},
+		{"CumSum", CumSum([]Float16{n0, n0}), []Float16{p0, p0}},
+		{"Diff", Diff([]Float16{n0, n0, p0}), []Float16{p0, p0}},
+		{"Diff2", Diff2([]Float16{n0, n0, n0}), []Float16{p0}},
+		{"MeanPow2", vals(MeanPow2([]Float16{n0, n0})), vals(n0, nil)},
+		{"FusedNormalize", FusedNormalize([]Float16{n0, five}, n0), []Float16{n0, FromFloat64(math.Sqrt2)}},
+		{"FusedNormalizeInto", fused, []Float16{n0, p0}},
+		{"Normalize", Normalize([]Float16{n0, five}), []Float16{n0, One16}},
+		{"NormalizeInto", normalized, []Float16{n0, One16}},
+		{"NormalizeL1", NormalizeL1([]Float16{n0, five}), []Float16{n0, One16}},
+		{"Outer", Outer(nz, []Float16{five, negFive}), []Float16{n0, p0}},
+		{"Standardize", Standardize([]Float16{n0, n0}), []Float16{p0, p0}},
+
+		// view.go
+		{"DotReader", DotReader(View32{nz32}, View16{One16}), p0},
+		{"SumReader", vals(SumReader(View32{nz32}), SumReader(View16{n0, n0})), vals(p0, p0)},
+		{"View16.At", View16(nz).At(0), n0},
+		{"View32.At", View32{nz32}.At(0), n0},
+		{"View32.Materialize", View32{nz32}.Materialize(), nz},
+
+		// verify.go
+		{"ExhaustiveVerifyBinary", len(ExhaustiveVerifyBinary(Add, func(a, b float64) float64 { return a + b }, 0, 4099)), 0},
+		{"ExhaustiveVerifyUnary", len(ExhaustiveVerifyUnary(Float16.Neg, func(x float64) float64 { return -x }, 0)), 0},
+		{"UlpDistance", UlpDistance(n0, p0), uint16(0)},
+	}
+}
+
+// first returns the first of two results
+func first[A, B any](a A, _ B) A { return a }
+
+func TestSignedZero(t *testing.T) {
+	for _, c := range signedZeroCases() {
+		got, want := fmt.Sprintf("%#v", c.got), fmt.Sprintf("%#v", c.want)
+		if got != want {
+			t.Errorf("%s:\n got %s\nwant %s", c.api, got, want)
+		}
+	}
+}
+
+// TestSignedZeroCoverage requires a signedZeroCases entry or an exemption for
+// every exported function and method whose signature mentions Float16
+func TestSignedZeroCoverage(t *testing.T) {
+	covered := make(map[string]bool)
+	for _, c := range signedZeroCases() {
+		covered[c.api] = true
+	}
+	for api := range signedZeroExempt {
+		covered[api] = true
+	}
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || !fn.Name.IsExported() || !mentionsFloat16(fn.Type) && (fn.Recv == nil || !mentionsFloat16(fn.Recv)) {
+					continue
+				}
+				api, ok := apiName(fn)
+				if ok && !covered[api] {
+					t.Errorf("%s: no signed-zero case; add one to signedZeroCases or a reason to signedZeroExempt", api)
+				}
+			}
+		}
+	}
+}
+
+// mentionsFloat16 reports whether the Float16 type appears anywhere in n
+func mentionsFloat16(n ast.Node) (found bool) {
+	ast.Inspect(n, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && id.Name == "Float16" {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// apiName returns the name of fn as it appears in signedZeroCases, with the
+// receiver type for methods, and false for methods of unexported types
+func apiName(fn *ast.FuncDecl) (string, bool) {
+	if fn.Recv == nil {
+		return fn.Name.Name, true
+	}
+	recv := fn.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+	switch r := recv.(type) {
+	case *ast.IndexExpr:
+		recv = r.X
+	case *ast.IndexListExpr:
+		recv = r.X
+	}
+	id, ok := recv.(*ast.Ident)
+	if !ok || !id.IsExported() {
+		return "", false
+	}
+	return id.Name + "." + fn.Name.Name, true
+}
+
+// BenchmarkSignedZeroConversion measures the conversions on -0, which take
+// the zero shortcut rather than the general rounding path
+func BenchmarkSignedZeroConversion(b *testing.B) {
+	nz64 := math.Copysign(0, -1)
+	nz32 := float32(nz64)
+	b.Run("FromFloat32", func(b *testing.B) {
+		var sink Float16
+		for i := 0; i < b.N; i++ {
+			sink ^= FromFloat32(nz32)
+		}
+		_ = sink
+	})
+	b.Run("FromFloat64", func(b *testing.B) {
+		var sink Float16
+		for i := 0; i < b.N; i++ {
+			sink ^= FromFloat64(nz64)
+		}
+		_ = sink
+	})
+	b.Run("ToFloat32", func(b *testing.B) {
+		var sink float32
+		for i := 0; i < b.N; i++ {
+			sink += NegativeZero.ToFloat32()
+		}
+		_ = sink
+	})
+}
@@ -0,0 +1,202 @@
+package float16
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// DataMix describes the proportions of value classes used to build benchmark
+// input. Both fields are fractions in [0, 1]; whatever remains after
+// Subnormal and Special is filled with normal values.
+type DataMix struct {
+	Subnormal float64 // fraction of subnormal values
+	Special   float64 // fraction of zeros, infinities and NaNs
+}
+
+// DefaultDataMix returns a mix resembling activations after a normalisation
+// layer: mostly normal values with a small tail of subnormals and specials.
+func DefaultDataMix() DataMix {
+	return DataMix{Subnormal: 0.05, Special: 0.02}
+}
+
+// ThroughputConfig controls RunThroughputReport.
+type ThroughputConfig struct {
+	// Budget is the total wall-clock time shared by all kernels. Zero selects
+	// 100ms.
+	Budget time.Duration
+	// Elements is the length of the input slices. Zero selects 4096.
+	Elements int
+	// Mix is the distribution of the generated input.
+	Mix DataMix
+	// Seed seeds the input generator so reports are reproducible.
+	Seed int64
+}
+
+// ThroughputReport holds the measured throughput of the main kernels, in
+// elements per second.
+type ThroughputReport struct {
+	ToSlice16  float64
+	ToSlice32  float64
+	AddSlice   float64
+	MulSlice   float64
+	DotProduct float64
+	Sqrt       float64
+	Exp        float64
+
+	Elements int           // length of the input slices
+	Elapsed  time.Duration // total time spent measuring
+}
+
+// MixedData32 returns n float32 values drawn according to mix. Normal values
+// span the full Float16 exponent range so that conversion code exercises all
+// of its branches; uniform input makes branchy kernels look faster than they
+// are in practice.
+func MixedData32(n int, mix DataMix, seed int64) []float32 {
+	rng := rand.New(rand.NewSource(seed))
+	result := make([]float32, n)
+	for i := range result {
+		result[i] = mixedValue32(rng, mix)
+	}
+	return result
+}
+
+// mixedValue32 draws one value according to mix
+func mixedValue32(rng *rand.Rand, mix DataMix) float32 {
+	specials := [...]float32{0, float32(math.Copysign(0, -1)), float32(math.Inf(1)), float32(math.Inf(-1)), float32(math.NaN())}
+	var v float32
+	u := rng.Float64()
+	switch {
+	case u < mix.Special:
+		v = specials[rng.Intn(len(specials))]
+	case u < mix.Special+mix.Subnormal:
+		v = float32(rng.Intn(1023)+1) * 0x1p-24
+	default:
+		// Exponents -14..15 cover every normal binade
+		v = float32(1+rng.Float64()) * float32(math.Ldexp(1, rng.Intn(30)-14))
+	}
+	if rng.Intn(2) == 0 {
+		v = -v
+	}
+	return v
+}
+
+// DataProfile names a benchmark input composition for GenerateBenchData.
+// Performance of branchy kernels depends heavily on the mix of value
+// classes, so benchmarks name the profile they ran on.
+type DataProfile int
+
+// The values are part of the wire format and never change.
+const (
+	// ProfileUniformNormal: normal values only, spread evenly over every
+	// binade
+	ProfileUniformNormal DataProfile = 0
+	// ProfileHeavySubnormal: half subnormals, half normal values
+	ProfileHeavySubnormal DataProfile = 1
+	// ProfileMixedSpecial: a quarter zeros, infinities and NaNs, 5%
+	// subnormals and normal values otherwise
+	ProfileMixedSpecial DataProfile = 2
+	// ProfileIntegerHeavy: three quarters integers in [-2048, 2048], normal
+	// values otherwise
+	ProfileIntegerHeavy DataProfile = 3
+)
+
+// dataProfiles holds the composition of each profile: the DataMix of the
+// non-integer values and the fraction of integers
+var dataProfiles = [...]struct {
+	name    string
+	mix     DataMix
+	integer float64
+}{
+	ProfileUniformNormal:  {"uniform-normal", DataMix{}, 0},
+	ProfileHeavySubnormal: {"heavy-subnormal", DataMix{Subnormal: 0.5}, 0},
+	ProfileMixedSpecial:   {"mixed-special", DataMix{Subnormal: 0.05, Special: 0.25}, 0},
+	ProfileIntegerHeavy:   {"integer-heavy", DataMix{}, 0.75},
+}
+
+// String returns the profile name, such as "heavy-subnormal"
+func (p DataProfile) String() string {
+	if p < 0 || int(p) >= len(dataProfiles) {
+		return fmt.Sprintf("DataProfile(%d)", int(p))
+	}
+	return dataProfiles[p].name
+}
+
+// GenerateBenchData returns n values drawn according to profile. The
+// generator is seeded from the profile alone, so the same call always
+// returns the same sequence and a longer sequence extends a shorter one.
+// It panics on an unknown profile.
+func GenerateBenchData(n int, profile DataProfile) []Float16 {
+	if profile < 0 || int(profile) >= len(dataProfiles) {
+		panic("float16: unknown DataProfile")
+	}
+	p := dataProfiles[profile]
+	rng := rand.New(rand.NewSource(int64(profile) + 1))
+	result := make([]Float16, n)
+	for i := range result {
+		if rng.Float64() < p.integer {
+			result[i] = FromInt(rng.Intn(4097) - 2048)
+		} else {
+			result[i] = FromFloat32(mixedValue32(rng, p.mix))
+		}
+	}
+	return result
+}
+
+// RunThroughputReport times the main conversion, arithmetic and math kernels
+// on mixed data and returns their throughput. Each kernel gets an equal share
+// of cfg.Budget and runs at least once, so the report is always fully
+// populated. It is intended for startup logging and DebugInfo-style
+// diagnostics, not as a replacement for go test -bench.
+func RunThroughputReport(cfg ThroughputConfig) ThroughputReport {
+	if cfg.Budget <= 0 {
+		cfg.Budget = 100 * time.Millisecond
+	}
+	if cfg.Elements <= 0 {
+		cfg.Elements = 4096
+	}
+	src := MixedData32(cfg.Elements, cfg.Mix, cfg.Seed)
+	a := ToSlice16(src)
+	b := ToSlice16(MixedData32(cfg.Elements, cfg.Mix, cfg.Seed+1))
+
+	out := make([]Float16, cfg.Elements)
+
+	var report ThroughputReport
+	kernels := []struct {
+		dst *float64
+		run func()
+	}{
+		{&report.ToSlice16, func() { _ = ToSlice16(src) }},
+		{&report.ToSlice32, func() { _ = ToSlice32(a) }},
+		{&report.AddSlice, func() { _ = AddSlice(a, b) }},
+		{&report.MulSlice, func() { _ = MulSlice(a, b) }},
+		{&report.DotProduct, func() { _ = DotProduct(a, b) }},
+		{&report.Sqrt, func() { unaryInto(out, a, Sqrt) }},
+		{&report.Exp, func() { unaryInto(out, a, Exp) }},
+	}
+
+	share := cfg.Budget / time.Duration(len(kernels))
+	start := time.Now()
+	for _, k := range kernels {
+		begin := time.Now()
+		iters := 0
+		for {
+			k.run()
+			iters++
+			if time.Since(begin) >= share {
+				break
+			}
+		}
+		*k.dst = float64(iters*cfg.Elements) / time.Since(begin).Seconds()
+	}
+	report.Elements = cfg.Elements
+	report.Elapsed = time.Since(start)
+	return report
+}
+
+func unaryInto(dst, s []Float16, op func(Float16) Float16) {
+	for i, v := range s {
+		dst[i] = op(v)
+	}
+}
@@ -0,0 +1,255 @@
+package float16
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestMixedData32(t *testing.T) {
+	data := MixedData32(20000, DataMix{Subnormal: 0.25, Special: 0.25}, 1)
+	var normal, subnormal, special int
+	for _, v := range data {
+		switch f := FromFloat32(v); {
+		case f.IsZero() || f.IsInf(0) || f.IsNaN():
+			special++
+		case f.IsSubnormal():
+			subnormal++
+		default:
+			normal++
+		}
+	}
+	for _, c := range []struct {
+		name string
+		got  int
+	}{{"normal", normal}, {"subnormal", subnormal}, {"special", special}} {
+		if c.got == 0 {
+			t.Errorf("no %s values generated", c.name)
+		}
+	}
+	if normal < 9000 || normal > 11000 {
+		t.Errorf("normal count %d, want about 10000", normal)
+	}
+	if subnormal < 4000 || subnormal > 6000 {
+		t.Errorf("subnormal count %d, want about 5000", subnormal)
+	}
+
+	again := MixedData32(20000, DataMix{Subnormal: 0.25, Special: 0.25}, 1)
+	for i := range data {
+		if FromFloat32(data[i]) != FromFloat32(again[i]) {
+			t.Fatal("MixedData32 is not deterministic for a fixed seed")
+		}
+	}
+}
+
+func TestGenerateBenchData(t *testing.T) {
+	pinned := map[DataProfile][]Float16{
+		ProfileUniformNormal:  {0x7aa9, 0xc4a0, 0xf8db, 0xc92c, 0x7648, 0x04d4, 0x30a2, 0xc03d},
+		ProfileHeavySubnormal: {0x8310, 0x65b3, 0xc373, 0x65b9, 0xe0a2, 0xc2fa, 0x156d, 0x3b4e},
+		ProfileMixedSpecial:   {0x07c5, 0x6608, 0xa345, 0xbee6, 0x5ec7, 0xb4bf, 0x8c90, 0x6f83},
+		ProfileIntegerHeavy:   {0x65c2, 0x659d, 0x6649, 0x1fbe, 0xe77c, 0x6735, 0xd5c6, 0xdac0},
+	}
+	for profile, want := range pinned {
+		got := GenerateBenchData(len(want), profile)
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("GenerateBenchData(%d, %v)[%d] = 0x%04x, want 0x%04x", len(want), profile, i, uint16(got[i]), uint16(want[i]))
+			}
+		}
+		if longer := GenerateBenchData(100, profile); longer[len(want)-1] != want[len(want)-1] {
+			t.Errorf("a longer %v sequence does not extend the shorter one", profile)
+		}
+	}
+
+	// Fractions of special, subnormal and integer values. Normal values are
+	// integers in the six binades from 2^10 up and, with probability 2^-k,
+	// in the binade k below, so 7/30 of them are integers.
+	const n = 100000
+	const normalIntegers = 7.0 / 30
+	tests := []struct {
+		profile                     DataProfile
+		special, subnormal, integer float64
+	}{
+		{ProfileUniformNormal, 0, 0, normalIntegers},
+		{ProfileHeavySubnormal, 0, 0.5, 0.5 * normalIntegers},
+		{ProfileMixedSpecial, 0.25, 0.05, 0.7 * normalIntegers},
+		{ProfileIntegerHeavy, 0, 0, 0.75 + 0.25*normalIntegers},
+	}
+	for _, tt := range tests {
+		var special, subnormal, integer int
+		for _, v := range GenerateBenchData(n, tt.profile) {
+			switch {
+			case v.IsZero() || v.IsInf(0) || v.IsNaN():
+				special++
+			case v.IsSubnormal():
+				subnormal++
+			case v.IsInteger():
+				integer++
+			}
+		}
+		for _, c := range []struct {
+			name string
+			got  int
+			want float64
+		}{{"special", special, tt.special}, {"subnormal", subnormal, tt.subnormal}, {"integer", integer, tt.integer}} {
+			if frac := float64(c.got) / n; frac < c.want-0.01 || frac > c.want+0.01 {
+				t.Errorf("%v: %s fraction %.3f, want %.3f", tt.profile, c.name, frac, c.want)
+			}
+		}
+	}
+
+	if got := DataProfile(7).String(); got != "DataProfile(7)" {
+		t.Errorf("DataProfile(7).String() = %q", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("GenerateBenchData with an unknown profile did not panic")
+		}
+	}()
+	GenerateBenchData(1, DataProfile(-1))
+}
+
+func TestDebugInfoCPU(t *testing.T) {
+	info := DebugInfo()
+	if info["cpu_arch"] != runtime.GOARCH {
+		t.Errorf("cpu_arch = %v, want %s", info["cpu_arch"], runtime.GOARCH)
+	}
+	if n, ok := info["cpu_count"].(int); !ok || n < 1 {
+		t.Errorf("cpu_count = %v", info["cpu_count"])
+	}
+	features, ok := info["cpu_features"].([]string)
+	if !ok {
+		t.Fatalf("cpu_features has type %T, want []string", info["cpu_features"])
+	}
+	known := map[string]bool{"sse4.1": true, "avx": true, "fma": true, "f16c": true, "avx2": true, "avx512f": true, "avx512fp16": true}
+	seen := map[string]bool{}
+	for _, f := range features {
+		if !known[f] || seen[f] {
+			t.Errorf("unexpected or repeated feature %q in %v", f, features)
+		}
+		seen[f] = true
+	}
+	// AVX-512 implies AVX2, which implies AVX
+	if (seen["avx512f"] && !seen["avx2"]) || (seen["avx2"] && !seen["avx"]) {
+		t.Errorf("inconsistent features %v", features)
+	}
+}
+
+func TestRunThroughputReport(t *testing.T) {
+	budget := 20 * time.Millisecond
+	report := RunThroughputReport(ThroughputConfig{Budget: budget, Elements: 512, Mix: DefaultDataMix()})
+
+	fields := map[string]float64{
+		"ToSlice16":  report.ToSlice16,
+		"ToSlice32":  report.ToSlice32,
+		"AddSlice":   report.AddSlice,
+		"MulSlice":   report.MulSlice,
+		"DotProduct": report.DotProduct,
+		"Sqrt":       report.Sqrt,
+		"Exp":        report.Exp,
+	}
+	for name, v := range fields {
+		if !(v > 0) {
+			t.Errorf("%s throughput = %v, want > 0", name, v)
+		}
+	}
+	if report.Elements != 512 {
+		t.Errorf("Elements = %d, want 512", report.Elements)
+	}
+	if report.Elapsed < budget {
+		t.Errorf("Elapsed = %v, want at least the budget %v", report.Elapsed, budget)
+	}
+	// Allow generous slack for a loaded machine, but not unbounded overrun
+	if report.Elapsed > 10*budget {
+		t.Errorf("Elapsed = %v, far exceeds budget %v", report.Elapsed, budget)
+	}
+
+	defaults := RunThroughputReport(ThroughputConfig{Budget: time.Millisecond})
+	if defaults.Elements != 4096 {
+		t.Errorf("default Elements = %d, want 4096", defaults.Elements)
+	}
+}
+
+var benchmarkSizes = []struct {
+	name string
+	n    int
+}{{"1K", 1 << 10}, {"64K", 1 << 16}, {"1M", 1 << 20}}
+
+func BenchmarkConvert(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		src := MixedData32(size.n, DefaultDataMix(), 1)
+		half := ToSlice16(src)
+		b.Run(fmt.Sprintf("ToSlice16/%s", size.name), func(b *testing.B) {
+			b.SetBytes(int64(size.n) * 4)
+			for i := 0; i < b.N; i++ {
+				_ = ToSlice16(src)
+			}
+		})
+		for _, fast := range []bool{true, false} {
+			name := map[bool]string{true: "fast", false: "accurate"}[fast]
+			b.Run(fmt.Sprintf("ToSlice16Mode/%s/%s", name, size.name), func(b *testing.B) {
+				b.SetBytes(int64(size.n) * 4)
+				for i := 0; i < b.N; i++ {
+					_ = ToSlice16Mode(src, fast)
+				}
+			})
+		}
+		b.Run(fmt.Sprintf("ToSlice32/%s", size.name), func(b *testing.B) {
+			b.SetBytes(int64(size.n) * 2)
+			for i := 0; i < b.N; i++ {
+				_ = ToSlice32(half)
+			}
+		})
+	}
+}
+
+// benchmarkProfiles lists every DataProfile, so kernel benchmarks report
+// how input composition affects them
+var benchmarkProfiles = []DataProfile{ProfileUniformNormal, ProfileHeavySubnormal, ProfileMixedSpecial, ProfileIntegerHeavy}
+
+func BenchmarkArithmeticKernels(b *testing.B) {
+	const n = 1 << 16
+	for _, profile := range benchmarkProfiles {
+		data := GenerateBenchData(2*n, profile)
+		x, y := data[:n], data[n:]
+		kernels := []struct {
+			name string
+			run  func()
+		}{
+			{"AddSlice", func() { _ = AddSlice(x, y) }},
+			{"MulSlice", func() { _ = MulSlice(x, y) }},
+			{"DivSlice", func() { _ = DivSlice(x, y) }},
+			{"DotProduct", func() { _ = DotProduct(x, y) }},
+			{"SumSlice", func() { _ = SumSlice(x) }},
+		}
+		for _, k := range kernels {
+			b.Run(fmt.Sprintf("%s/%v", k.name, profile), func(b *testing.B) {
+				b.SetBytes(n * 2)
+				for i := 0; i < b.N; i++ {
+					k.run()
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkMathKernels(b *testing.B) {
+	const n = 1 << 16
+	out := make([]Float16, n)
+	for _, profile := range benchmarkProfiles {
+		x := GenerateBenchData(n, profile)
+		kernels := []struct {
+			name string
+			op   func(Float16) Float16
+		}{{"Sqrt", Sqrt}, {"Exp", Exp}, {"Log", Log}, {"Tanh", Tanh}}
+		for _, k := range kernels {
+			b.Run(fmt.Sprintf("%s/%v", k.name, profile), func(b *testing.B) {
+				b.SetBytes(n * 2)
+				for i := 0; i < b.N; i++ {
+					unaryInto(out, x, k.op)
+				}
+			})
+		}
+	}
+}
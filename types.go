@@ -2,22 +2,27 @@ package float16
 
 import (
 	"fmt"
+	"math/bits"
 )
 
 // ErrorCode represents specific error categories for float16 operations
 type ErrorCode int
 
+// The numeric values are part of the wire format and never change.
 const (
-	ErrInvalidOperation ErrorCode = iota
-	ErrNaN
-	ErrInfinity
-	ErrOverflow
-	ErrUnderflow
-	ErrDivisionByZero
-	ErrNotImplemented
+	ErrInvalidOperation ErrorCode = 0
+	ErrNaN              ErrorCode = 1
+	ErrInfinity         ErrorCode = 2
+	ErrOverflow         ErrorCode = 3
+	ErrUnderflow        ErrorCode = 4
+	ErrDivisionByZero   ErrorCode = 5
+	ErrNotImplemented   ErrorCode = 6
 )
 
-// Float16Error provides detailed error information for float16 operations
+// Float16Error provides detailed error information for float16 operations.
+// Scalar conversions and arithmetic never allocate when they succeed; their
+// error paths allocate once, for the *Float16Error, whose Op and Msg are
+// constant strings.
 type Float16Error struct {
 	Op   string
 	Msg  string
@@ -54,27 +59,29 @@ func (e *BFloat16Error) Error() string {
 // RoundingMode controls how results are rounded during conversion/arithmetic
 type RoundingMode int
 
+// The numeric values are part of the wire format and never change.
 const (
 	// Round to nearest, ties to even
-	RoundNearestEven RoundingMode = iota
+	RoundNearestEven RoundingMode = 0
 	// Round toward zero (truncate)
-	RoundTowardZero
+	RoundTowardZero RoundingMode = 1
 	// Round toward +Inf
-	RoundTowardPositive
+	RoundTowardPositive RoundingMode = 2
 	// Round toward -Inf
-	RoundTowardNegative
+	RoundTowardNegative RoundingMode = 3
 	// Round to nearest, ties away from zero
-	RoundNearestAway
+	RoundNearestAway RoundingMode = 4
 )
 
 // ConversionMode controls error reporting behavior for conversions
 type ConversionMode int
 
+// The numeric values are part of the wire format and never change.
 const (
 	// ModeIEEE performs IEEE-style conversion, saturating to Inf/0 with no errors
-	ModeIEEE ConversionMode = iota
+	ModeIEEE ConversionMode = 0
 	// ModeStrict reports errors for NaN, Inf, overflow, and underflow
-	ModeStrict
+	ModeStrict ConversionMode = 1
 )
 
 // Float16 represents a 16-bit IEEE 754 half-precision floating-point value
@@ -145,6 +152,16 @@ func (f Float16) IsZero() bool {
 	return (f & 0x7FFF) == 0
 }
 
+// IsNegativeZero returns true only for negative zero (0x8000)
+func (f Float16) IsNegativeZero() bool {
+	return f == NegativeZero
+}
+
+// IsPositiveZero returns true only for positive zero (0x0000)
+func (f Float16) IsPositiveZero() bool {
+	return f == PositiveZero
+}
+
 // IsInf returns true if the Float16 value represents infinity
 // If sign > 0, returns true only for positive infinity
 // If sign < 0, returns true only for negative infinity
@@ -185,24 +202,81 @@ func (f Float16) IsSubnormal() bool {
 	return exp == ExponentZero && mant != 0
 }
 
+// EffectiveMantissaBits returns the number of fraction bits f needs after its
+// leading one, ignoring trailing zeros: 0 for powers of two such as 0.5, 10
+// for values such as 1/3 that use the whole mantissa. A subnormal has no
+// implicit one, so its count starts after its highest set bit and is at most
+// 9. Zeros return 0; NaNs and infinities return -1.
+func (f Float16) EffectiveMantissaBits() int {
+	exp := (f & ExponentMask) >> MantissaLen
+	mant := uint16(f & MantissaMask)
+	switch {
+	case exp == ExponentInfinity:
+		return -1
+	case mant == 0:
+		return 0
+	case exp == ExponentZero:
+		return bits.Len16(mant) - 1 - bits.TrailingZeros16(mant)
+	}
+	return MantissaLen - bits.TrailingZeros16(mant)
+}
+
 // FloatClass enumerates the IEEE 754 classification of a Float16 value
 type FloatClass int
 
+// The numeric values are part of the wire format and never change.
 const (
-	ClassPositiveZero FloatClass = iota
-	ClassNegativeZero
-	ClassPositiveSubnormal
-	ClassNegativeSubnormal
-	ClassPositiveNormal
-	ClassNegativeNormal
-	ClassPositiveInfinity
-	ClassNegativeInfinity
-	ClassQuietNaN
-	ClassSignalingNaN
+	ClassPositiveZero      FloatClass = 0
+	ClassNegativeZero      FloatClass = 1
+	ClassPositiveSubnormal FloatClass = 2
+	ClassNegativeSubnormal FloatClass = 3
+	ClassPositiveNormal    FloatClass = 4
+	ClassNegativeNormal    FloatClass = 5
+	ClassPositiveInfinity  FloatClass = 6
+	ClassNegativeInfinity  FloatClass = 7
+	ClassQuietNaN          FloatClass = 8
+	ClassSignalingNaN      FloatClass = 9
 )
 
 // Class returns the IEEE 754 classification of the value
 func (f Float16) Class() FloatClass {
+	return FloatClass(classTable[classKey(f)])
+}
+
+// ClassSlice returns the number of elements of s in each FloatClass, indexed
+// by the class value.
+func ClassSlice(s []Float16) [10]int {
+	var counts [10]int
+	for _, v := range s {
+		counts[classTable[classKey(v)]]++
+	}
+	return counts
+}
+
+// classKey packs everything that determines the class into 8 bits: the sign,
+// the exponent and the top mantissa bit (bits 15..9 of f), followed by
+// whether the mantissa is nonzero. The carry trick avoids a branch.
+func classKey(f Float16) uint8 {
+	return uint8(f>>9)<<1 | uint8((f&MantissaMask+MantissaMask)>>MantissaLen)
+}
+
+// classTable maps classKey to FloatClass. Each entry is filled from a
+// representative value with that key; keys with the top mantissa bit set but
+// the nonzero flag clear cannot occur.
+var classTable = func() (t [256]uint8) {
+	for k := range t {
+		f := Float16(k>>1) << 9
+		if k&1 == 1 {
+			f |= 1
+		}
+		t[k] = uint8(classifyBits(f))
+	}
+	return t
+}()
+
+// classifyBits is the reference classification from which classTable is
+// built
+func classifyBits(f Float16) FloatClass {
 	bits := uint16(f)
 	sign := (bits & SignMask) != 0
 	exp := (bits & ExponentMask) >> MantissaLen
@@ -277,12 +351,13 @@ func (f Float16) ToInt() int {
 	return int(f.ToFloat32())
 }
 
-// String returns a string representation of the Float16 value
+// String returns a string representation of the Float16 value: "NaN",
+// "+Inf" or "-Inf" for the special values and otherwise the float32 value in
+// %.6g format, so 1.5 prints as "1.5", 0.1 as "0.0999756" and negative zero as
+// "-0". Use FormatFloat with precision -2 for the shortest round-trip form.
 func (f Float16) String() string {
 	if f.IsNaN() {
-		if f.Signbit() {
-			return "-NaN"
-		}
+		// Unsigned, as strconv prints it; DebugString shows sign and payload
 		return "NaN"
 	}
 	if f.IsInf(0) {
@@ -299,6 +374,47 @@ func (f Float16) GoString() string {
 	return fmt.Sprintf("float16.FromBits(0x%04x)", uint16(f))
 }
 
+// IsInteger reports whether f is a finite value with no fractional part.
+// Both zeros are integers; NaNs and infinities are not.
+func (f Float16) IsInteger() bool {
+	_, ok := f.ExactInt()
+	return ok
+}
+
+// ExactInt returns the value of f and true if f is exactly an integer, and 0
+// and false for fractional values, NaNs and infinities. It reads the value
+// from the bits without a float conversion. Every finite Float16 lies within
+// ±65504, so an integral value always fits in an int.
+func (f Float16) ExactInt() (int, bool) {
+	exp := int((f & ExponentMask) >> MantissaLen)
+	mant := int(f & MantissaMask)
+	switch {
+	case exp == ExponentInfinity:
+		return 0, false
+	case exp == ExponentZero:
+		return 0, mant == 0 // only zero; subnormals are below 1
+	}
+
+	e := exp - ExponentBias
+	if e < 0 {
+		return 0, false // normal values below 1
+	}
+	sig := 1<<MantissaLen | mant
+	var v int
+	if e >= MantissaLen {
+		v = sig << (e - MantissaLen)
+	} else {
+		if sig&(1<<(MantissaLen-e)-1) != 0 {
+			return 0, false
+		}
+		v = sig >> (MantissaLen - e)
+	}
+	if f.Signbit() {
+		v = -v
+	}
+	return v, true
+}
+
 func (f Float16) ToInt32() int32 {
 	return int32(f.ToFloat32())
 }
@@ -206,3 +206,168 @@ func TestToInt64(t *testing.T) {
 		})
 	}
 }
+
+func TestClassTableExhaustive(t *testing.T) {
+	for i := 0; i < 1<<16; i++ {
+		f := Float16(i)
+		if got, want := f.Class(), classifyBits(f); got != want {
+			t.Fatalf("Class(%#04x) = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestClassSlice(t *testing.T) {
+	s := []Float16{
+		PositiveZero,
+		NegativeZero, NegativeZero,
+		SmallestSubnormal, FromBits(0x03FF), FromBits(0x0200),
+		FromBits(0x8001),
+		One(), MaxValue, FromBits(0x0400), FromInt(7),
+		FromInt(-1),
+		PositiveInfinity,
+		NegativeInfinity, NegativeInfinity,
+		QuietNaN, QuietNaN | SignMask, FromBits(0x7FFF),
+		FromBits(0x7C01), FromBits(0xFDFF),
+	}
+	want := [10]int{
+		ClassPositiveZero:      1,
+		ClassNegativeZero:      2,
+		ClassPositiveSubnormal: 3,
+		ClassNegativeSubnormal: 1,
+		ClassPositiveNormal:    4,
+		ClassNegativeNormal:    1,
+		ClassPositiveInfinity:  1,
+		ClassNegativeInfinity:  2,
+		ClassQuietNaN:          3,
+		ClassSignalingNaN:      2,
+	}
+	if got := ClassSlice(s); got != want {
+		t.Errorf("ClassSlice() = %v, want %v", got, want)
+	}
+	if got := ClassSlice(nil); got != [10]int{} {
+		t.Errorf("ClassSlice(nil) = %v, want zeros", got)
+	}
+}
+
+func benchmarkClassData() []Float16 {
+	return GenerateBenchData(1<<20, ProfileMixedSpecial)
+}
+
+func BenchmarkClassSlice(b *testing.B) {
+	s := benchmarkClassData()
+	b.SetBytes(int64(len(s)) * 2)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = ClassSlice(s)
+	}
+}
+
+func BenchmarkClassSliceNaive(b *testing.B) {
+	s := benchmarkClassData()
+	b.SetBytes(int64(len(s)) * 2)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var counts [10]int
+		for _, v := range s {
+			counts[classifyBits(v)]++
+		}
+		_ = counts
+	}
+}
+
+func TestExactInt(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Float16
+		want int
+		ok   bool
+	}{
+		{"zero", PositiveZero, 0, true},
+		{"negative zero", NegativeZero, 0, true},
+		{"one", One16, 1, true},
+		{"minus ten", Ten16.Neg(), -10, true},
+		{"largest contiguous", FromFloat32(2048), 2048, true},
+		{"above contiguous", FromFloat32(4100), 4100, true},
+		{"max", MaxValue, 65504, true},
+		{"min", MinValue, -65504, true},
+		{"half", Half16, 0, false},
+		{"one and a half", FromFloat32(1.5), 0, false},
+		{"fraction near 1024", FromFloat32(1023.5), 0, false},
+		{"subnormal", SmallestSubnormal, 0, false},
+		{"smallest normal", SmallestNormal, 0, false},
+		{"+Inf", PositiveInfinity, 0, false},
+		{"-Inf", NegativeInfinity, 0, false},
+		{"NaN", QuietNaN, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.f.ExactInt()
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("%v.ExactInt() = (%d, %v), want (%d, %v)", tt.f, got, ok, tt.want, tt.ok)
+			}
+			if tt.f.IsInteger() != tt.ok {
+				t.Errorf("%v.IsInteger() = %v, want %v", tt.f, !tt.ok, tt.ok)
+			}
+		})
+	}
+
+	// Exhaustive agreement with the float64 value
+	for b := 0; b <= 0xFFFF; b++ {
+		f := Float16(b)
+		x := f.ToFloat64()
+		integral := !math.IsNaN(x) && !math.IsInf(x, 0) && x == math.Trunc(x)
+		got, ok := f.ExactInt()
+		if ok != integral || (ok && float64(got) != x) {
+			t.Fatalf("0x%04x.ExactInt() = (%d, %v), value %v", b, got, ok, x)
+		}
+	}
+}
+
+func TestEffectiveMantissaBits(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Float16
+		want int
+	}{
+		{"one", One16, 0},
+		{"half", Half16, 0},
+		{"1024", FromFloat32(1024), 0},
+		{"three", Three16, 1},
+		{"1.25", FromFloat32(1.25), 2},
+		{"tenth", FromFloat32(0.1), 9}, // 0x2e66: the rounded mantissa ends in 0
+		{"third", Third16, 10},
+		{"max", MaxValue, 10},
+		{"negative", FromFloat32(-1.5), 1},
+		{"zero", PositiveZero, 0},
+		{"negative zero", NegativeZero, 0},
+		{"smallest subnormal", SmallestSubnormal, 0},
+		{"subnormal power of two", FromBits(0x0100), 0},
+		{"subnormal 3", FromBits(0x0003), 1},
+		{"largest subnormal", LargestSubnormal, 9},
+		{"smallest normal", SmallestNormal, 0},
+		{"+Inf", PositiveInfinity, -1},
+		{"NaN", QuietNaN, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.f.EffectiveMantissaBits(); got != tt.want {
+				t.Errorf("%v.EffectiveMantissaBits() = %d, want %d", tt.f, got, tt.want)
+			}
+		})
+	}
+
+	// A value with n effective bits survives rounding to n fraction bits
+	for b := 0; b < 0x7C00; b++ {
+		f := Float16(b)
+		n := f.EffectiveMantissaBits()
+		x := f.ToFloat64()
+		if x == 0 {
+			continue
+		}
+		frac, exp := math.Frexp(x) // x = frac·2^exp with frac in [0.5, 1)
+		scaled := math.Ldexp(frac, n+1)
+		if scaled != math.Trunc(scaled) || (n > 0 && math.Ldexp(frac, n) == math.Trunc(math.Ldexp(frac, n))) {
+			t.Fatalf("0x%04x (%v·2^%d): EffectiveMantissaBits() = %d", b, frac, exp, n)
+		}
+	}
+}
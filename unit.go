@@ -0,0 +1,97 @@
+package float16
+
+import (
+	"fmt"
+	"math"
+)
+
+// Values in the unit interval
+
+// UnitInterval is a Float16 known to lie in [0, 1], such as a progress value
+// or a probability. Its field is unexported, so a plain Float16 only becomes
+// a UnitInterval through NewUnitInterval or Clamp01 and only leaves through
+// Float16; the compiler catches code that mixes the two by accident. The zero
+// value is 0.
+type UnitInterval struct {
+	v Float16
+}
+
+// NewUnitInterval returns f as a UnitInterval. It returns an ErrNaN error for
+// a NaN and an ErrInvalidOperation error for values outside [0, 1]. -0 is
+// stored as +0.
+func NewUnitInterval(f Float16) (UnitInterval, error) {
+	switch {
+	case f.IsNaN():
+		return UnitInterval{}, &Float16Error{Op: "NewUnitInterval", Msg: "NaN is not in [0, 1]", Code: ErrNaN}
+	case f.IsZero():
+		return UnitInterval{}, nil
+	case f.Signbit() || f > One16:
+		return UnitInterval{}, &Float16Error{
+			Op:   "NewUnitInterval",
+			Msg:  fmt.Sprintf("%v is outside [0, 1]", f),
+			Code: ErrInvalidOperation,
+		}
+	}
+	return UnitInterval{f}, nil
+}
+
+// Clamp01 returns f clamped to [0, 1] as a UnitInterval. Values below zero,
+// including -Inf, give 0, values above one give 1, and NaN gives 0.
+func Clamp01(f Float16) UnitInterval {
+	switch {
+	case f.IsNaN() || f.Signbit():
+		return UnitInterval{}
+	case f > One16:
+		return UnitInterval{One16}
+	}
+	return UnitInterval{f}
+}
+
+// Float16 returns u as a plain Float16
+func (u UnitInterval) Float16() Float16 { return u.v }
+
+// Float64 returns the exact value of u
+func (u UnitInterval) Float64() float64 { return u.v.ToFloat64() }
+
+// String returns the value formatted as Float16.String does
+func (u UnitInterval) String() string { return u.v.String() }
+
+// Add returns u + v, saturating at 1
+func (u UnitInterval) Add(v UnitInterval) UnitInterval { return Clamp01(Add(u.v, v.v)) }
+
+// Sub returns u - v, saturating at 0
+func (u UnitInterval) Sub(v UnitInterval) UnitInterval { return Clamp01(Sub(u.v, v.v)) }
+
+// Mul returns u·v, which always stays in [0, 1]
+func (u UnitInterval) Mul(v UnitInterval) UnitInterval { return Clamp01(Mul(u.v, v.v)) }
+
+// Complement returns 1 - u, rounded to the nearest Float16
+func (u UnitInterval) Complement() UnitInterval { return Clamp01(Sub(One16, u.v)) }
+
+// Percent returns u·100 rounded to the nearest integer, halves away from
+// zero. The product is exact in float64, so the only rounding is the final
+// one and values such as 0.333, stored as 0.33300781, give 33.
+func (u UnitInterval) Percent() int { return int(math.Round(u.Float64() * 100)) }
+
+// Permille returns u·1000 rounded to the nearest integer, halves away from
+// zero, with the same single rounding as Percent
+func (u UnitInterval) Permille() int { return int(math.Round(u.Float64() * 1000)) }
+
+// MarshalJSON implements json.Marshaler, encoding u as a plain number with the
+// shortest decimal that parses back to it.
+func (u UnitInterval) MarshalJSON() ([]byte, error) {
+	return []byte(FormatFloat(u.v, 'g', -2)), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a number that rounds
+// to a Float16 in [0, 1].
+func (u *UnitInterval) UnmarshalJSON(data []byte) error {
+	f, err := Parse(string(data))
+	if err == nil {
+		*u, err = NewUnitInterval(f)
+	}
+	if err != nil {
+		return fmt.Errorf("float16 UnitInterval.UnmarshalJSON: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package float16
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func mustUnit(t *testing.T, f float32) UnitInterval {
+	t.Helper()
+	u, err := NewUnitInterval(FromFloat32(f))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+func TestNewUnitInterval(t *testing.T) {
+	for _, f := range []Float16{PositiveZero, NegativeZero, SmallestSubnormal, Half16, One16, One16 - 1} {
+		u, err := NewUnitInterval(f)
+		if err != nil || !Equal(u.Float16(), f) {
+			t.Errorf("NewUnitInterval(%v) = (%v, %v)", f, u, err)
+		}
+	}
+	if u, _ := NewUnitInterval(NegativeZero); u.Float16() != PositiveZero {
+		t.Errorf("NewUnitInterval(-0) stored %#04x, want +0", u.Float16().Bits())
+	}
+
+	tests := []struct {
+		f    Float16
+		code ErrorCode
+	}{
+		{QuietNaN, ErrNaN},
+		{NegativeQNaN, ErrNaN},
+		{One16 + 1, ErrInvalidOperation},
+		{SmallestSubnormal.Neg(), ErrInvalidOperation},
+		{PositiveInfinity, ErrInvalidOperation},
+		{NegativeInfinity, ErrInvalidOperation},
+	}
+	for _, tt := range tests {
+		var fe *Float16Error
+		if _, err := NewUnitInterval(tt.f); !errors.As(err, &fe) || fe.Code != tt.code {
+			t.Errorf("NewUnitInterval(%v) error = %v, want code %v", tt.f, err, tt.code)
+		}
+	}
+}
+
+func TestUnitIntervalSaturation(t *testing.T) {
+	tests := []struct {
+		name string
+		got  UnitInterval
+		want Float16
+	}{
+		{"Clamp01(2)", Clamp01(Two16), One16},
+		{"Clamp01(+Inf)", Clamp01(PositiveInfinity), One16},
+		{"Clamp01(-0.5)", Clamp01(Half16.Neg()), PositiveZero},
+		{"Clamp01(-Inf)", Clamp01(NegativeInfinity), PositiveZero},
+		{"Clamp01(NaN)", Clamp01(QuietNaN), PositiveZero},
+		{"Clamp01(-0)", Clamp01(NegativeZero), PositiveZero},
+		{"0.75 + 0.5", mustUnit(t, 0.75).Add(mustUnit(t, 0.5)), One16},
+		{"0.25 + 0.5", mustUnit(t, 0.25).Add(mustUnit(t, 0.5)), FromFloat32(0.75)},
+		{"0.25 - 0.5", mustUnit(t, 0.25).Sub(mustUnit(t, 0.5)), PositiveZero},
+		{"0.5 - 0.5", mustUnit(t, 0.5).Sub(mustUnit(t, 0.5)), PositiveZero},
+		{"1 - 0.25", mustUnit(t, 1).Sub(mustUnit(t, 0.25)), FromFloat32(0.75)},
+		{"0.5 · 0.5", mustUnit(t, 0.5).Mul(mustUnit(t, 0.5)), FromFloat32(0.25)},
+		{"complement of 0.25", mustUnit(t, 0.25).Complement(), FromFloat32(0.75)},
+		{"complement of 0", UnitInterval{}.Complement(), One16},
+	}
+	for _, tt := range tests {
+		if tt.got.Float16() != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestUnitIntervalPercent(t *testing.T) {
+	tests := []struct {
+		f                 float32
+		percent, permille int
+	}{
+		{0, 0, 0},
+		{1, 100, 1000},
+		{0.5, 50, 500},
+		{0.333, 33, 333},  // stored as 0.33300781
+		{0.005, 1, 5},     // stored as 0.0050010681, just above the halfway point
+		{0.995, 100, 995}, // stored as 0.99511719
+		{0.0001, 0, 0},    // stored as 0.000100017
+		{0x1p-24, 0, 0},   // smallest subnormal
+		{1 - 0x1p-11, 100, 1000},
+	}
+	for _, tt := range tests {
+		u := mustUnit(t, tt.f)
+		if got := u.Percent(); got != tt.percent {
+			t.Errorf("%v.Percent() = %d, want %d", u, got, tt.percent)
+		}
+		if got := u.Permille(); got != tt.permille {
+			t.Errorf("%v.Permille() = %d, want %d", u, got, tt.permille)
+		}
+	}
+}
+
+func TestUnitIntervalJSON(t *testing.T) {
+	in := struct {
+		Progress UnitInterval `json:"progress"`
+	}{mustUnit(t, 0.1)}
+	data, err := json.Marshal(in)
+	if err != nil || string(data) != `{"progress":0.1}` {
+		t.Fatalf("json.Marshal = (%s, %v), want a plain number", data, err)
+	}
+	out := in
+	out.Progress = UnitInterval{}
+	if err := json.Unmarshal(data, &out); err != nil || out != in {
+		t.Errorf("round trip = (%v, %v), want %v", out, err, in)
+	}
+	for _, bad := range []string{`{"progress":1.5}`, `{"progress":-0.1}`, `{"progress":"x"}`} {
+		if err := json.Unmarshal([]byte(bad), &out); err == nil {
+			t.Errorf("json.Unmarshal(%s) succeeded", bad)
+		}
+	}
+}
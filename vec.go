@@ -0,0 +1,180 @@
+package float16
+
+import "math"
+
+// Small fixed-size vectors
+//
+// Vec2, Vec3 and Vec4 are value types for graphics-style code. Every method
+// computes in float32 and rounds each output component once, needs no length
+// checks and does not allocate. NaNs and infinities propagate as they do in
+// the scalar operations.
+
+// Vec2 is a two-component Float16 vector
+type Vec2 [2]Float16
+
+// Vec3 is a three-component Float16 vector
+type Vec3 [3]Float16
+
+// Vec4 is a four-component Float16 vector
+type Vec4 [4]Float16
+
+// vector is the set of small vector types; the generic helpers below index
+// them without knowing the length
+type vector interface {
+	~[2]Float16 | ~[3]Float16 | ~[4]Float16
+}
+
+func vecAdd[V vector](a, b V) (r V) {
+	for i := 0; i < len(a); i++ {
+		r[i] = FromFloat32(a[i].ToFloat32() + b[i].ToFloat32())
+	}
+	return r
+}
+
+func vecSub[V vector](a, b V) (r V) {
+	for i := 0; i < len(a); i++ {
+		r[i] = FromFloat32(a[i].ToFloat32() - b[i].ToFloat32())
+	}
+	return r
+}
+
+func vecScale[V vector](a V, s float32) (r V) {
+	for i := 0; i < len(a); i++ {
+		r[i] = FromFloat32(a[i].ToFloat32() * s)
+	}
+	return r
+}
+
+func vecLerp[V vector](a, b V, t float32) (r V) {
+	// The endpoints are exact, as for Lerp, and keep the sign of zeros
+	switch t {
+	case 0:
+		return a
+	case 1:
+		return b
+	}
+	for i := 0; i < len(a); i++ {
+		x := a[i].ToFloat32()
+		r[i] = FromFloat32(x + t*(b[i].ToFloat32()-x))
+	}
+	return r
+}
+
+// vecDot32 returns the dot product in float32; products of Float16 values
+// are exact in float32, so only the sums round
+func vecDot32[V vector](a, b V) float32 {
+	var sum float32
+	for i := 0; i < len(a); i++ {
+		sum += a[i].ToFloat32() * b[i].ToFloat32()
+	}
+	return sum
+}
+
+func vecNormalize[V vector](a V) (r V, ok bool) {
+	length := float32(math.Sqrt(float64(vecDot32(a, a))))
+	if length == 0 || length != length || math.IsInf(float64(length), 0) {
+		return r, false
+	}
+	return vecScale(a, 1/length), true
+}
+
+func vecFromFloat32[V vector, A ~[2]float32 | ~[3]float32 | ~[4]float32](a A) (r V) {
+	for i := 0; i < len(a); i++ {
+		r[i] = FromFloat32(a[i])
+	}
+	return r
+}
+
+func vecToFloat32[A ~[2]float32 | ~[3]float32 | ~[4]float32, V vector](a V) (r A) {
+	for i := 0; i < len(a); i++ {
+		r[i] = a[i].ToFloat32()
+	}
+	return r
+}
+
+// Vec2FromFloat32 rounds each component of a to Float16
+func Vec2FromFloat32(a [2]float32) Vec2 { return vecFromFloat32[Vec2](a) }
+
+// Vec3FromFloat32 rounds each component of a to Float16
+func Vec3FromFloat32(a [3]float32) Vec3 { return vecFromFloat32[Vec3](a) }
+
+// Vec4FromFloat32 rounds each component of a to Float16
+func Vec4FromFloat32(a [4]float32) Vec4 { return vecFromFloat32[Vec4](a) }
+
+// Float32 returns the components of v as float32 values
+func (v Vec2) Float32() [2]float32 { return vecToFloat32[[2]float32](v) }
+
+// Add returns v + o
+func (v Vec2) Add(o Vec2) Vec2 { return vecAdd(v, o) }
+
+// Sub returns v - o
+func (v Vec2) Sub(o Vec2) Vec2 { return vecSub(v, o) }
+
+// Scale returns v·s
+func (v Vec2) Scale(s Float16) Vec2 { return vecScale(v, s.ToFloat32()) }
+
+// Dot returns the dot product of v and o
+func (v Vec2) Dot(o Vec2) Float16 { return FromFloat32(vecDot32(v, o)) }
+
+// Length returns the Euclidean length of v
+func (v Vec2) Length() Float16 { return FromFloat32(float32(math.Sqrt(float64(vecDot32(v, v))))) }
+
+// Normalize returns v scaled to unit length. For a zero vector, or one whose
+// length is NaN or infinite, it returns the zero vector and false.
+func (v Vec2) Normalize() (Vec2, bool) { return vecNormalize(v) }
+
+// Lerp returns v + t·(o - v), interpolating linearly from v at t = 0 to o at
+// t = 1
+func (v Vec2) Lerp(o Vec2, t Float16) Vec2 { return vecLerp(v, o, t.ToFloat32()) }
+
+// Float32 returns the components of v as float32 values
+func (v Vec3) Float32() [3]float32 { return vecToFloat32[[3]float32](v) }
+
+// Add returns v + o
+func (v Vec3) Add(o Vec3) Vec3 { return vecAdd(v, o) }
+
+// Sub returns v - o
+func (v Vec3) Sub(o Vec3) Vec3 { return vecSub(v, o) }
+
+// Scale returns v·s
+func (v Vec3) Scale(s Float16) Vec3 { return vecScale(v, s.ToFloat32()) }
+
+// Dot returns the dot product of v and o
+func (v Vec3) Dot(o Vec3) Float16 { return FromFloat32(vecDot32(v, o)) }
+
+// Length returns the Euclidean length of v
+func (v Vec3) Length() Float16 { return FromFloat32(float32(math.Sqrt(float64(vecDot32(v, v))))) }
+
+// Normalize returns v scaled to unit length. For a zero vector, or one whose
+// length is NaN or infinite, it returns the zero vector and false.
+func (v Vec3) Normalize() (Vec3, bool) { return vecNormalize(v) }
+
+// Lerp returns v + t·(o - v), interpolating linearly from v at t = 0 to o at
+// t = 1
+func (v Vec3) Lerp(o Vec3, t Float16) Vec3 { return vecLerp(v, o, t.ToFloat32()) }
+
+// Float32 returns the components of v as float32 values
+func (v Vec4) Float32() [4]float32 { return vecToFloat32[[4]float32](v) }
+
+// Add returns v + o
+func (v Vec4) Add(o Vec4) Vec4 { return vecAdd(v, o) }
+
+// Sub returns v - o
+func (v Vec4) Sub(o Vec4) Vec4 { return vecSub(v, o) }
+
+// Scale returns v·s
+func (v Vec4) Scale(s Float16) Vec4 { return vecScale(v, s.ToFloat32()) }
+
+// Dot returns the dot product of v and o
+func (v Vec4) Dot(o Vec4) Float16 { return FromFloat32(vecDot32(v, o)) }
+
+// Length returns the Euclidean length of v
+func (v Vec4) Length() Float16 { return FromFloat32(float32(math.Sqrt(float64(vecDot32(v, v))))) }
+
+// Normalize returns v scaled to unit length. For a zero vector, or one whose
+// length is NaN or infinite, it returns the zero vector and false.
+func (v Vec4) Normalize() (Vec4, bool) { return vecNormalize(v) }
+
+// Lerp returns v + t·(o - v), interpolating linearly from v at t = 0 to o at
+// t = 1
+func (v Vec4) Lerp(o Vec4, t Float16) Vec4 { return vecLerp(v, o, t.ToFloat32()) }
@@ -0,0 +1,183 @@
+package float16
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// ulpDistance returns how many representable values lie between a and b
+func ulpDistance(a, b Float16) int {
+	d := int(a.TotalOrderInt()) - int(b.TotalOrderInt())
+	if d < 0 {
+		d = -d
+	}
+	return d
+}
+
+func TestVecAccuracy(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	rnd := func() Float16 { return FromFloat64(r.NormFloat64() * 100) }
+
+	for i := 0; i < 2000; i++ {
+		a := Vec4{rnd(), rnd(), rnd(), rnd()}
+		b := Vec4{rnd(), rnd(), rnd(), rnd()}
+		s, u := rnd(), FromFloat64(r.Float64())
+
+		sum, diff, scaled, lerp := a.Add(b), a.Sub(b), a.Scale(s), a.Lerp(b, u)
+		var dot float64
+		for k := range a {
+			x, y := a[k].ToFloat64(), b[k].ToFloat64()
+			checks := []struct {
+				op   string
+				got  Float16
+				want float64
+			}{
+				{"Add", sum[k], x + y},
+				{"Sub", diff[k], x - y},
+				{"Scale", scaled[k], x * s.ToFloat64()},
+				{"Lerp", lerp[k], x + u.ToFloat64()*(y-x)},
+			}
+			for _, c := range checks {
+				if d := ulpDistance(c.got, FromFloat64(c.want)); d > 1 {
+					t.Fatalf("%v.%s component %d = %v, float64 reference %v", a, c.op, k, c.got, c.want)
+				}
+			}
+			dot += x * y
+		}
+		if d := ulpDistance(a.Dot(b), FromFloat64(dot)); d > 1 {
+			t.Fatalf("%v.Dot(%v) = %v, float64 reference %v", a, b, a.Dot(b), dot)
+		}
+		length := math.Sqrt(sumSquares64(a[:]))
+		if d := ulpDistance(a.Length(), FromFloat64(length)); d > 1 {
+			t.Fatalf("%v.Length() = %v, float64 reference %v", a, a.Length(), length)
+		}
+		if n, ok := a.Normalize(); ok {
+			if l := n.Length().ToFloat64(); math.Abs(l-1) > 2e-3 {
+				t.Fatalf("%v.Normalize() has length %v", a, l)
+			}
+		}
+	}
+}
+
+// sumSquares64 returns the sum of squares of v in float64
+func sumSquares64(v []Float16) float64 {
+	var sum float64
+	for _, x := range v {
+		sum += x.ToFloat64() * x.ToFloat64()
+	}
+	return sum
+}
+
+func TestVecSmallSizes(t *testing.T) {
+	a2, b2 := Vec2{One16, Two16}, Vec2{Three16, Four16}
+	if got := a2.Add(b2); got != (Vec2{Four16, FromFloat32(6)}) {
+		t.Errorf("Vec2.Add = %v", got)
+	}
+	if got := a2.Dot(b2); got != FromFloat32(11) {
+		t.Errorf("Vec2.Dot = %v, want 11", got)
+	}
+	if got := (Vec2{Three16, Four16}).Length(); got != Five16 {
+		t.Errorf("Vec2.Length = %v, want 5", got)
+	}
+
+	a3 := Vec3FromFloat32([3]float32{1, 2, 2})
+	if got := a3.Length(); got != Three16 {
+		t.Errorf("Vec3.Length = %v, want 3", got)
+	}
+	if got := a3.Sub(a3); got != (Vec3{}) {
+		t.Errorf("Vec3.Sub(self) = %v", got)
+	}
+	if got := a3.Scale(Half16).Float32(); got != [3]float32{0.5, 1, 1} {
+		t.Errorf("Vec3.Scale = %v", got)
+	}
+	if got := a3.Lerp(Vec3{}, Half16); got != a3.Scale(Half16) {
+		t.Errorf("Vec3.Lerp = %v", got)
+	}
+	n, ok := a3.Normalize()
+	if !ok || n.Float32() != [3]float32{FromFloat32(1.0 / 3).ToFloat32(), FromFloat32(2.0 / 3).ToFloat32(), FromFloat32(2.0 / 3).ToFloat32()} {
+		t.Errorf("Vec3.Normalize = (%v, %v)", n, ok)
+	}
+
+	a4 := Vec4FromFloat32([4]float32{0.1, 1e5, -2, 0})
+	if a4 != (Vec4{FromFloat32(0.1), PositiveInfinity, FromFloat32(-2), PositiveZero}) {
+		t.Errorf("Vec4FromFloat32 = %v", a4)
+	}
+	if a4.Float32() != [4]float32{FromFloat32(0.1).ToFloat32(), float32(math.Inf(1)), -2, 0} {
+		t.Errorf("Vec4.Float32 = %v", a4.Float32())
+	}
+}
+
+func TestVecSpecialValues(t *testing.T) {
+	for _, v := range []Vec3{{}, {NegativeZero, PositiveZero, NegativeZero}, {QuietNaN, One16, One16}, {PositiveInfinity, One16, One16}} {
+		n, ok := v.Normalize()
+		if ok || n != (Vec3{}) {
+			t.Errorf("%v.Normalize() = (%v, %v), want zero vector and false", v, n, ok)
+		}
+	}
+
+	// Components follow the scalar operations
+	a := Vec4{PositiveInfinity, QuietNaN, MaxValue, NegativeZero}
+	b := Vec4{NegativeInfinity, One16, MaxValue, NegativeZero}
+	sum := a.Add(b)
+	for k := range a {
+		want := Add(a[k], b[k])
+		if sum[k] != want && !(sum[k].IsNaN() && want.IsNaN()) {
+			t.Errorf("Add component %d = %v, scalar Add gives %v", k, sum[k], want)
+		}
+	}
+	if got := a.Dot(b); !got.IsNaN() {
+		t.Errorf("Dot with NaN = %v", got)
+	}
+	if got := (Vec2{MaxValue, MaxValue}).Length(); got != PositiveInfinity {
+		t.Errorf("Length past MaxValue = %v, want +Inf", got)
+	}
+}
+
+func TestVecAllocations(t *testing.T) {
+	a, b := Vec4{One16, Two16, Three16, Four16}, Vec4{Half16, Half16, Two16, One16}
+	var sink Vec4
+	var s Float16
+	allocs := testing.AllocsPerRun(100, func() {
+		sink = a.Add(b).Sub(b).Scale(Two16).Lerp(b, Half16)
+		s = Add(sink.Dot(a), sink.Length())
+		sink, _ = sink.Normalize()
+	})
+	if allocs != 0 {
+		t.Errorf("vector ops allocate %v times per run", allocs)
+	}
+	_ = s
+}
+
+func BenchmarkVec4(b *testing.B) {
+	x, y := Vec4{One16, Two16, Three16, Four16}, Vec4{Half16, Half16, Two16, One16}
+	xs, ys := x[:], y[:]
+	var sink Vec4
+	var dot Float16
+
+	b.Run("Add/Vec4", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sink = x.Add(y)
+		}
+	})
+	b.Run("Add/AddSlice", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			copy(sink[:], AddSlice(xs, ys))
+		}
+	})
+	b.Run("Dot/Vec4", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			dot = x.Dot(y)
+		}
+	})
+	b.Run("Dot/DotProduct", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			dot = DotProduct(xs, ys)
+		}
+	})
+	_, _ = sink, dot
+}
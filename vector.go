@@ -0,0 +1,231 @@
+package float16
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// Vector operations
+//
+// These functions accumulate in float32 or float64 and round each output to
+// Float16 once, so they do not overflow or lose precision in the way that
+// chaining the element-wise Float16 operations would.
+
+// Normalize returns s scaled to unit L2 norm. The norm is computed in float32,
+// which cannot overflow for Float16 inputs. A zero vector is returned as all
+// zeros rather than NaN.
+func Normalize(s []Float16) []Float16 {
+	dst := make([]Float16, len(s))
+	NormalizeInto(dst, s)
+	return dst
+}
+
+// NormalizeInto writes s scaled to unit L2 norm into dst, which must have the
+// same length as s. dst may alias s. A zero vector produces all zeros.
+func NormalizeInto(dst, s []Float16) {
+	if len(dst) != len(s) {
+		panic("float16: slice length mismatch")
+	}
+	var sumSquares float32
+	for _, v := range s {
+		f := v.ToFloat32()
+		sumSquares += f * f
+	}
+	scaleInto(dst, s, float32(math.Sqrt(float64(sumSquares))))
+}
+
+// FusedNormalize returns s divided by sqrt(mean(s²) + eps), the RMSNorm
+// scaling without a learned gain. See FusedNormalizeInto.
+func FusedNormalize(s []Float16, eps Float16) []Float16 {
+	dst := make([]Float16, len(s))
+	FusedNormalizeInto(dst, s, eps)
+	return dst
+}
+
+// FusedNormalizeInto writes s divided by sqrt(mean(s²) + eps) into dst,
+// which must have the same length as s. dst may alias s. The mean of the
+// squares is accumulated in float32 in a single pass, and each element is
+// multiplied by the float32 reciprocal root and rounded once. eps should not
+// be negative: a positive eps keeps an all-zero s finite, while with eps 0 an
+// all-zero s gives zeros of the same signs. A NaN in s or eps makes every
+// element NaN.
+func FusedNormalizeInto(dst, s []Float16, eps Float16) {
+	if len(dst) != len(s) {
+		panic("float16: slice length mismatch")
+	}
+	if len(s) == 0 {
+		return
+	}
+	var sumSquares float32
+	for _, v := range s {
+		f := v.ToFloat32()
+		sumSquares += f * f
+	}
+	meanSquare := sumSquares/float32(len(s)) + eps.ToFloat32()
+	if meanSquare == 0 {
+		scaleInto(dst, s, 0)
+		return
+	}
+	// A Float16 times a float32 is exact in float64, so FromFloat64 rounds
+	// the product only once
+	inv := float64(float32(1 / math.Sqrt(float64(meanSquare))))
+	for i, v := range s {
+		dst[i] = FromFloat64(v.ToFloat64() * inv)
+	}
+}
+
+// NormalizeL1 returns s scaled so that the sum of absolute values is 1. A zero
+// vector is returned as all zeros rather than NaN.
+func NormalizeL1(s []Float16) []Float16 {
+	var sumAbs float32
+	for _, v := range s {
+		sumAbs += v.Abs().ToFloat32()
+	}
+	dst := make([]Float16, len(s))
+	scaleInto(dst, s, sumAbs)
+	return dst
+}
+
+// scaleInto writes s divided by norm into dst, writing zeros when norm is zero
+func scaleInto(dst, s []Float16, norm float32) {
+	if norm == 0 {
+		for i, v := range s {
+			dst[i] = v & SignMask
+		}
+		return
+	}
+	for i, v := range s {
+		dst[i] = FromFloat32(v.ToFloat32() / norm)
+	}
+}
+
+// Center returns s minus its mean. The mean is accumulated in float32 with
+// compensated summation, so long slices do not drift.
+func Center(s []Float16) []Float16 {
+	mean := mean32(s)
+	dst := make([]Float16, len(s))
+	for i, v := range s {
+		dst[i] = FromFloat32(v.ToFloat32() - mean)
+	}
+	return dst
+}
+
+// Standardize returns (s-mean)/stddev using the population standard
+// deviation. Input with zero variance, including empty and single-element
+// slices, is returned as all zeros rather than NaN.
+func Standardize(s []Float16) []Float16 {
+	mean := mean32(s)
+	var sum, c float32
+	for _, v := range s {
+		d := v.ToFloat32() - mean
+		var e float32
+		sum, e = twoSum32(sum, d*d)
+		c += e
+	}
+	dst := make([]Float16, len(s))
+	stddev := float32(math.Sqrt(float64((sum + c) / float32(len(s)))))
+	if stddev == 0 {
+		return dst
+	}
+	for i, v := range s {
+		dst[i] = FromFloat32((v.ToFloat32() - mean) / stddev)
+	}
+	return dst
+}
+
+// mean32 returns the mean of s using compensated float32 summation
+func mean32(s []Float16) float32 {
+	if len(s) == 0 {
+		return 0
+	}
+	var sum, c float32
+	for _, v := range s {
+		var e float32
+		sum, e = twoSum32(sum, v.ToFloat32())
+		c += e
+	}
+	return (sum + c) / float32(len(s))
+}
+
+// MeanPow2 returns the mean of s, whose length must be a power of two. The
+// sum is formed by pairwise reduction in float32 and then divided by the
+// length with math.Ldexp, which only subtracts from the exponent and so is
+// exact. The only roundings are in the summation, bounded by
+// log2(len(s))·2^-24·mean(|s|), and the final rounding to Float16, at most
+// half an ULP of the result. A window of identical values returns that value
+// exactly. It returns an error if len(s) is zero or not a power of two.
+func MeanPow2(s []Float16) (Float16, error) {
+	n := len(s)
+	if n == 0 || n&(n-1) != 0 {
+		return 0, &Float16Error{
+			Op:   "MeanPow2",
+			Msg:  fmt.Sprintf("length %d is not a power of two", n),
+			Code: ErrInvalidOperation,
+		}
+	}
+	sum := pairwiseSum32(s)
+	return FromFloat32(float32(math.Ldexp(float64(sum), -bits.TrailingZeros(uint(n))))), nil
+}
+
+// pairwiseSum32 sums the non-empty s in float32 by recursive halving, so
+// rounding error grows with the logarithm of the length rather than the
+// length. Each leaf starts from its first element rather than +0, so a sum of
+// -0 terms stays -0.
+func pairwiseSum32(s []Float16) float32 {
+	if len(s) <= 8 {
+		sum := s[0].ToFloat32()
+		for _, v := range s[1:] {
+			sum += v.ToFloat32()
+		}
+		return sum
+	}
+	half := len(s) / 2
+	return pairwiseSum32(s[:half]) + pairwiseSum32(s[half:])
+}
+
+// Diff returns the first differences of s: out[i] = Sub(s[i+1], s[i]), a
+// slice of length len(s)-1. Slices shorter than two elements give an empty
+// result. Diff is the inverse of CumSum up to the first element: s[i] equals
+// s[0] plus CumSum(Diff(s))[i-1], within Float16 rounding.
+func Diff(s []Float16) []Float16 {
+	if len(s) < 2 {
+		return []Float16{}
+	}
+	out := make([]Float16, len(s)-1)
+	for i := range out {
+		out[i] = Sub(s[i+1], s[i])
+	}
+	return out
+}
+
+// Diff2 returns the second differences of s, Diff(Diff(s)), a slice of
+// length len(s)-2, or an empty slice for fewer than three elements.
+func Diff2(s []Float16) []Float16 {
+	return Diff(Diff(s))
+}
+
+// CumSum returns the running sums of s: out[i] = s[0] + ... + s[i], each
+// step computed with Add.
+func CumSum(s []Float16) []Float16 {
+	out := make([]Float16, len(s))
+	sum := PositiveZero
+	for i, v := range s {
+		sum = Add(sum, v)
+		out[i] = sum
+	}
+	return out
+}
+
+// Outer returns the len(a)×len(b) outer product of a and b in row-major
+// order, with out[i*len(b)+j] = Mul(a[i], b[j]).
+func Outer(a, b []Float16) []Float16 {
+	out := make([]Float16, len(a)*len(b))
+	for i, x := range a {
+		row := out[i*len(b) : (i+1)*len(b)]
+		for j, y := range b {
+			row[j] = Mul(x, y)
+		}
+	}
+	return out
+}
@@ -0,0 +1,364 @@
+package float16
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	s := []Float16{FromInt(3), FromInt(-4), FromInt(12)}
+	got := Normalize(s)
+	want := []float64{3.0 / 13, -4.0 / 13, 12.0 / 13}
+	for i := range got {
+		if got[i] != FromFloat64(want[i]) {
+			t.Errorf("Normalize()[%d] = %v, want %v", i, got[i], FromFloat64(want[i]))
+		}
+	}
+	if n := Norm2(got).ToFloat64(); math.Abs(n-1) > 1e-3 {
+		t.Errorf("norm of result = %v, want ~1", n)
+	}
+
+	// Values whose squares overflow Float16 arithmetic
+	big := []Float16{MaxValue, MaxValue, MinValue, MaxValue}
+	for i, v := range Normalize(big) {
+		if math.Abs(math.Abs(v.ToFloat64())-0.5) > 1e-3 {
+			t.Errorf("Normalize(big)[%d] = %v, want ±0.5", i, v)
+		}
+	}
+
+	for i, v := range Normalize([]Float16{PositiveZero, NegativeZero, PositiveZero}) {
+		if !v.IsZero() {
+			t.Errorf("zero vector element %d = %v, want 0", i, v)
+		}
+	}
+	if len(Normalize(nil)) != 0 {
+		t.Error("Normalize(nil) should be empty")
+	}
+}
+
+func TestNormalizeIntoAliasing(t *testing.T) {
+	s := []Float16{FromInt(0), FromInt(5), FromInt(0)}
+	NormalizeInto(s, s)
+	if s[1] != One() {
+		t.Errorf("in-place NormalizeInto = %v, want [0 1 0]", s)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic on length mismatch")
+		}
+	}()
+	NormalizeInto(make([]Float16, 2), s)
+}
+
+func TestNormalizeL1(t *testing.T) {
+	s := []Float16{FromInt(1), FromInt(-2), FromInt(5)}
+	got := NormalizeL1(s)
+	var sum float64
+	for i, v := range got {
+		ref := s[i].ToFloat64() / 8
+		if v != FromFloat64(ref) {
+			t.Errorf("NormalizeL1()[%d] = %v, want %v", i, v, ref)
+		}
+		sum += math.Abs(v.ToFloat64())
+	}
+	if math.Abs(sum-1) > 1e-3 {
+		t.Errorf("L1 norm of result = %v, want ~1", sum)
+	}
+	for _, v := range NormalizeL1([]Float16{PositiveZero, PositiveZero}) {
+		if !v.IsZero() {
+			t.Errorf("zero vector produced %v", v)
+		}
+	}
+}
+
+func TestNormalizeAgainstFloat64(t *testing.T) {
+	s := make([]Float16, 257)
+	for i := range s {
+		s[i] = FromFloat64(math.Sin(float64(i)) * 100)
+	}
+	var sumSquares float64
+	for _, v := range s {
+		sumSquares += v.ToFloat64() * v.ToFloat64()
+	}
+	norm := math.Sqrt(sumSquares)
+	for i, v := range Normalize(s) {
+		want := s[i].ToFloat64() / norm
+		if diff := math.Abs(v.ToFloat64() - want); diff > math.Abs(want)*1e-3+1e-7 {
+			t.Errorf("element %d: got %v, want %v", i, v, want)
+		}
+	}
+}
+
+// fusedNormalizeRef is FusedNormalize computed in float64
+func fusedNormalizeRef(s []Float16, eps Float16) []Float16 {
+	var sumSquares float64
+	for _, v := range s {
+		sumSquares += v.ToFloat64() * v.ToFloat64()
+	}
+	root := math.Sqrt(sumSquares/float64(len(s)) + eps.ToFloat64())
+	dst := make([]Float16, len(s))
+	for i, v := range s {
+		dst[i] = FromFloat64(v.ToFloat64() / root)
+	}
+	return dst
+}
+
+func TestFusedNormalize(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	eps := FromFloat64(1e-5)
+	for _, n := range []int{1, 7, 64, 4096} {
+		for _, scale := range []float64{1e-4, 1, 300} {
+			s := make([]Float16, n)
+			for i := range s {
+				s[i] = FromFloat64(r.NormFloat64() * scale)
+			}
+			got, want := FusedNormalize(s, eps), fusedNormalizeRef(s, eps)
+			for i := range s {
+				if d := ulpDistance(got[i], want[i]); d > 2 {
+					t.Errorf("n=%d scale=%g [%d]: %v, want %v (%d ULP)", n, scale, i, got[i], want[i], d)
+				}
+			}
+		}
+	}
+
+	zeros := []Float16{PositiveZero, NegativeZero}
+	for _, e := range []Float16{eps, PositiveZero} {
+		got := FusedNormalize(zeros, e)
+		if got[0] != PositiveZero || got[1] != NegativeZero {
+			t.Errorf("all-zero input with eps %v = %v, want [0 -0]", e, got)
+		}
+	}
+	for i, v := range FusedNormalize([]Float16{One16, QuietNaN, Two16}, eps) {
+		if !v.IsNaN() {
+			t.Errorf("NaN input: element %d = %v, want NaN", i, v)
+		}
+	}
+	if !FusedNormalize([]Float16{One16}, QuietNaN)[0].IsNaN() {
+		t.Error("NaN eps did not propagate")
+	}
+	if len(FusedNormalize(nil, eps)) != 0 {
+		t.Error("FusedNormalize(nil) should be empty")
+	}
+
+	s := []Float16{Three16, Three16.Neg()}
+	FusedNormalizeInto(s, s, PositiveZero)
+	if s[0] != One16 || s[1] != negOne16 {
+		t.Errorf("in-place FusedNormalizeInto = %v, want [1 -1]", s)
+	}
+	if !panics(func() { FusedNormalizeInto(make([]Float16, 1), s, eps) }) {
+		t.Error("FusedNormalizeInto did not panic on length mismatch")
+	}
+}
+
+func BenchmarkFusedNormalize(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	s := make([]Float16, 4096)
+	for i := range s {
+		s[i] = FromFloat64(r.NormFloat64())
+	}
+	eps := FromFloat64(1e-5)
+
+	b.Run("Fused", func(b *testing.B) {
+		dst := make([]Float16, len(s))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			FusedNormalizeInto(dst, s, eps)
+		}
+	})
+	b.Run("Composed", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			meanSquare := Add(Div(SumSlice(MulSlice(s, s)), FromInt(len(s))), eps)
+			_ = ScaleSlice(s, Rsqrt(meanSquare))
+		}
+	})
+}
+
+func TestOuter(t *testing.T) {
+	a := []Float16{FromInt(1), FromInt(-2), FromFloat32(0.5)}
+	b := []Float16{FromInt(3), FromInt(4)}
+	got := Outer(a, b)
+	want := []Float16{
+		FromInt(3), FromInt(4),
+		FromInt(-6), FromInt(-8),
+		FromFloat32(1.5), FromInt(2),
+	}
+	if len(got) != len(a)*len(b) {
+		t.Fatalf("len = %d, want %d", len(got), len(a)*len(b))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Outer()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if len(Outer(a, nil)) != 0 || len(Outer(nil, b)) != 0 {
+		t.Error("outer product with an empty vector should be empty")
+	}
+	// Products are rounded to Float16 individually
+	if got := Outer([]Float16{MaxValue}, []Float16{FromInt(2)}); !got[0].IsInf(1) {
+		t.Errorf("overflowing product = %v, want +Inf", got[0])
+	}
+}
+
+func TestCenterAndStandardize(t *testing.T) {
+	s := make([]Float16, 1000)
+	for i := range s {
+		s[i] = FromFloat64(50 + 10*math.Sin(float64(i)*0.7))
+	}
+	var mean float64
+	for _, v := range s {
+		mean += v.ToFloat64()
+	}
+	mean /= float64(len(s))
+	var variance float64
+	for _, v := range s {
+		variance += (v.ToFloat64() - mean) * (v.ToFloat64() - mean)
+	}
+	stddev := math.Sqrt(variance / float64(len(s)))
+
+	centered := Center(s)
+	var sum float64
+	for i, v := range centered {
+		want := s[i].ToFloat64() - mean
+		if math.Abs(v.ToFloat64()-want) > math.Abs(want)*1e-3+1e-3 {
+			t.Errorf("Center()[%d] = %v, want %v", i, v, want)
+		}
+		sum += v.ToFloat64()
+	}
+	if m := sum / float64(len(s)); math.Abs(m) > 1e-3 {
+		t.Errorf("mean of centered slice = %v, want ~0", m)
+	}
+
+	standardized := Standardize(s)
+	var m2, sq float64
+	for i, v := range standardized {
+		want := (s[i].ToFloat64() - mean) / stddev
+		if math.Abs(v.ToFloat64()-want) > math.Abs(want)*1e-3+1e-3 {
+			t.Errorf("Standardize()[%d] = %v, want %v", i, v, want)
+		}
+		m2 += v.ToFloat64()
+		sq += v.ToFloat64() * v.ToFloat64()
+	}
+	m2 /= float64(len(s))
+	if v := sq/float64(len(s)) - m2*m2; math.Abs(v-1) > 1e-2 {
+		t.Errorf("variance of standardized slice = %v, want ~1", v)
+	}
+}
+
+func TestStandardizeZeroVariance(t *testing.T) {
+	tests := []struct {
+		name string
+		s    []Float16
+	}{
+		{"empty", nil},
+		{"single", []Float16{FromInt(7)}},
+		{"constant", []Float16{FromInt(3), FromInt(3), FromInt(3)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Standardize(tt.s)
+			if len(got) != len(tt.s) {
+				t.Fatalf("len = %d, want %d", len(got), len(tt.s))
+			}
+			for i, v := range got {
+				if !v.IsZero() {
+					t.Errorf("Standardize()[%d] = %v, want 0", i, v)
+				}
+			}
+			for i, v := range Center(tt.s) {
+				if !v.IsZero() {
+					t.Errorf("Center()[%d] = %v, want 0", i, v)
+				}
+			}
+		})
+	}
+}
+
+func TestMeanPow2(t *testing.T) {
+	for _, v := range []Float16{SmallestSubnormal, FromFloat32(0.1), FromBits(0x3BFF), MaxValue, MinValue} {
+		for k := 0; k <= 14; k++ {
+			s := make([]Float16, 1<<k)
+			for i := range s {
+				s[i] = v
+			}
+			got, err := MeanPow2(s)
+			if err != nil || got != v {
+				t.Fatalf("MeanPow2 of %d copies of %v = %v, %v", len(s), v, got, err)
+			}
+		}
+	}
+
+	rng := rand.New(rand.NewSource(21))
+	for _, n := range []int{2, 16, 256, 4096} {
+		s := make([]Float16, n)
+		var sum, sumAbs float64
+		for i := range s {
+			s[i] = FromFloat64(rng.NormFloat64()*100 + 20)
+			sum += s[i].ToFloat64()
+			sumAbs += math.Abs(s[i].ToFloat64())
+		}
+		want := sum / float64(n)
+		got, err := MeanPow2(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ulp := math.Abs(NextAfter(got, PositiveInfinity).ToFloat64() - got.ToFloat64())
+		bound := ulp/2 + math.Log2(float64(n))*0x1p-24*sumAbs/float64(n)
+		if diff := math.Abs(got.ToFloat64() - want); diff > bound {
+			t.Errorf("n=%d: MeanPow2 = %v, float64 mean %v, error %v exceeds bound %v", n, got, want, diff, bound)
+		}
+	}
+
+	for _, n := range []int{0, 3, 6, 1000} {
+		_, err := MeanPow2(make([]Float16, n))
+		var fe *Float16Error
+		if !errors.As(err, &fe) || fe.Code != ErrInvalidOperation {
+			t.Errorf("MeanPow2 of length %d: err = %v, want ErrInvalidOperation", n, err)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	squares := []Float16{FromInt(0), FromInt(1), FromInt(4), FromInt(9), FromInt(16)}
+	if got, want := Diff(squares), []Float16{FromInt(1), FromInt(3), FromInt(5), FromInt(7)}; !slices.Equal(got, want) {
+		t.Errorf("Diff(squares) = %v, want %v", got, want)
+	}
+	if got, want := Diff2(squares), []Float16{FromInt(2), FromInt(2), FromInt(2)}; !slices.Equal(got, want) {
+		t.Errorf("Diff2(squares) = %v, want %v", got, want)
+	}
+	if got := CumSum([]Float16{One(), FromInt(2), FromInt(-3)}); !slices.Equal(got, []Float16{One(), FromInt(3), PositiveZero}) {
+		t.Errorf("CumSum = %v", got)
+	}
+
+	for _, s := range [][]Float16{nil, {One()}} {
+		if got := Diff(s); got == nil || len(got) != 0 {
+			t.Errorf("Diff(%v) = %#v, want empty", s, got)
+		}
+	}
+	if got := Diff2([]Float16{One(), FromInt(2)}); len(got) != 0 {
+		t.Errorf("Diff2 of two elements = %v, want empty", got)
+	}
+	if got := Diff([]Float16{MaxValue, MinValue}); !got[0].IsInf(-1) {
+		t.Errorf("overflowing difference = %v, want -Inf", got[0])
+	}
+}
+
+func TestCumSumInvertsDiff(t *testing.T) {
+	s := make([]Float16, 200)
+	for i := range s {
+		s[i] = FromFloat64(math.Sin(float64(i)*0.1) * 10)
+	}
+	sums := CumSum(Diff(s))
+	for i := 1; i < len(s); i++ {
+		got := Add(s[0], sums[i-1])
+		// Each Sub and Add rounds, so allow a few ULPs at the scale of the data
+		if math.Abs(got.ToFloat64()-s[i].ToFloat64()) > 0.05 {
+			t.Fatalf("reconstructed s[%d] = %v, want %v", i, got, s[i])
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package float16
+
+// Exhaustive verification helpers
+//
+// These helpers compare a Float16 operation against a float64 reference over
+// every input bit pattern. Running them is slow for binary operations, so
+// downstream projects typically call them from tests guarded by their own
+// build tag; this package does the same with the "exhaustive" tag.
+
+// UlpDistance returns the number of representable Float16 values between a
+// and b. Signed zeros are treated as the same value. Two NaNs are at distance
+// 0, and a NaN is at the maximum distance from any non-NaN value.
+func UlpDistance(a, b Float16) uint16 {
+	aNaN, bNaN := a.IsNaN(), b.IsNaN()
+	if aNaN || bNaN {
+		if aNaN && bNaN {
+			return 0
+		}
+		return 0xFFFF
+	}
+	ka, kb := zeroCollapsedKey(a), zeroCollapsedKey(b)
+	if ka > kb {
+		return uint16(ka - kb)
+	}
+	return uint16(kb - ka)
+}
+
+// zeroCollapsedKey is OrderedKey with -0 mapped onto +0, so that the
+// smallest subnormals of either sign are one step from zero
+func zeroCollapsedKey(f Float16) int32 {
+	k := int32(f.OrderedKey())
+	if f&SignMask == 0 {
+		k--
+	}
+	return k
+}
+
+// ExhaustiveVerifyUnary evaluates op for all 65536 inputs and compares each
+// result with ref applied to the exact float64 value of the input and rounded
+// to Float16. It returns the inputs whose results differ by more than tolULP
+// units in the last place (see UlpDistance), in ascending bit order.
+func ExhaustiveVerifyUnary(op func(Float16) Float16, ref func(float64) float64, tolULP uint16) []Float16 {
+	var failures []Float16
+	for i := 0; i < 1<<16; i++ {
+		x := Float16(i)
+		want := FromFloat64(ref(x.ToFloat64()))
+		if UlpDistance(op(x), want) > tolULP {
+			failures = append(failures, x)
+		}
+	}
+	return failures
+}
+
+// ExhaustiveVerifyBinary is the two-operand form of ExhaustiveVerifyUnary.
+// Every first operand is tested against every stride-th second operand
+// pattern; a stride of 1 checks all 2^32 pairs. It returns the failing pairs.
+func ExhaustiveVerifyBinary(op func(a, b Float16) Float16, ref func(a, b float64) float64, tolULP uint16, stride int) [][2]Float16 {
+	if stride < 1 {
+		stride = 1
+	}
+	var failures [][2]Float16
+	for i := 0; i < 1<<16; i++ {
+		a := Float16(i)
+		af := a.ToFloat64()
+		for j := 0; j < 1<<16; j += stride {
+			b := Float16(j)
+			want := FromFloat64(ref(af, b.ToFloat64()))
+			if UlpDistance(op(a, b), want) > tolULP {
+				failures = append(failures, [2]Float16{a, b})
+			}
+		}
+	}
+	return failures
+}
@@ -0,0 +1,68 @@
+package float16
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUlpDistance(t *testing.T) {
+	tests := []struct {
+		a, b Float16
+		want uint16
+	}{
+		{One(), One(), 0},
+		{One(), NextAfter(One(), PositiveInfinity), 1},
+		{PositiveZero, NegativeZero, 0},
+		{SmallestSubnormal, SmallestSubnormal | SignMask, 2},
+		{NegativeZero, SmallestSubnormal, 1},
+		{MaxValue, PositiveInfinity, 1},
+		{QuietNaN, NegativeQNaN, 0},
+		{QuietNaN, One(), 0xFFFF},
+	}
+	for _, tt := range tests {
+		if got := UlpDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("UlpDistance(%v, %v) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := UlpDistance(tt.b, tt.a); got != tt.want {
+			t.Errorf("UlpDistance(%v, %v) = %d, want %d", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestExhaustiveVerifyUnary(t *testing.T) {
+	if failures := ExhaustiveVerifyUnary(Abs, math.Abs, 0); len(failures) != 0 {
+		t.Errorf("Abs: %d failures, first %v", len(failures), failures[0])
+	}
+
+	// Off by one ULP above 1: fails with tolerance 0, passes with tolerance 1
+	broken := func(f Float16) Float16 {
+		if f.IsFinite() && Greater(f, One()) && !f.IsInf(0) && f != MaxValue {
+			return f + 1
+		}
+		return f
+	}
+	identity := func(x float64) float64 { return x }
+	failures := ExhaustiveVerifyUnary(broken, identity, 0)
+	if len(failures) == 0 {
+		t.Fatal("broken op reported no failures")
+	}
+	for _, f := range failures {
+		if !Greater(f, One()) {
+			t.Fatalf("unexpected failing input %v", f)
+		}
+	}
+	if failures := ExhaustiveVerifyUnary(broken, identity, 1); len(failures) != 0 {
+		t.Errorf("tolerance 1 still reports %d failures", len(failures))
+	}
+}
+
+func TestExhaustiveVerifyBinarySampled(t *testing.T) {
+	add := func(a, b float64) float64 { return a + b }
+	if failures := ExhaustiveVerifyBinary(Add, add, 0, 4099); len(failures) != 0 {
+		t.Errorf("Add: %d failures, first %v", len(failures), failures[0])
+	}
+	sub := func(a, b Float16) Float16 { return Sub(a, b) }
+	if failures := ExhaustiveVerifyBinary(sub, add, 0, 8191); len(failures) == 0 {
+		t.Error("Sub checked against addition should fail")
+	}
+}
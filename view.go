@@ -0,0 +1,69 @@
+package float16
+
+// Conversion views
+//
+// A View32 reads a []float32 buffer as Float16 values and a View16 reads a
+// []Float16 buffer as float32 values, converting one element per access.
+// When only a few elements of a large buffer are read, this avoids
+// materializing a converted copy. SumReader and DotReader stream over any
+// Reader, so a reduction over a view needs no conversion buffer either.
+
+// Reader is read-only indexed access to a sequence of Float16 values
+type Reader interface {
+	Len() int
+	At(i int) Float16
+}
+
+// View32 reads a []float32 as Float16 values, converting each element on
+// access with FromFloat32. Converting a slice to View32 does not copy it.
+type View32 []float32
+
+// Len returns the number of elements
+func (v View32) Len() int { return len(v) }
+
+// At returns element i converted to Float16
+func (v View32) At(i int) Float16 { return FromFloat32(v[i]) }
+
+// Materialize returns all elements converted to Float16, as ToSlice16 does
+func (v View32) Materialize() []Float16 { return ToSlice16(v) }
+
+// View16 reads a []Float16 as float32 values, converting each element on
+// access. It also implements Reader, returning the elements unchanged.
+// Converting a slice to View16 does not copy it.
+type View16 []Float16
+
+// Len returns the number of elements
+func (v View16) Len() int { return len(v) }
+
+// At returns element i
+func (v View16) At(i int) Float16 { return v[i] }
+
+// AtFloat32 returns element i converted exactly to float32
+func (v View16) AtFloat32(i int) float32 { return v[i].ToFloat32() }
+
+// Materialize returns all elements converted to float32, as ToSlice32 does
+func (v View16) Materialize() []float32 { return ToSlice32(v) }
+
+// SumReader returns the sum of the elements of r, added in order as SumSlice
+// does, so SumReader(View32(s)) equals SumSlice(ToSlice16(s)) without
+// allocating the converted slice.
+func SumReader[R Reader](r R) Float16 {
+	sum := PositiveZero
+	for i := range r.Len() {
+		sum = Add(sum, r.At(i))
+	}
+	return sum
+}
+
+// DotReader returns the dot product of a and b, accumulated in order as
+// DotProduct does. It panics if the lengths differ.
+func DotReader[A, B Reader](a A, b B) Float16 {
+	if a.Len() != b.Len() {
+		panic("float16: slice length mismatch")
+	}
+	sum := PositiveZero
+	for i := range a.Len() {
+		sum = Add(sum, Mul(a.At(i), b.At(i)))
+	}
+	return sum
+}
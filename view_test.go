@@ -0,0 +1,117 @@
+package float16
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func viewTestData(n int) []float32 {
+	r := rand.New(rand.NewSource(7))
+	s := make([]float32, n)
+	for i := range s {
+		s[i] = float32(r.NormFloat64() * 100)
+	}
+	s[1], s[2] = float32(math.Copysign(0, -1)), 1e-7 // -0 and a subnormal
+	return s
+}
+
+func TestViews(t *testing.T) {
+	f32 := viewTestData(1000)
+	v32 := View32(f32)
+	f16 := v32.Materialize()
+	if !slices.Equal(f16, ToSlice16(f32)) {
+		t.Fatal("View32.Materialize differs from ToSlice16")
+	}
+	if v32.Len() != len(f32) {
+		t.Errorf("View32.Len() = %d, want %d", v32.Len(), len(f32))
+	}
+	for i := range f32 {
+		if v32.At(i) != f16[i] {
+			t.Fatalf("View32.At(%d) = %#04x, want %#04x", i, uint16(v32.At(i)), uint16(f16[i]))
+		}
+	}
+
+	v16 := View16(f16)
+	back := v16.Materialize()
+	if !slices.Equal(back, ToSlice32(f16)) {
+		t.Fatal("View16.Materialize differs from ToSlice32")
+	}
+	for i := range f16 {
+		if math.Float32bits(v16.AtFloat32(i)) != math.Float32bits(back[i]) || v16.At(i) != f16[i] {
+			t.Fatalf("View16 element %d = %v, %v, want %v", i, v16.AtFloat32(i), v16.At(i), back[i])
+		}
+	}
+
+	if got, want := SumReader(v32), SumSlice(f16); got != want {
+		t.Errorf("SumReader(View32) = %v, SumSlice = %v", got, want)
+	}
+	if got, want := SumReader(v16), SumSlice(f16); got != want {
+		t.Errorf("SumReader(View16) = %v, SumSlice = %v", got, want)
+	}
+	weights := View16(Reversed(f16))
+	if got, want := DotReader(v32, weights), DotProduct(f16, weights); got != want {
+		t.Errorf("DotReader = %v, DotProduct = %v", got, want)
+	}
+	if SumReader(View32(nil)) != PositiveZero {
+		t.Error("SumReader of an empty view is not +0")
+	}
+	if !panics(func() { DotReader(v32, v16[1:]) }) {
+		t.Error("DotReader did not panic on a length mismatch")
+	}
+}
+
+// TestViewAllocs checks that sparse access and streaming reductions over
+// views allocate nothing, where materializing allocates the whole slice
+func TestViewAllocs(t *testing.T) {
+	v32 := View32(viewTestData(1 << 16))
+	v16 := View16(v32.Materialize())
+	var sink Float16
+	var sink32 float32
+	sparse := testing.AllocsPerRun(100, func() {
+		for i := 0; i < v32.Len(); i += 4096 {
+			sink ^= v32.At(i)
+			sink32 += v16.AtFloat32(i)
+		}
+	})
+	reduce := testing.AllocsPerRun(10, func() {
+		sink ^= SumReader(v32) ^ DotReader(v32, v16)
+	})
+	materialize := testing.AllocsPerRun(10, func() {
+		sink ^= v32.Materialize()[0]
+	})
+	if sparse != 0 || reduce != 0 {
+		t.Errorf("views allocate: %v per sparse pass, %v per reduction", sparse, reduce)
+	}
+	if materialize == 0 {
+		t.Error("Materialize does not allocate")
+	}
+	_, _ = sink, sink32
+}
+
+func BenchmarkViewSparse(b *testing.B) {
+	f32 := viewTestData(1 << 20)
+	b.Run("View32", func(b *testing.B) {
+		b.ReportAllocs()
+		var sink Float16
+		for i := 0; i < b.N; i++ {
+			v := View32(f32)
+			for j := 0; j < v.Len(); j += 1 << 14 {
+				sink ^= v.At(j)
+			}
+		}
+		_ = sink
+	})
+	b.Run("Materialize", func(b *testing.B) {
+		b.ReportAllocs()
+		var sink Float16
+		for i := 0; i < b.N; i++ {
+			s := ToSlice16(f32)
+			for j := 0; j < len(s); j += 1 << 14 {
+				sink ^= s[j]
+			}
+		}
+		_ = sink
+	})
+}